@@ -0,0 +1,325 @@
+package telegrambot
+
+// Archiving incoming updates (and outgoing messages) as JSON lines, for retention and offline analytics.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// default maximum size of each archive file
+	defaultRotatingFileMaxSize = 100 * 1024 * 1024
+
+	// replacement of redacted values
+	archiveRedactedValue = "[REDACTED]"
+)
+
+// ArchiveRecordKind is a kind of ArchiveRecord
+type ArchiveRecordKind string
+
+// ArchiveRecordKind strings
+const (
+	ArchiveRecordKindUpdate   ArchiveRecordKind = "update"   // an incoming update
+	ArchiveRecordKindOutgoing ArchiveRecordKind = "outgoing" // a message sent by the bot
+)
+
+// ArchiveRecord is a line of archives
+type ArchiveRecord struct {
+	Time   time.Time         `json:"time"`
+	Kind   ArchiveRecordKind `json:"kind"`
+	Method string            `json:"method,omitempty"` // method of outgoing messages (eg. "sendMessage")
+	Data   json.RawMessage   `json:"data"`             // Update or Message, with fields redacted
+}
+
+// ArchiverConfig is a configuration for EnableArchiver()
+type ArchiverConfig struct {
+	Writer io.WriteCloser // destination of archived records (eg. NewRotatingFileWriter())
+
+	IncludeOutgoing bool     // archive messages sent by the bot too
+	SampleRate      float64  // ratio of archived records (0 < `SampleRate` <= 1, default: 1)
+	RedactFields    []string // names of JSON fields whose values are redacted at any depth (eg. "text", "phone_number")
+}
+
+// Archiver writes incoming updates (and outgoing messages) as JSON lines.
+type Archiver struct {
+	writer          io.WriteCloser
+	includeOutgoing bool
+	sampleRate      float64
+	redactFields    map[string]bool
+
+	lock   sync.Mutex
+	closed bool
+}
+
+// EnableArchiver enables archiving every received update (and optionally every sent message)
+// as a line of ArchiveRecord in JSON, and returns the Archiver.
+//
+// Archiver should be closed with Close() when the bot stops, for closing the writer.
+//
+//	writer, _ := NewRotatingFileWriter("./archive/updates.jsonl", 0, 10)
+//	archiver, _ := client.EnableArchiver(ArchiverConfig{
+//		Writer:       writer,
+//		RedactFields: []string{"phone_number", "email"},
+//	})
+//	defer archiver.Close()
+func (b *Bot) EnableArchiver(config ArchiverConfig) (*Archiver, error) {
+	if config.Writer == nil {
+		return nil, fmt.Errorf("writer of archiver is nil")
+	}
+	if config.SampleRate <= 0 || config.SampleRate > 1 {
+		config.SampleRate = 1
+	}
+
+	a := &Archiver{
+		writer:          config.Writer,
+		includeOutgoing: config.IncludeOutgoing,
+		sampleRate:      config.SampleRate,
+		redactFields:    map[string]bool{},
+	}
+	for _, field := range config.RedactFields {
+		a.redactFields[field] = true
+	}
+	b.archiver = a
+
+	return a, nil
+}
+
+// Close stops archiving, and closes the writer.
+func (a *Archiver) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+
+	return a.writer.Close()
+}
+
+// archive an incoming update
+func (a *Archiver) archiveUpdate(update Update) error {
+	return a.archive(ArchiveRecordKindUpdate, "", update)
+}
+
+// archive an outgoing message
+func (a *Archiver) archiveOutgoing(method string, message Message) error {
+	if !a.includeOutgoing {
+		return nil
+	}
+	return a.archive(ArchiveRecordKindOutgoing, method, message)
+}
+
+// write a record of given data
+func (a *Archiver) archive(kind ArchiveRecordKind, method string, data any) error {
+	if a.sampleRate < 1 && rand.Float64() >= a.sampleRate {
+		return nil
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s for archive: %w", kind, err)
+	}
+	if len(a.redactFields) > 0 {
+		if bytes, err = a.redact(bytes); err != nil {
+			return err
+		}
+	}
+
+	line, err := json.Marshal(ArchiveRecord{
+		Time:   time.Now(),
+		Kind:   kind,
+		Method: method,
+		Data:   bytes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive record: %w", err)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.closed {
+		return nil
+	}
+	if _, err = a.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write archive record: %w", err)
+	}
+	return nil
+}
+
+// redact values of configured fields in given JSON
+func (a *Archiver) redact(bytes []byte) ([]byte, error) {
+	var value any
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal for redaction: %w", err)
+	}
+
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, child := range v {
+				if a.redactFields[key] {
+					v[key] = archiveRedactedValue
+				} else {
+					walk(child)
+				}
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(value)
+
+	return json.Marshal(value)
+}
+
+////////////////////////////////
+// rotating files
+//
+
+// rotatingFile is an io.WriteCloser which rotates files by size
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file   *os.File
+	size   int64
+	closed bool
+	lock   sync.Mutex
+}
+
+// NewRotatingFileWriter returns an io.WriteCloser which appends to the file at given path, and rotates it
+// when its size exceeds `maxSize` bytes (default: 100MB) by renaming it with a timestamp suffix.
+//
+// Only the newest `maxBackups` rotated files are kept. (all of them are kept if `maxBackups` <= 0)
+func NewRotatingFileWriter(path string, maxSize int64, maxBackups int) (io.WriteCloser, error) {
+	if maxSize <= 0 {
+		maxSize = defaultRotatingFileMaxSize
+	}
+
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends given bytes to the file, rotating it before if needed.
+//
+// When the rotation fails, given bytes are still appended to the current file and the error is returned,
+// and the rotation is retried on the next write.
+func (f *rotatingFile) Write(p []byte) (n int, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if f.file == nil { // reopen after a failed rotation
+		if err = f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	var errRotate error
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if errRotate = f.rotate(); f.file == nil {
+			return 0, errRotate
+		}
+	}
+
+	n, err = f.file.Write(p)
+	f.size += int64(n)
+	if err == nil {
+		err = errRotate
+	}
+	return n, err
+}
+
+// Close closes the file.
+func (f *rotatingFile) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open (or create) the file for appending
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for '%s': %w", f.path, err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat '%s': %w", f.path, err)
+	}
+
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+// rename the current file with a timestamp, open a new one, and remove old backups
+//
+// If it fails, the original path is reopened for appending (f.file stays nil when it also fails).
+func (f *rotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close '%s': %w", f.path, err)
+	}
+
+	backup := fmt.Sprintf("%s.%s", f.path, time.Now().Format("20060102-150405.000000000"))
+	if err = os.Rename(f.path, backup); err != nil {
+		err = fmt.Errorf("failed to rotate '%s': %w", f.path, err)
+		if errOpen := f.open(); errOpen != nil {
+			return fmt.Errorf("%w (and failed to reopen: %v)", err, errOpen)
+		}
+		return err
+	}
+	if err = f.open(); err != nil {
+		return err
+	}
+
+	if f.maxBackups > 0 {
+		if backups, err := filepath.Glob(f.path + ".*"); err == nil && len(backups) > f.maxBackups {
+			sort.Strings(backups) // oldest first
+			for _, old := range backups[:len(backups)-f.maxBackups] {
+				_ = os.Remove(old)
+			}
+		}
+	}
+
+	return nil
+}
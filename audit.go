@@ -0,0 +1,112 @@
+package telegrambot
+
+// Audit log of outgoing API calls.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maximum length of each param value in audit entries (longer values are truncated)
+const auditParamMaxLength = 256
+
+// AuditEntry is a record of an outgoing API call
+type AuditEntry struct {
+	Time        time.Time         `json:"time"`
+	Method      string            `json:"method"`
+	ChatID      string            `json:"chat_id,omitempty"`
+	Params      map[string]string `json:"params,omitempty"` // truncated params, with files elided
+	Duration    time.Duration     `json:"duration"`
+	Ok          bool              `json:"ok"`
+	ErrorCode   int               `json:"error_code,omitempty"` // `error_code` of the response (0 if the request itself failed)
+	Description string            `json:"description,omitempty"`
+}
+
+// AuditSink is an interface for recording outgoing API calls.
+//
+// Implement this interface for storing audit entries elsewhere (eg. a database).
+//
+// NOTE: Record() is called synchronously on each API call, so it should return quickly.
+type AuditSink interface {
+	Record(entry AuditEntry)
+}
+
+// AuditSinkFunc is a function which implements AuditSink
+type AuditSinkFunc func(entry AuditEntry)
+
+// Record calls the function itself.
+func (f AuditSinkFunc) Record(entry AuditEntry) {
+	f(entry)
+}
+
+// auditWriter is an AuditSink which writes entries as JSON lines
+type auditWriter struct {
+	writer io.Writer
+	lock   sync.Mutex
+}
+
+// NewAuditWriter returns an AuditSink which writes each entry to `w` as a line of JSON.
+func NewAuditWriter(w io.Writer) AuditSink {
+	return &auditWriter{writer: w}
+}
+
+// Record writes given entry as a line of JSON.
+func (w *auditWriter) Record(entry AuditEntry) {
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	_, _ = w.writer.Write(append(bytes, '\n'))
+}
+
+// SetAuditSink sets the sink for recording every outgoing API call. (nil for disabling it)
+//
+// Bot token is redacted from recorded entries.
+func (b *Bot) SetAuditSink(sink AuditSink) {
+	b.auditSink = sink
+}
+
+// record an API call to the audit sink (and the live stream)
+func (b *Bot) audit(method string, params map[string]string, started time.Time, resp []byte, err error) {
+	entry := AuditEntry{
+		Time:     started,
+		Method:   method,
+		ChatID:   params["chat_id"],
+		Params:   map[string]string{},
+		Duration: time.Since(started),
+	}
+	for key, value := range params {
+		entry.Params[key] = b.redact(truncateAuditParam(value))
+	}
+
+	entry.Ok, entry.ErrorCode, entry.Description = b.responseSummary(resp, err)
+
+	if b.auditSink != nil {
+		b.auditSink.Record(entry)
+	}
+	if b.liveStream != nil {
+		b.liveStream.publishCall(entry)
+	}
+}
+
+// truncate given param value for audit entries
+func truncateAuditParam(value string) string {
+	if len(value) <= auditParamMaxLength {
+		return value
+	}
+
+	// cut at a rune boundary
+	cut := auditParamMaxLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s…(%d bytes)", value[:cut], len(value))
+}
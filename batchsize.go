@@ -0,0 +1,121 @@
+package telegrambot
+
+// Adapting the `limit` of getUpdates to the throughput of handlers and the backlog of updates.
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// range of `limit` of getUpdates
+	getUpdatesMinLimit = 1
+	getUpdatesMaxLimit = 100
+
+	// fetched-but-not-handled updates are kept under what handlers can handle in this duration
+	adaptiveBatchTargetBacklog = 2 * time.Second
+
+	// weight of the latest measurement in the moving average of throughput
+	adaptiveBatchRateWeight = 0.5
+)
+
+// batchSizer adapts `limit` of getUpdates:
+//
+//   - a full batch means that updates are backed up on the server, so the limit is doubled,
+//   - a batch much smaller than the limit means that the bot is idle, so the limit is halved,
+//   - the limit never exceeds what handlers can handle in adaptiveBatchTargetBacklog, minus pending updates.
+type batchSizer struct {
+	min, max int
+
+	limit    int
+	pending  int64 // fetched but not handled yet (atomic)
+	handled  int64 // handled in total (atomic)
+	rate     float64
+	measured int64
+	lastTime time.Time
+
+	lock sync.Mutex
+}
+
+// SetAdaptiveBatchSize makes polling loops (StartMonitoringUpdates() and StartMonitoringUpdatesPipelined())
+// adjust the `limit` of getUpdates between `min` and `max` (1-100), based on the throughput of handlers and the backlog:
+// larger batches are fetched when falling behind, and smaller ones when idle.
+//
+// (0 for `max` disables it, and the default limit of 100 is used)
+func (b *Bot) SetAdaptiveBatchSize(min, max int) {
+	if max <= 0 {
+		b.batchSizer = nil
+		return
+	}
+
+	if max > getUpdatesMaxLimit {
+		max = getUpdatesMaxLimit
+	}
+	if min < getUpdatesMinLimit {
+		min = getUpdatesMinLimit
+	}
+	if min > max {
+		min = max
+	}
+
+	b.batchSizer = &batchSizer{
+		min:      min,
+		max:      max,
+		limit:    min,
+		lastTime: time.Now(),
+	}
+}
+
+// mark given number of updates as fetched
+func (s *batchSizer) fetched(count int) {
+	atomic.AddInt64(&s.pending, int64(count))
+}
+
+// mark an update as handled
+func (s *batchSizer) done() {
+	atomic.AddInt64(&s.pending, -1)
+	atomic.AddInt64(&s.handled, 1)
+}
+
+// limit for the next getUpdates, after a batch of `fetched` updates
+func (s *batchSizer) next(fetched int) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// throughput of handlers (updates per second)
+	now := time.Now()
+	handled := atomic.LoadInt64(&s.handled)
+	if elapsed := now.Sub(s.lastTime).Seconds(); elapsed > 0 && handled > s.measured {
+		rate := float64(handled-s.measured) / elapsed
+		if s.rate == 0 {
+			s.rate = rate
+		} else {
+			s.rate = adaptiveBatchRateWeight*rate + (1-adaptiveBatchRateWeight)*s.rate
+		}
+		s.measured, s.lastTime = handled, now
+	}
+
+	// backlog on the server
+	if fetched >= s.limit {
+		s.limit *= 2
+	} else if fetched*4 < s.limit {
+		s.limit /= 2
+	}
+
+	// backlog in the process
+	if s.rate > 0 {
+		capacity := int(s.rate*adaptiveBatchTargetBacklog.Seconds()) - int(atomic.LoadInt64(&s.pending))
+		if s.limit > capacity {
+			s.limit = capacity
+		}
+	}
+
+	if s.limit < s.min {
+		s.limit = s.min
+	}
+	if s.limit > s.max {
+		s.limit = s.max
+	}
+	return s.limit
+}
@@ -0,0 +1,250 @@
+package telegrambot
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// params of a typical sendMessage request
+func benchmarkMessageParams() map[string]any {
+	return OptionsSendMessage{
+		"chat_id": int64(1234567890),
+		"text":    "Hello, world! This is a message for benchmarking.",
+	}.
+		SetParseMode(ParseModeHTML).
+		SetDisableNotification(true).
+		SetReplyToMessageID(42).
+		SetReplyMarkup(InlineKeyboardMarkup{
+			InlineKeyboard: [][]InlineKeyboardButton{
+				NewInlineKeyboardButtonsWithCallbackData(map[string]string{
+					"Yes": "yes",
+					"No":  "no",
+				}),
+			},
+		})
+}
+
+// params of a typical sendPhoto request
+func benchmarkPhotoParams() map[string]any {
+	return OptionsSendPhoto{
+		"chat_id": int64(1234567890),
+		"photo":   InputFileFromBytes(bytes.Repeat([]byte{0xFF, 0xD8, 0xFF, 0xE0}, 16*1024)), // 64KB
+	}.
+		SetCaption("a photo for benchmarking").
+		SetParseMode(ParseModeMarkdownV2)
+}
+
+// start a test server which responds with given body, and return its url
+func benchmarkServer(b *testing.B, response string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = io.WriteString(w, response)
+	}))
+	b.Cleanup(server.Close)
+
+	return server.URL
+}
+
+// read a golden file in testdata/
+func benchmarkGoldenFile(b *testing.B, path ...string) []byte {
+	data, err := os.ReadFile(filepath.Join(append([]string{"testdata"}, path...)...))
+	if err != nil {
+		b.Fatalf("failed to read golden file: %s", err)
+	}
+	return data
+}
+
+// BenchmarkParamToString benchmarks the serialization of each kind of parameter.
+func BenchmarkParamToString(b *testing.B) {
+	client := NewClient("01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST")
+
+	for _, param := range []struct {
+		name  string
+		value any
+	}{
+		{"int", 42},
+		{"int64", int64(1234567890)},
+		{"float32", float32(37.5665)},
+		{"float64", 37.56653515},
+		{"bool", true},
+		{"string", "Hello, world!"},
+		{"parse_mode", ParseModeHTML},
+		{"json", benchmarkMessageParams()["reply_markup"]},
+	} {
+		value := param.value
+
+		b.Run(param.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = client.paramToString(value)
+			}
+		})
+	}
+}
+
+// BenchmarkURLEncodedParams benchmarks the encoding of urlencoded form data.
+func BenchmarkURLEncodedParams(b *testing.B) {
+	client := NewClient("01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST")
+	params := benchmarkMessageParams()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = client.urlEncodedParams(params)
+	}
+}
+
+// BenchmarkRequestURLEncodedFormData benchmarks a urlencoded request and its response.
+func BenchmarkRequestURLEncodedFormData(b *testing.B) {
+	client := NewClient("01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST")
+	apiURL := benchmarkServer(b, string(benchmarkGoldenFile(b, "responses", "message_sendmessage.json")))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.requestURLEncodedFormData(apiURL, benchmarkMessageParams()); err != nil {
+			b.Fatalf("request failed: %s", err)
+		}
+	}
+}
+
+// BenchmarkRequestMultipartFormData benchmarks the construction of a multipart request and its response.
+func BenchmarkRequestMultipartFormData(b *testing.B) {
+	client := NewClient("01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST")
+	apiURL := benchmarkServer(b, string(benchmarkGoldenFile(b, "responses", "message_sendmessage.json")))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.requestMultipartFormData(apiURL, benchmarkPhotoParams()); err != nil {
+			b.Fatalf("request failed: %s", err)
+		}
+	}
+}
+
+// BenchmarkUpdateDecoding benchmarks the decoding of updates.
+func BenchmarkUpdateDecoding(b *testing.B) {
+	for _, name := range []string{"message_text.json", "message_photo.json", "callback_query.json"} {
+		data := benchmarkGoldenFile(b, "updates", name)
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				var update Update
+				if err := json.Unmarshal(data, &update); err != nil {
+					b.Fatalf("failed to decode update: %s", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkGetUpdatesDecoding benchmarks the decoding of a getUpdates response with many updates.
+func BenchmarkGetUpdatesDecoding(b *testing.B) {
+	updates := []Update{}
+	for i := 0; i < 100; i++ {
+		updates = append(updates, NewTestMessageUpdate(1000, 1000, "/start benchmarking"))
+	}
+	data, _ := json.Marshal(map[string]any{"ok": true, "result": updates})
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var response APIResponse[[]Update]
+		if err := json.Unmarshal(data, &response); err != nil {
+			b.Fatalf("failed to decode response: %s", err)
+		}
+	}
+}
+
+// BenchmarkWebhookHandler benchmarks the request path of the webhook handler.
+func BenchmarkWebhookHandler(b *testing.B) {
+	client := NewClient("01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST")
+	client.updateHandler = func(b *Bot, update Update, err error) {}
+	data := benchmarkGoldenFile(b, "updates", "message_text.json")
+	path := client.getWebhookPath()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+		client.handleWebhook(httptest.NewRecorder(), req)
+	}
+}
+
+// transport which closes connection after each request (for comparison)
+type closingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends given request with `Connection: close`.
+func (t closingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Close = true
+	return t.base.RoundTrip(req)
+}
+
+// BenchmarkConnectionReuse compares throughputs of requests over TLS with and without connection reuse.
+func BenchmarkConnectionReuse(b *testing.B) {
+	response := string(benchmarkGoldenFile(b, "responses", "message_sendmessage.json"))
+
+	for _, reuse := range []bool{true, false} {
+		name := "keep-alive"
+		if !reuse {
+			name = "close"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				_, _ = io.WriteString(w, response)
+			}))
+			defer server.Close()
+
+			client := NewClient("01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST")
+			transport := server.Client().Transport.(*http.Transport).Clone()
+			transport.MaxIdleConnsPerHost = 100
+			if reuse {
+				client.SetHTTPTransport(transport)
+			} else {
+				client.SetHTTPTransport(closingTransport{base: transport})
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := client.requestURLEncodedFormData(server.URL, benchmarkMessageParams()); err != nil {
+						b.Errorf("request failed: %s", err)
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkLazyUpdateDecoding benchmarks the partial decoding of updates.
+func BenchmarkLazyUpdateDecoding(b *testing.B) {
+	for _, name := range []string{"message_text.json", "message_photo.json", "callback_query.json"} {
+		data := benchmarkGoldenFile(b, "updates", name)
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := NewLazyUpdate(data); err != nil {
+					b.Fatalf("failed to decode update: %s", err)
+				}
+			}
+		})
+	}
+}
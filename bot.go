@@ -6,7 +6,9 @@
 package telegrambot
 
 import (
+	"context"
 	"crypto/md5"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net"
@@ -15,6 +17,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,6 +26,9 @@ const (
 	fileBaseURL = "https://api.telegram.org/file/bot"
 
 	webhookPath = "/telegram/bot/webhook"
+
+	pipelinedPollingTimeoutSeconds = 5
+	pipelinedPollingRetryDelay     = 1 * time.Second
 )
 
 const (
@@ -33,6 +39,9 @@ const (
 var _stdout = log.New(os.Stdout, "", log.LstdFlags)
 var _stderr = log.New(os.Stderr, "", log.LstdFlags)
 
+// sequential id for correlating debug log entries
+var _debugRequestID uint64
+
 // Bot struct
 type Bot struct {
 	token       string // Telegram bot API's token
@@ -42,13 +51,67 @@ type Bot struct {
 	webhookPort int    // webhook port number
 	webhookURL  string // webhook url
 
+	webhookSecretToken        string           // secret token of webhook requests ("" for no verification)
+	webhookPendingSecretToken string           // secret token being sent with setWebhook (redacted from logs until it succeeds)
+	webhookIPFilter           *webhookIPFilter // allowed sources of webhook requests (nil for all)
+	webhookLock               sync.RWMutex     // lock for webhookHost, webhookPort, webhookURL, and webhook secret tokens
+
+	apiBaseURL  string    // base url of the API server
+	fileBaseURL string    // base url of file downloads
+	localServer bool      // whether the API server is a local Bot API server (which returns local file paths) or not
+	failover    *failover // failover to a secondary API server (nil for no failover)
+
+	payloadLimits *PayloadLimits // limits for validating params before requests (nil for no validation)
+
 	httpClient *http.Client // http client
+	codec      JSONCodec    // json codec (nil for `encoding/json`)
 
 	quitLoop chan struct{} // quit channel of monitoring loop
 
+	policies   methodPolicies // policies of API requests per method class
+	batchSizer *batchSizer    // adaptive `limit` of getUpdates (nil for the fixed limit)
+
+	internalMux    *http.ServeMux // mux of the internal server (for pprof and runtime stats)
+	internalServer *http.Server   // internal server
+	internalLock   sync.Mutex
+
 	updateHandler func(b *Bot, update Update, err error) // update(webhook) handler function
+	updateFilter  func(update *LazyUpdate) bool          // filter for updates (nil for accepting all)
+
+	chatCache    CacheStore    // cache for chat info (nil for no caching)
+	chatCacheTTL time.Duration // ttl of cached chat info
+
+	chatIDCache            CacheStore    // cache for chat ids resolved from usernames
+	chatIDCacheTTL         time.Duration // ttl of resolved chat ids
+	chatIDNegativeCacheTTL time.Duration // ttl of not-found usernames
+
+	fileIDCache    CacheStore    // cache for file ids of uploaded files (nil for no caching)
+	fileIDCacheTTL time.Duration // ttl of cached file ids
+
+	idempotencyStore  CacheStore    // store of idempotency keys
+	idempotencyWindow time.Duration // window in which sends with the same idempotency key are suppressed
+	idempotencyLock   sync.Mutex
+
+	auditSink  AuditSink    // sink for recording outgoing API calls (nil for no auditing)
+	hooks      requestHooks // hooks called before and after API requests
+	metrics    *Metrics     // metrics of the bot (nil for no metrics)
+	logger     Logger       // logger for log messages (nil for printing them to stdout/stderr)
+	liveStream *LiveStream  // live stream of updates and API calls (nil for no streaming)
+	archiver   *Archiver    // archiver of updates and sent messages (nil for no archiving)
+
+	handlers      updateHandlers  // handlers added with AddUpdateHandler()
+	commands      commandHandlers // commands registered with HandleCommand()
+	updateTimeout time.Duration   // deadline for handling each update (0 for no deadline)
+	workerPool    *WorkerPool     // pool of workers which handle updates (nil for handling them in receivers' goroutines)
+
+	markdownV2AutoEscape  bool // fix MarkdownV2 texts before sending them or not
+	autoSplitLongMessages bool // split texts of SendMessage() which are too long into multiple messages or not
+
+	moderation   ModerationConfig // configuration of moderation helpers
+	warningLocks keyedLocks       // locks of warnings, per key in the store
 
 	Verbose bool // print verbose log messages or not
+	Debug   bool // print each API request and its response as one correlated log entry or not
 }
 
 // NewClient gets a new bot API client with given token string.
@@ -57,23 +120,49 @@ func NewClient(token string) *Bot {
 		token:       token,
 		tokenHashed: fmt.Sprintf("%x", md5.Sum([]byte(token))),
 
-		httpClient: &http.Client{
+		apiBaseURL:  apiBaseURL,
+		fileBaseURL: fileBaseURL,
+
+		httpClient: &http.Client{ // NOTE: timeouts of requests are set per method class (see SetMethodPolicy())
 			Transport: &http.Transport{
 				DialContext: (&net.Dialer{
 					Timeout:   10 * time.Second,
 					KeepAlive: 300 * time.Second,
 				}).DialContext,
+				MaxIdleConns:          100,
+				MaxIdleConnsPerHost:   100, // NOTE: all requests go to the same host, so keep more idle connections for reuse
 				IdleConnTimeout:       90 * time.Second,
 				TLSHandshakeTimeout:   10 * time.Second,
-				ResponseHeaderTimeout: 10 * time.Second,
 				ExpectContinueTimeout: 1 * time.Second,
 			},
 		},
 
 		quitLoop: make(chan struct{}, 1),
+
+		policies: methodPolicies{
+			policies:   defaultMethodPolicies(),
+			classes:    map[string]MethodClass{},
+			rateLimits: map[string]*rateLimiter{},
+		},
+
+		chatIDCache:            NewMemoryCacheStore(),
+		chatIDCacheTTL:         defaultChatIDCacheTTL,
+		chatIDNegativeCacheTTL: defaultChatIDNegativeCacheTTL,
+
+		idempotencyStore:  NewMemoryCacheStore(),
+		idempotencyWindow: defaultIdempotencyWindow,
+
+		moderation: ModerationConfig{}.withDefaults(),
 	}
 }
 
+// SetHTTPTransport replaces the transport of the bot's http client.
+//
+// (eg. VCRTransport for recording/replaying API interactions in tests)
+func (b *Bot) SetHTTPTransport(transport http.RoundTripper) {
+	b.httpClient.Transport = transport
+}
+
 // GenCertAndKey generates a certificate and a private key file with given domain.
 // (`OpenSSL` is needed.)
 func GenCertAndKey(domain string, outCertFilepath string, outKeyFilepath string, expiresInDays int) error {
@@ -95,32 +184,64 @@ func GenCertAndKey(domain string, outCertFilepath string, outKeyFilepath string,
 // Certification file(.pem) and a private key is needed.
 // Incoming webhooks will be received through webhookHandler function.
 //
+// If environment variable `TELEGRAM_BOT_INTERNAL_ADDR` is set (eg. "127.0.0.1:6060"),
+// an internal server for profiling and runtime stats will also be started on it. (see StartInternalServer())
+//
 // https://core.telegram.org/bots/self-signed
 func (b *Bot) StartWebhookServerAndWait(certFilepath string, keyFilepath string, webhookHandler func(b *Bot, webhook Update, err error)) {
-	b.verbose("starting webhook server on: %s (port: %d) ...", b.getWebhookPath(), b.webhookPort)
+	b.verbose("starting webhook server on: %s (port: %d) ...", b.getWebhookPath(), b.webhookPortNumber())
+
+	// set update handler
+	if webhookHandler == nil && !b.hasUpdateHandlers() {
+		b.error("given webhook handler is nil")
+		return
+	}
+	b.updateHandler = webhookHandler
+
+	// internal server for profiling
+	b.startInternalServerFromEnv()
+
+	b.serveWebhookAndWait(certFilepath, keyFilepath, nil, b.handleWebhook)
+}
+
+// StartWebhookServerWithTLSConfigAndWait starts a webhook server with given TLS configuration (and waits forever),
+// eg. for certificates loaded from memory, or reloaded with `GetCertificate`.
+//
+// It is the same as StartWebhookServerAndWait() except for the TLS configuration.
+// (see also WebhookHandler() for serving webhook requests on a custom server)
+func (b *Bot) StartWebhookServerWithTLSConfigAndWait(tlsConfig *tls.Config, webhookHandler func(b *Bot, webhook Update, err error)) {
+	b.verbose("starting webhook server on: %s (port: %d) ...", b.getWebhookPath(), b.webhookPortNumber())
 
 	// set update handler
-	if webhookHandler == nil {
+	if webhookHandler == nil && !b.hasUpdateHandlers() {
 		b.error("given webhook handler is nil")
 		return
 	}
 	b.updateHandler = webhookHandler
 
+	// internal server for profiling
+	b.startInternalServerFromEnv()
+
+	b.serveWebhookAndWait("", "", tlsConfig, b.handleWebhook)
+}
+
+// serve webhook requests with given handler (blocks until the server fails)
+//
+// Certificates of `tlsConfig` (if not nil) are used when `certFilepath` and `keyFilepath` are empty.
+func (b *Bot) serveWebhookAndWait(certFilepath string, keyFilepath string, tlsConfig *tls.Config, handler http.HandlerFunc) {
 	// routing
 	mux := http.NewServeMux()
-	mux.HandleFunc(b.getWebhookPath(), b.handleWebhook)
-
-	// TODO: check http header: `X-Telegram-Bot-Api-Secret-Token` if `secret_token` is provided
-	// (https://core.telegram.org/bots/api#setwebhook)
+	mux.HandleFunc(b.getWebhookPath(), handler)
 
 	// start server
 	server := &http.Server{
-		Addr:              fmt.Sprintf(":%d", b.webhookPort),
+		Addr:              fmt.Sprintf(":%d", b.webhookPortNumber()),
 		Handler:           mux,
 		ReadTimeout:       10 * time.Second,
 		ReadHeaderTimeout: 10 * time.Second,
 		WriteTimeout:      10 * time.Second,
 		IdleTimeout:       60 * time.Second,
+		TLSConfig:         tlsConfig,
 	}
 	if err := server.ListenAndServeTLS(certFilepath, keyFilepath); err != nil {
 		panic(err.Error())
@@ -140,30 +261,40 @@ func (b *Bot) StartMonitoringUpdates(updateOffset int64, interval int, updateHan
 		SetTimeout(1)  // default: 0 for testing
 
 	// set update handler
-	if updateHandler == nil {
+	if updateHandler == nil && !b.hasUpdateHandlers() {
 		b.error("given update handler is nil")
 		return
 	}
 	b.updateHandler = updateHandler
 
-	var updates APIResponse[[]Update]
+	// internal server for profiling
+	b.startInternalServerFromEnv()
+
+	sizer := b.batchSizer
+	if sizer != nil {
+		options.SetLimit(sizer.limit)
+	}
+
 loop:
 	for {
 		select {
 		case <-b.quitLoop:
 			break loop
 		default:
-			if updates = b.GetUpdates(options); updates.Ok {
-				for _, update := range *updates.Result {
-					// update offset (max + 1)
-					if options["offset"].(int64) <= update.UpdateID {
-						options["offset"] = update.UpdateID + 1
+			if updates, err := b.pollUpdates(options); err == nil {
+				if sizer != nil {
+					sizer.fetched(len(updates))
+					for _, update := range updates {
+						go b.handleUpdateThen(update, sizer.done)
+					}
+					options.SetLimit(sizer.next(len(updates)))
+				} else {
+					for _, update := range updates {
+						go b.handleUpdate(update, nil)
 					}
-
-					go b.updateHandler(b, update, nil)
 				}
 			} else {
-				go b.updateHandler(b, Update{}, fmt.Errorf("%s", *updates.Description))
+				go b.handleUpdate(Update{}, err)
 			}
 
 			time.Sleep(time.Duration(interval) * time.Second)
@@ -173,6 +304,272 @@ loop:
 	b.verbose("stopped monitoring updates")
 }
 
+// StartMonitoringUpdatesPipelined retrieves updates from API server constantly,
+// issuing the next getUpdates request while the current batch is being handled.
+//
+// Updates are handled by `workers` goroutines, and at most `prefetch` batches are fetched ahead of them.
+//
+// NOTE: Prefetched updates are confirmed to the API server before they are handled,
+// so they will be lost if the process stops before handling them.
+//
+// If webhook is registered, it may not work properly. So make sure webhook is deleted, or not registered.
+func (b *Bot) StartMonitoringUpdatesPipelined(updateOffset int64, workers, prefetch int, updateHandler func(b *Bot, update Update, err error)) {
+	b.verbose("starting monitoring updates (pipelined, workers: %d, prefetch: %d) ...", workers, prefetch)
+
+	// set update handler
+	if updateHandler == nil && !b.hasUpdateHandlers() {
+		b.error("given update handler is nil")
+		return
+	}
+	b.updateHandler = updateHandler
+
+	// internal server for profiling
+	b.startInternalServerFromEnv()
+
+	if workers <= 0 {
+		workers = 1
+	}
+	if prefetch <= 0 {
+		prefetch = 1
+	}
+
+	batches := make(chan []Update, prefetch) // bounded prefetch
+	stop := make(chan struct{})
+	sizer := b.batchSizer
+
+	// fetcher
+	go func() {
+		defer close(batches)
+
+		// https://core.telegram.org/bots/api#getupdates
+		options := OptionsGetUpdates{}.
+			SetOffset(updateOffset).
+			SetLimit(100).
+			SetTimeout(pipelinedPollingTimeoutSeconds)
+		if sizer != nil {
+			options.SetLimit(sizer.limit)
+		}
+
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			// NOTE: updated offset confirms fetched updates on the next request
+			if updates, err := b.pollUpdates(options); err == nil {
+				if sizer != nil {
+					sizer.fetched(len(updates))
+					options.SetLimit(sizer.next(len(updates)))
+				}
+				if len(updates) == 0 {
+					continue
+				}
+
+				select {
+				case batches <- updates:
+				case <-stop:
+					return
+				}
+			} else {
+				go b.handleUpdate(Update{}, err)
+
+				time.Sleep(pipelinedPollingRetryDelay)
+			}
+		}
+	}()
+
+	// workers
+	queue := make(chan Update)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var done func()
+			if sizer != nil {
+				done = sizer.done
+			}
+			for update := range queue {
+				b.handleUpdateThen(update, done)
+			}
+		}()
+	}
+
+	// dispatch fetched batches to workers
+loop:
+	for {
+		select {
+		case <-b.quitLoop:
+			break loop
+		case batch, ok := <-batches:
+			if !ok {
+				break loop
+			}
+			for _, update := range batch {
+				queue <- update
+			}
+		}
+	}
+
+	close(stop)
+	close(queue)
+	wg.Wait()
+
+	b.verbose("stopped monitoring updates")
+}
+
+// handle an update (or an error) from webhook or polling
+func (b *Bot) handleUpdate(update Update, err error) {
+	if err != nil {
+		if b.updateHandler != nil {
+			b.callUpdateHandler(context.Background(), update, err)
+		} else {
+			b.error("error while receiving update (%s)", err)
+		}
+		return
+	}
+
+	b.handleUpdateThen(update, nil)
+}
+
+// handle a received update, and call `done` (if not nil) after it is handled
+//
+// With a worker pool, it returns as soon as the update is queued, and `done` is called by the worker.
+func (b *Bot) handleUpdateThen(update Update, done func()) {
+	if pool := b.workerPool; pool != nil && pool.submit(update, done) {
+		return
+	}
+
+	b.handleReceivedUpdate(update)
+	if done != nil {
+		done()
+	}
+}
+
+// handle a received update (in a worker of the pool, if enabled)
+func (b *Bot) handleReceivedUpdate(update Update) {
+	b.invalidateChatCacheWithUpdate(update)
+	if b.metrics != nil {
+		b.metrics.observeUpdate(update)
+	}
+	if b.liveStream != nil {
+		b.liveStream.publishUpdate(update)
+	}
+	if b.archiver != nil {
+		if err := b.archiver.archiveUpdate(update); err != nil {
+			b.error("failed to archive update: %s", err)
+		}
+	}
+
+	if b.updateTimeout <= 0 {
+		b.processUpdate(context.Background(), update, &handlerProgress{})
+		return
+	}
+
+	// process the update with a deadline
+	ctx, cancel := context.WithTimeout(context.Background(), b.updateTimeout)
+	defer cancel()
+
+	progress := &handlerProgress{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.processUpdate(ctx, update, progress)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// NOTE: the handler keeps running in its goroutine, but the worker is freed
+		b.reportHandlerError(&HandlerError{
+			Handler:  progress.current(),
+			UpdateID: update.UpdateID,
+			Err:      ctx.Err(),
+		})
+	}
+}
+
+// pass an update to added handlers and the update handler, in order
+func (b *Bot) processUpdate(ctx context.Context, update Update, progress *handlerProgress) {
+	if b.hasUpdateHandlers() && b.dispatchToHandlers(ctx, update, progress) {
+		return // consumed (or timed out)
+	}
+
+	if b.updateHandler != nil {
+		progress.set(defaultHandlerName)
+		b.callUpdateHandler(ctx, update, nil)
+	}
+}
+
+// call the update handler like added handlers, reporting its panic to the handler error hook
+func (b *Bot) callUpdateHandler(ctx context.Context, update Update, err error) {
+	c := &Ctx{
+		Context: ctx,
+		Bot:     b,
+		Update:  update,
+	}
+
+	if herr := b.callHandler(c, namedHandler{
+		name: defaultHandlerName,
+		handler: func(c *Ctx) error {
+			b.updateHandler(b, update, err)
+			return nil
+		},
+	}); herr != nil {
+		b.reportHandlerError(herr) // a recovered panic
+	}
+}
+
+// retrieve updates with given options, applying the update filter if it exists
+//
+// the offset of given options is updated (max + 1) after updates are retrieved.
+func (b *Bot) pollUpdates(options OptionsGetUpdates) (updates []Update, err error) {
+	offset, _ := options["offset"].(int64)
+
+	if b.updateFilter == nil {
+		fetched := b.GetUpdates(options)
+		if !fetched.Ok {
+			return nil, fmt.Errorf("%s", *fetched.Description)
+		}
+
+		for _, update := range *fetched.Result {
+			if offset <= update.UpdateID {
+				offset = update.UpdateID + 1
+			}
+		}
+		updates = *fetched.Result
+	} else {
+		fetched := b.GetUpdatesLazy(options)
+		if !fetched.Ok {
+			return nil, fmt.Errorf("%s", *fetched.Description)
+		}
+
+		for _, lazy := range *fetched.Result {
+			if offset <= lazy.UpdateID {
+				offset = lazy.UpdateID + 1
+			}
+
+			if !b.updateFilter(lazy) {
+				b.verbose("update filtered out: %d (%s)", lazy.UpdateID, lazy.Type)
+				continue
+			}
+
+			if update, err := lazy.Update(); err == nil {
+				updates = append(updates, update)
+			} else {
+				b.error("failed to decode update: %d (%s)", lazy.UpdateID, err)
+			}
+		}
+	}
+
+	options["offset"] = offset
+
+	return updates, nil
+}
+
 // StopMonitoringUpdates stops loop of polling updates
 func (b *Bot) StopMonitoringUpdates() {
 	b.verbose("stopping monitoring updates...")
@@ -185,26 +582,67 @@ func (b *Bot) getWebhookPath() string {
 	return fmt.Sprintf("%s/%s", webhookPath, b.tokenHashed)
 }
 
-// Get full URL of webhook interface.
-func (b *Bot) getWebhookURL() string {
-	return fmt.Sprintf("https://%s:%d%s", b.webhookHost, b.webhookPort, b.getWebhookPath())
+// Get full URL of webhook interface with given host and port.
+func (b *Bot) getWebhookURL(host string, port int) string {
+	return fmt.Sprintf("https://%s:%d%s", host, port, b.getWebhookPath())
+}
+
+// Get host, port, and full URL of the registered webhook.
+func (b *Bot) webhookAddress() (host string, port int, url string) {
+	b.webhookLock.RLock()
+	defer b.webhookLock.RUnlock()
+
+	return b.webhookHost, b.webhookPort, b.webhookURL
+}
+
+// Get port number of the registered webhook.
+func (b *Bot) webhookPortNumber() int {
+	_, port, _ := b.webhookAddress()
+	return port
 }
 
 // Remove confidential info from given string.
 func (b *Bot) redact(str string) string {
 	tokenRemoved := strings.Replace(str, b.token, redactedString, -1)
 	redacted := strings.Replace(tokenRemoved, b.tokenHashed, redactedString, -1)
+	b.webhookLock.RLock()
+	secretTokens := []string{b.webhookSecretToken, b.webhookPendingSecretToken}
+	b.webhookLock.RUnlock()
+	for _, secretToken := range secretTokens {
+		if secretToken != "" {
+			redacted = strings.Replace(redacted, secretToken, redactedString, -1)
+		}
+	}
 	return redacted
 }
 
 // Print formatted log message. (only when Bot.Verbose == true)
 func (b *Bot) verbose(str string, args ...any) {
 	if b.Verbose {
+		if b.logger != nil {
+			b.logger.Info(b.redact(fmt.Sprintf(str, args...)))
+			return
+		}
+		_stdout.Printf("%s\n", b.redact(fmt.Sprintf(str, args...)))
+	}
+}
+
+// Print formatted debug message. (only when Bot.Debug == true)
+func (b *Bot) debug(str string, args ...any) {
+	if b.Debug {
+		if b.logger != nil {
+			b.logger.Debug(b.redact(fmt.Sprintf(str, args...)))
+			return
+		}
 		_stdout.Printf("%s\n", b.redact(fmt.Sprintf(str, args...)))
 	}
 }
 
 // Print formatted error message.
 func (b *Bot) error(str string, args ...any) {
+	if b.logger != nil {
+		b.logger.Error(b.redact(fmt.Sprintf(str, args...)))
+		return
+	}
 	_stderr.Printf("%s\n", b.redact(fmt.Sprintf(str, args...)))
 }
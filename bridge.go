@@ -0,0 +1,122 @@
+package telegrambot
+
+// Bridging received webhook updates to message brokers.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// timeout for publishing an update
+const publishTimeout = 5 * time.Second
+
+// PublishedUpdate is an update published to a message broker, with its metadata
+type PublishedUpdate struct {
+	UpdateID   int64           `json:"update_id"`
+	Type       UpdateType      `json:"type,omitempty"` // empty if unknown
+	ReceivedAt time.Time       `json:"received_at"`
+	BotID      string          `json:"bot_id"` // id of the bot (the part of its token before ':')
+	Raw        json.RawMessage `json:"raw"`    // original payload of the update
+}
+
+// Publisher is an interface for publishing updates to a message broker. (eg. NATS, Redis Streams, Kafka)
+//
+// See samples/bridge/ for examples.
+type Publisher interface {
+	// Publish publishes given update. Returned error makes the webhook request fail, so Telegram will retry it later.
+	Publish(ctx context.Context, update PublishedUpdate) error
+}
+
+// PublisherFunc is a function which implements Publisher
+type PublisherFunc func(ctx context.Context, update PublishedUpdate) error
+
+// Publish calls the function itself.
+func (f PublisherFunc) Publish(ctx context.Context, update PublishedUpdate) error {
+	return f(ctx, update)
+}
+
+// WebhookBridgeHandler returns a http handler which publishes each received webhook update with `publisher`,
+// instead of handling it in this process.
+//
+// Updates are filtered with the update filter (if set), and are not decoded fully.
+func (b *Bot) WebhookBridgeHandler(publisher Publisher) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		defer req.Body.Close()
+
+		if !b.verifyWebhookRequest(writer, req) {
+			return
+		}
+
+		buf := _bufferPool.Get().(*bytes.Buffer)
+		defer putBuffer(buf)
+		buf.Reset()
+
+		if _, err := buf.ReadFrom(req.Body); err != nil {
+			b.error("error while reading webhook request (%s)", err)
+
+			http.Error(writer, "failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		lazy, err := NewLazyUpdate(buf.Bytes()) // NOTE: bytes are copied
+		if err != nil {
+			b.error("error while parsing json (%s)", err)
+
+			http.Error(writer, "failed to parse update", http.StatusBadRequest)
+			return
+		}
+
+		if b.updateFilter != nil {
+			lazy.codec = b.codec
+			if !b.updateFilter(lazy) {
+				b.verbose("update filtered out: %d (%s)", lazy.UpdateID, lazy.Type)
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), publishTimeout)
+		defer cancel()
+
+		if err := publisher.Publish(ctx, PublishedUpdate{
+			UpdateID:   lazy.UpdateID,
+			Type:       lazy.Type,
+			ReceivedAt: time.Now(),
+			BotID:      b.botID(),
+			Raw:        lazy.Raw(),
+		}); err != nil {
+			b.error("failed to publish update %d (%s)", lazy.UpdateID, err)
+
+			http.Error(writer, "failed to publish update", http.StatusInternalServerError)
+			return
+		}
+
+		b.verbose("published update: %d (%s)", lazy.UpdateID, lazy.Type)
+	}
+}
+
+// StartWebhookBridgeAndWait starts a webhook server which publishes received updates with `publisher`.
+//
+// Published updates can be consumed and handled by other processes. (see UpdateSource)
+func (b *Bot) StartWebhookBridgeAndWait(certFilepath string, keyFilepath string, publisher Publisher) {
+	b.verbose("starting webhook bridge on: %s (port: %d) ...", b.getWebhookPath(), b.webhookPortNumber())
+
+	if publisher == nil {
+		b.error("given publisher is nil")
+		return
+	}
+
+	// internal server for profiling
+	b.startInternalServerFromEnv()
+
+	b.serveWebhookAndWait(certFilepath, keyFilepath, nil, b.WebhookBridgeHandler(publisher))
+}
+
+// id of this bot, which is the part of its token before ':'
+func (b *Bot) botID() string {
+	id, _, _ := strings.Cut(b.token, ":")
+	return id
+}
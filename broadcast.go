@@ -0,0 +1,335 @@
+package telegrambot
+
+// Sending a message to many chats at a safe pace, with pause/resume and resumable progress.
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultBroadcastRate       = 25.0 // messages per second (below the global limit of 30)
+	defaultBroadcastMaxRetries = 3
+
+	broadcastProgressKeyPrefix = "broadcast:" // prefix of keys in the progress store
+)
+
+// BroadcastConfig is a configuration for Broadcast() and BroadcastFunc()
+type BroadcastConfig struct {
+	Rate       float64       // messages per second (default: 25)
+	MaxRetries int           // retries of each chat after 429 errors (default: 3, negative for no retries)
+	Options    MethodOptions // shared options of sendXXX methods (eg. `disable_notification`)
+
+	// store for persisting progress (nil for no persistence)
+	//
+	// With a persistent store, a broadcast started again with the same id (eg. after a crash)
+	// skips the chats which were already processed.
+	ProgressStore CacheStore
+
+	// delivery for holding messages during recipients' quiet hours (nil for sending immediately)
+	QuietHours *QuietHoursDelivery
+	// send immediately regardless of `QuietHours` or not
+	Urgent bool
+
+	// called with the result of each chat
+	OnResult func(b *Bot, result BroadcastResult)
+}
+
+// fill default values of the configuration
+func (c BroadcastConfig) withDefaults() BroadcastConfig {
+	if c.Rate <= 0 {
+		c.Rate = defaultBroadcastRate
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = defaultBroadcastMaxRetries
+	} else if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	return c
+}
+
+// BroadcastResult is a result of sending to a chat in a broadcast
+type BroadcastResult struct {
+	ChatID   int64
+	Messages []Message // sent messages
+	Held     bool      // held until the end of the chat's quiet hours (see BroadcastConfig.QuietHours)
+	Err      error     // nil if sent (or held) successfully (eg. an *APIError when the bot was blocked by the user)
+}
+
+// BroadcastStats is the progress of a broadcast
+type BroadcastStats struct {
+	Total   int  // number of all chats
+	Resumed int  // number of chats skipped as they were processed before (with a progress store)
+	Sent    int  // number of chats sent successfully
+	Held    int  // number of chats whose messages are held during their quiet hours
+	Failed  int  // number of chats failed
+	Done    bool // all chats were processed or not
+}
+
+// Broadcaster sends a message to chats one by one in the background.
+type Broadcaster struct {
+	bot     *Bot
+	id      string
+	chatIDs []int64
+	content func(chatID int64) Sendable
+	config  BroadcastConfig
+	limiter *rateLimiter
+
+	stats  BroadcastStats
+	resume chan struct{} // closed on Resume() (nil when not paused)
+	lock   sync.Mutex
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Broadcast starts sending `item` to given chats in the background, and returns the Broadcaster.
+//
+// `id` identifies the broadcast in `config.ProgressStore`.
+//
+//	broadcaster := client.Broadcast("newsletter-2024-06", subscriberIDs, SendableText{Text: "Our new release is out!"}, BroadcastConfig{
+//		ProgressStore: store,
+//		OnResult: func(b *Bot, result BroadcastResult) {
+//			if IsBlockedByUser(result.Err) || IsUserDeactivated(result.Err) {
+//				unsubscribe(result.ChatID)
+//			}
+//		},
+//	})
+//	stats := broadcaster.Wait()
+func (b *Bot) Broadcast(id string, chatIDs []int64, item Sendable, config BroadcastConfig) *Broadcaster {
+	return b.BroadcastFunc(id, chatIDs, func(int64) Sendable { return item }, config)
+}
+
+// BroadcastFunc starts sending contents generated by `content` to given chats in the background,
+// and returns the Broadcaster.
+//
+//	client.BroadcastFunc("greetings", chatIDs, func(chatID int64) Sendable {
+//		return SendableText{Text: fmt.Sprintf("Hello, %s!", names[chatID])}
+//	}, BroadcastConfig{})
+func (b *Bot) BroadcastFunc(id string, chatIDs []int64, content func(chatID int64) Sendable, config BroadcastConfig) *Broadcaster {
+	config = config.withDefaults()
+
+	bc := &Broadcaster{
+		bot:     b,
+		id:      id,
+		chatIDs: chatIDs,
+		content: content,
+		config:  config,
+		limiter: newRateLimiter(config.Rate, 1),
+		stats:   BroadcastStats{Total: len(chatIDs)},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go bc.run()
+
+	b.verbose("started broadcast '%s' to %d chats (rate: %g/s)", id, len(chatIDs), config.Rate)
+
+	return bc
+}
+
+// Pause pauses sending after the current chat.
+func (bc *Broadcaster) Pause() {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	if bc.resume == nil {
+		bc.resume = make(chan struct{})
+	}
+}
+
+// Resume resumes the paused sending.
+func (bc *Broadcaster) Resume() {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	if bc.resume != nil {
+		close(bc.resume)
+		bc.resume = nil
+	}
+}
+
+// Paused returns whether sending is paused or not.
+func (bc *Broadcaster) Paused() bool {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return bc.resume != nil
+}
+
+// Stop stops sending after the current chat. (the progress is kept in the progress store, if any)
+func (bc *Broadcaster) Stop() {
+	bc.once.Do(func() { close(bc.stop) })
+}
+
+// Done returns a channel which is closed when sending is finished or stopped.
+func (bc *Broadcaster) Done() <-chan struct{} {
+	return bc.done
+}
+
+// Wait waits until sending is finished or stopped, and returns the stats.
+func (bc *Broadcaster) Wait() BroadcastStats {
+	<-bc.done
+	return bc.Stats()
+}
+
+// Stats returns the current stats.
+func (bc *Broadcaster) Stats() BroadcastStats {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return bc.stats
+}
+
+// send to chats from the saved progress
+func (bc *Broadcaster) run() {
+	defer close(bc.done)
+
+	start := bc.loadProgress()
+
+	bc.lock.Lock()
+	bc.stats.Resumed = start
+	bc.lock.Unlock()
+
+	for i := start; i < len(bc.chatIDs); i++ {
+		if !bc.waitUnlessPaused() {
+			bc.bot.verbose("stopped broadcast '%s' (%d/%d)", bc.id, i, len(bc.chatIDs))
+			return
+		}
+
+		result := bc.send(bc.chatIDs[i])
+
+		bc.lock.Lock()
+		if result.Err == nil && result.Held {
+			bc.stats.Held++
+		} else if result.Err == nil {
+			bc.stats.Sent++
+		} else {
+			bc.stats.Failed++
+		}
+		bc.lock.Unlock()
+
+		bc.saveProgress(i + 1)
+
+		if bc.config.OnResult != nil {
+			bc.config.OnResult(bc.bot, result)
+		}
+	}
+
+	bc.lock.Lock()
+	bc.stats.Done = true
+	stats := bc.stats
+	bc.lock.Unlock()
+
+	bc.bot.verbose("finished broadcast '%s' (sent: %d, held: %d, failed: %d, resumed: %d)", bc.id, stats.Sent, stats.Held, stats.Failed, stats.Resumed)
+}
+
+// wait while paused, and return false if stopped
+func (bc *Broadcaster) waitUnlessPaused() bool {
+	bc.lock.Lock()
+	resume := bc.resume
+	bc.lock.Unlock()
+
+	if resume != nil {
+		select {
+		case <-resume:
+		case <-bc.stop:
+			return false
+		}
+	}
+
+	select {
+	case <-bc.stop:
+		return false
+	default:
+		return true
+	}
+}
+
+// send the content to a chat (or hold it during the chat's quiet hours), retrying after 429 errors
+func (bc *Broadcaster) send(chatID int64) (result BroadcastResult) {
+	result.ChatID = chatID
+	item := bc.content(chatID)
+
+	if bc.config.QuietHours != nil && !bc.config.Urgent {
+		result.Held, result.Err = bc.config.QuietHours.hold(chatID, item, bc.config.Options, time.Now())
+		if result.Held || result.Err != nil {
+			return result
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		bc.limiter.wait()
+
+		res := bc.bot.Send(chatID, item, bc.config.Options)
+		if res.Ok {
+			result.Err = nil
+			if res.Result != nil {
+				result.Messages = *res.Result
+			}
+			return result
+		}
+
+		result.Err = res.Err()
+		retryAfter, tooMany := IsTooManyRequests(result.Err)
+		if !tooMany || attempt >= bc.config.MaxRetries {
+			return result
+		}
+		if retryAfter <= 0 {
+			retryAfter = 1 * time.Second
+		}
+
+		bc.bot.verbose("broadcast '%s' is flood-limited, retrying chat %d after %s (%d/%d)", bc.id, chatID, retryAfter, attempt+1, bc.config.MaxRetries)
+
+		select {
+		case <-time.After(retryAfter):
+		case <-bc.stop:
+			return result
+		}
+	}
+}
+
+// key of the progress in the progress store
+func (bc *Broadcaster) progressKey() string {
+	return broadcastProgressKeyPrefix + bc.id
+}
+
+// load the index of the next chat from the progress store (0 if none)
+func (bc *Broadcaster) loadProgress() int {
+	if bc.config.ProgressStore == nil {
+		return 0
+	}
+
+	value, exists := bc.config.ProgressStore.Get(bc.progressKey())
+	if !exists {
+		return 0
+	}
+
+	var next int
+	switch v := value.(type) {
+	case int:
+		next = v
+	case int64:
+		next = int(v)
+	case float64: // eg. decoded from JSON
+		next = int(v)
+	default:
+		bc.bot.error("ignoring progress of broadcast '%s' with unexpected type: %T", bc.id, value)
+		return 0
+	}
+
+	if next < 0 || next > len(bc.chatIDs) {
+		return 0
+	}
+	return next
+}
+
+// save the index of the next chat to the progress store
+func (bc *Broadcaster) saveProgress(next int) {
+	if bc.config.ProgressStore == nil {
+		return
+	}
+
+	bc.config.ProgressStore.Set(bc.progressKey(), next, 0)
+}
@@ -0,0 +1,287 @@
+package telegrambot
+
+// Caching layer for frequently requested chat info (getChat, getChatMember, and getChatAdministrators).
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CacheStore is an interface for storing values with TTL.
+//
+// Implement this interface for sharing cached values between processes (eg. with Redis).
+type CacheStore interface {
+	// Get returns the value for given key, and whether it exists (and is not expired) or not.
+	Get(key string) (value any, exists bool)
+
+	// Set stores the value for given key, which expires after `ttl`. (never expires if `ttl` <= 0)
+	Set(key string, value any, ttl time.Duration)
+
+	// Delete removes the value for given key.
+	Delete(key string)
+}
+
+// AtomicCacheStore is a CacheStore which can store a value only when its key does not exist, atomically.
+//
+// Implement this interface (eg. with `SET key value NX PX ttl` of Redis) for claiming keys across processes.
+type AtomicCacheStore interface {
+	CacheStore
+
+	// SetIfNotExists stores the value for given key only if it does not exist (or is expired),
+	// and returns whether it was stored or not.
+	SetIfNotExists(key string, value any, ttl time.Duration) (stored bool)
+}
+
+// encode a value as a JSON string for storing it in a CacheStore,
+// so that it is kept intact by stores which serialize values (eg. Redis, SQL)
+func encodeStoreValue(value any) (string, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// decode a value read from a CacheStore, which is encoded with encodeStoreValue()
+//
+// Values which were already decoded by the store (eg. maps from JSON) are also accepted.
+func decodeStoreValue[T any](value any) (decoded T, err error) {
+	var bytes []byte
+	switch v := value.(type) {
+	case T:
+		return v, nil
+	case string:
+		bytes = []byte(v)
+	case []byte:
+		bytes = v
+	case json.RawMessage:
+		bytes = v
+	default:
+		if bytes, err = json.Marshal(v); err != nil {
+			return decoded, err
+		}
+	}
+
+	err = json.Unmarshal(bytes, &decoded)
+	return decoded, err
+}
+
+// number of Set() calls between sweeps of expired items
+const memoryCacheSweepInterval = 1024
+
+// cached item of MemoryCacheStore
+type memoryCacheItem struct {
+	value     any
+	expiresAt time.Time // zero for no expiration
+}
+
+// MemoryCacheStore is an in-memory CacheStore.
+type MemoryCacheStore struct {
+	items map[string]memoryCacheItem
+	sets  int
+
+	lock sync.Mutex
+}
+
+// NewMemoryCacheStore returns a new in-memory CacheStore.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		items: map[string]memoryCacheItem{},
+	}
+}
+
+// Get returns the value for given key, and whether it exists (and is not expired) or not.
+func (s *MemoryCacheStore) Get(key string) (value any, exists bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	item, exists := s.items[key]
+	if !exists {
+		return nil, false
+	}
+	if !item.expiresAt.IsZero() && time.Now().After(item.expiresAt) {
+		delete(s.items, key)
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+// Set stores the value for given key, which expires after `ttl`. (never expires if `ttl` <= 0)
+func (s *MemoryCacheStore) Set(key string, value any, ttl time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.set(key, value, ttl)
+}
+
+// store the value for given key (the lock should be held)
+func (s *MemoryCacheStore) set(key string, value any, ttl time.Duration) {
+	item := memoryCacheItem{value: value}
+	if ttl > 0 {
+		item.expiresAt = time.Now().Add(ttl)
+	}
+	s.items[key] = item
+
+	// sweep expired items periodically
+	if s.sets++; s.sets >= memoryCacheSweepInterval {
+		s.sets = 0
+
+		now := time.Now()
+		for k, v := range s.items {
+			if !v.expiresAt.IsZero() && now.After(v.expiresAt) {
+				delete(s.items, k)
+			}
+		}
+	}
+}
+
+// SetIfNotExists stores the value for given key only if it does not exist (or is expired),
+// and returns whether it was stored or not.
+func (s *MemoryCacheStore) SetIfNotExists(key string, value any, ttl time.Duration) (stored bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if item, exists := s.items[key]; exists && (item.expiresAt.IsZero() || time.Now().Before(item.expiresAt)) {
+		return false
+	}
+
+	s.set(key, value, ttl)
+	return true
+}
+
+// Delete removes the value for given key.
+func (s *MemoryCacheStore) Delete(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.items, key)
+}
+
+// Len returns the number of stored items (including expired ones which are not swept yet).
+func (s *MemoryCacheStore) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.items)
+}
+
+////////////////////////////////
+// Chat cache
+//
+
+// EnableChatCache enables caching results of GetChat(), GetChatMember(), and GetChatAdministrators() for `ttl`.
+//
+// If `store` is nil, a new MemoryCacheStore will be used.
+//
+// Cached values are invalidated with incoming `chat_member`/`my_chat_member` updates and service messages
+// (eg. new chat title), but only for numeric chat ids: values cached with "@channelusername" expire with their TTL only.
+//
+// NOTE: Cached results are shared between callers, so they should not be modified.
+func (b *Bot) EnableChatCache(store CacheStore, ttl time.Duration) {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+
+	b.chatCache = store
+	b.chatCacheTTL = ttl
+}
+
+// DisableChatCache disables caching results of GetChat(), GetChatMember(), and GetChatAdministrators().
+func (b *Bot) DisableChatCache() {
+	b.chatCache = nil
+}
+
+// InvalidateChatCache removes cached values of given chat. (including its members)
+//
+// Cached members which are not administrators are not removed, and expire with their TTL.
+func (b *Bot) InvalidateChatCache(chatID ChatID) {
+	if b.chatCache == nil {
+		return
+	}
+
+	b.chatCache.Delete(chatCacheKey(chatID))
+	b.chatCache.Delete(chatAdminsCacheKey(chatID))
+}
+
+// InvalidateChatMemberCache removes cached values of given chat member. (including chat administrators)
+func (b *Bot) InvalidateChatMemberCache(chatID ChatID, userID int64) {
+	if b.chatCache == nil {
+		return
+	}
+
+	b.chatCache.Delete(chatMemberCacheKey(chatID, userID))
+	b.chatCache.Delete(chatAdminsCacheKey(chatID))
+}
+
+// cache key for a chat
+func chatCacheKey(chatID ChatID) string {
+	return fmt.Sprintf("chat:%v", chatID)
+}
+
+// cache key for administrators of a chat
+func chatAdminsCacheKey(chatID ChatID) string {
+	return fmt.Sprintf("chat_admins:%v", chatID)
+}
+
+// cache key for a chat member
+func chatMemberCacheKey(chatID ChatID, userID int64) string {
+	return fmt.Sprintf("chat_member:%v:%d", chatID, userID)
+}
+
+// return cached value of given key, or request and cache it
+func cachedRequest[T any](b *Bot, key string, request func() APIResponse[T]) APIResponse[T] {
+	store := b.chatCache
+	if store == nil {
+		return request()
+	}
+
+	if cached, exists := store.Get(key); exists {
+		if result, ok := cached.(APIResponse[T]); ok {
+			b.verbose("returning cached value for: %s", key)
+			return result
+		}
+	}
+
+	result := request()
+	if result.Ok {
+		store.Set(key, result, b.chatCacheTTL)
+	}
+
+	return result
+}
+
+// invalidate cached values with given update
+func (b *Bot) invalidateChatCacheWithUpdate(update Update) {
+	if b.chatCache == nil {
+		return
+	}
+
+	// chat member updates
+	for _, updated := range []*ChatMemberUpdated{update.ChatMember, update.MyChatMember} {
+		if updated != nil {
+			b.InvalidateChatMemberCache(updated.Chat.ID, updated.NewChatMember.User.ID)
+			b.InvalidateChatCache(updated.Chat.ID) // NOTE: permissions of the chat may change
+		}
+	}
+
+	// service messages which change the chat
+	if message := update.Message; message != nil {
+		if message.NewChatTitle != nil ||
+			len(message.NewChatPhoto) > 0 ||
+			message.DeleteChatPhoto ||
+			message.PinnedMessage != nil ||
+			message.MigrateToChatID != 0 {
+			b.InvalidateChatCache(message.Chat.ID)
+		}
+
+		if message.LeftChatMember != nil {
+			b.InvalidateChatMemberCache(message.Chat.ID, message.LeftChatMember.ID)
+		}
+		for _, member := range message.NewChatMembers {
+			b.InvalidateChatMemberCache(message.Chat.ID, member.ID)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package telegrambot
+
+// Encoding small structs into compact `callback_data` of inline keyboard buttons (and back),
+// with optional HMAC signatures against forged callback queries.
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	callbackDataSeparator      = ":"
+	callbackDataSignatureBytes = 8 // bytes of truncated HMAC-SHA256 signatures (11 characters in base64)
+)
+
+// CallbackDataCodec encodes structs into `callback_data` strings, and decodes them back.
+//
+// Encoded data are a prefix and exported fields of the struct in declared order,
+// separated by ':' (eg. "vote:16:up", with integers in base 36), followed by a signature when the codec has a secret.
+// Fields can be strings, booleans, integers, or floats, and fields with tag `callback:"-"` are skipped.
+//
+//	type Vote struct {
+//		PollID int64
+//		Choice string
+//	}
+//
+//	codec := NewCallbackDataCodec([]byte("my-secret"))
+//	data, err := codec.Encode("vote", Vote{PollID: 42, Choice: "up"})
+//	...
+//	OnCallbackData(router, codec, "vote", func(ctx *Ctx, query CallbackQuery, vote Vote) error { ... })
+type CallbackDataCodec struct {
+	secret []byte
+}
+
+// NewCallbackDataCodec returns a new CallbackDataCodec.
+//
+// With a non-empty `secret`, encoded data are signed and decoding data with invalid signatures fails.
+// (signatures take 12 of the 64 bytes)
+func NewCallbackDataCodec(secret []byte) *CallbackDataCodec {
+	return &CallbackDataCodec{
+		secret: secret,
+	}
+}
+
+// Encode encodes `value` (a struct or a pointer to it) with `prefix` into a `callback_data` string.
+//
+// It fails if the encoded data exceed 64 bytes.
+func (c *CallbackDataCodec) Encode(prefix string, value any) (data string, err error) {
+	if strings.Contains(prefix, callbackDataSeparator) {
+		return "", fmt.Errorf("prefix of callback data should not contain '%s': '%s'", callbackDataSeparator, prefix)
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("callback data should be a struct, got %T", value)
+	}
+
+	parts := []string{prefix}
+	for _, field := range callbackDataFields(v.Type()) {
+		encoded, err := encodeCallbackDataField(v.Field(field))
+		if err != nil {
+			return "", fmt.Errorf("failed to encode field '%s' of callback data: %w", v.Type().Field(field).Name, err)
+		}
+		parts = append(parts, encoded)
+	}
+
+	data = strings.Join(parts, callbackDataSeparator)
+	if c.signed() {
+		data += callbackDataSeparator + c.sign(data)
+	}
+
+	if len(data) > defaultCallbackDataMaxBytes {
+		return "", fmt.Errorf("encoded callback data exceed %d bytes: %d bytes", defaultCallbackDataMaxBytes, len(data))
+	}
+
+	return data, nil
+}
+
+// Button returns an inline keyboard button with `callback_data` encoded from `value` with `prefix`.
+func (c *CallbackDataCodec) Button(text, prefix string, value any) (button InlineKeyboardButton, err error) {
+	data, err := c.Encode(prefix, value)
+	if err != nil {
+		return button, err
+	}
+	return InlineKeyboardButton{Text: text, CallbackData: &data}, nil
+}
+
+// Decode decodes `data` encoded with `prefix` into `out`, which should be a pointer to a struct.
+//
+// It fails if `data` has another prefix, or has an invalid signature.
+func (c *CallbackDataCodec) Decode(prefix, data string, out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("callback data should be decoded into a pointer to a struct, got %T", out)
+	}
+	v = v.Elem()
+
+	if !HasCallbackDataPrefix(data, prefix) {
+		return fmt.Errorf("callback data does not have prefix '%s'", prefix)
+	}
+
+	if c.signed() {
+		index := strings.LastIndex(data, callbackDataSeparator)
+		if index < 0 || !hmac.Equal([]byte(data[index+1:]), []byte(c.sign(data[:index]))) {
+			return fmt.Errorf("invalid signature of callback data")
+		}
+		data = data[:index]
+	}
+
+	parts := strings.Split(data, callbackDataSeparator)[1:]
+	fields := callbackDataFields(v.Type())
+	if len(parts) != len(fields) {
+		return fmt.Errorf("callback data has %d fields, expected %d", len(parts), len(fields))
+	}
+
+	for i, field := range fields {
+		if err := decodeCallbackDataField(parts[i], v.Field(field)); err != nil {
+			return fmt.Errorf("failed to decode field '%s' of callback data: %w", v.Type().Field(field).Name, err)
+		}
+	}
+
+	return nil
+}
+
+// HasCallbackDataPrefix returns whether `data` was encoded with `prefix` or not.
+func HasCallbackDataPrefix(data, prefix string) bool {
+	return data == prefix || strings.HasPrefix(data, prefix+callbackDataSeparator)
+}
+
+// OnCallbackData registers a handler for `callback_query`s whose data were encoded with `prefix` by `codec`,
+// and passes the decoded struct of type T to the handler.
+//
+// When the data cannot be decoded (eg. forged data with invalid signatures), the query is consumed
+// without calling the handler, and an error is returned.
+func OnCallbackData[T any](router *Router, codec *CallbackDataCodec, prefix string, handler func(ctx *Ctx, query CallbackQuery, data T) error) {
+	router.add(func(update Update) (Handler, bool) {
+		query := update.CallbackQuery
+		if query == nil || query.Data == nil || !HasCallbackDataPrefix(*query.Data, prefix) {
+			return nil, false
+		}
+
+		return func(ctx *Ctx) error {
+			var data T
+			if err := codec.Decode(prefix, *query.Data, &data); err != nil {
+				return fmt.Errorf("failed to decode callback data of query '%s': %w", query.ID, err)
+			}
+			return handler(ctx, *query, data)
+		}, true
+	})
+}
+
+// whether encoded data are signed or not
+func (c *CallbackDataCodec) signed() bool {
+	return len(c.secret) > 0
+}
+
+// truncated HMAC-SHA256 signature of given data
+func (c *CallbackDataCodec) sign(data string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:callbackDataSignatureBytes])
+}
+
+// indices of encoded fields of given struct type
+func callbackDataFields(t reflect.Type) (fields []int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && field.Tag.Get("callback") != "-" {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+// encode a field into a string without separators
+func encodeCallbackDataField(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return escapeCallbackDataField(v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", nil
+		}
+		return "0", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 36), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 36), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	}
+	return "", fmt.Errorf("not supported type: %s", v.Type())
+}
+
+// decode a string encoded with encodeCallbackDataField() into a field
+func decodeCallbackDataField(s string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		unescaped, err := unescapeCallbackDataField(s)
+		if err != nil {
+			return err
+		}
+		v.SetString(unescaped)
+	case reflect.Bool:
+		v.SetBool(s == "1")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 36, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 36, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("not supported type: %s", v.Type())
+	}
+	return nil
+}
+
+// escape '%' and separators in a string field
+func escapeCallbackDataField(s string) string {
+	return strings.NewReplacer("%", "%25", callbackDataSeparator, "%3A").Replace(s)
+}
+
+// unescape a string field escaped with escapeCallbackDataField()
+func unescapeCallbackDataField(s string) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			builder.WriteByte(s[i])
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", fmt.Errorf("malformed escape in '%s'", s)
+		}
+		switch s[i+1 : i+3] {
+		case "25":
+			builder.WriteByte('%')
+		case "3A":
+			builder.WriteString(callbackDataSeparator)
+		default:
+			return "", fmt.Errorf("malformed escape in '%s'", s)
+		}
+		i += 2
+	}
+	return builder.String(), nil
+}
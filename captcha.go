@@ -0,0 +1,394 @@
+package telegrambot
+
+// Opt-in verification of new members in groups: restrict on join, challenge, then lift restrictions or kick.
+
+import (
+	"fmt"
+	"html"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CaptchaChallenge is a type of challenge for new members
+type CaptchaChallenge string
+
+// CaptchaChallenge strings
+const (
+	CaptchaChallengeButton CaptchaChallenge = "button" // press a button
+	CaptchaChallengeEmoji  CaptchaChallenge = "emoji"  // choose the requested emoji among others
+)
+
+// default values of CaptchaConfig
+const (
+	defaultCaptchaTimeout      = 2 * time.Minute
+	defaultCaptchaMaxAttempts  = 3
+	defaultCaptchaButtonText   = "✅ I'm not a robot"
+	defaultCaptchaEmojiChoices = 4
+
+	captchaResolvedRetention = 10 * time.Minute // duplicated joins (eg. late `chat_member` updates) are dropped for this duration after resolution
+
+	captchaCallbackPrefix = "captcha:"
+	captchaButtonAnswer   = "ok"
+)
+
+// default candidates of emojis for CaptchaChallengeEmoji
+var _defaultCaptchaEmojis = []string{"🍎", "🍌", "🍇", "🍉", "🚗", "🚲", "✈️", "⚽", "🐶", "🐱", "🐟", "🌵"}
+
+// CaptchaConfig is a configuration for EnableCaptcha()
+type CaptchaConfig struct {
+	Challenge   CaptchaChallenge // default: CaptchaChallengeButton
+	Timeout     time.Duration    // members who do not pass in time are kicked (default: 2 minutes)
+	MaxAttempts int              // members who choose wrong answers this many times are kicked (default: 3)
+
+	// text of the challenge message in HTML, where `answer` is the emoji to choose (empty for CaptchaChallengeButton)
+	//
+	// default: a greeting with the member's mention
+	Text func(user User, answer string) string
+
+	ButtonText   string   // text of the button for CaptchaChallengeButton
+	Emojis       []string // candidates of emojis for CaptchaChallengeEmoji
+	EmojiChoices int      // number of emojis shown for CaptchaChallengeEmoji (default: 4)
+
+	// permissions granted to members who passed (default: the chat's default permissions)
+	Permissions *ChatPermissions
+
+	OnPassed func(b *Bot, chat Chat, user User) // called when a member passed
+	OnFailed func(b *Bot, chat Chat, user User) // called when a member ran out of attempts or timed out, after being kicked
+}
+
+// fill default values of the configuration
+func (c CaptchaConfig) withDefaults() CaptchaConfig {
+	if c.Challenge == "" {
+		c.Challenge = CaptchaChallengeButton
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultCaptchaTimeout
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultCaptchaMaxAttempts
+	}
+	if c.Text == nil {
+		c.Text = defaultCaptchaText
+	}
+	if c.ButtonText == "" {
+		c.ButtonText = defaultCaptchaButtonText
+	}
+	if len(c.Emojis) == 0 {
+		c.Emojis = _defaultCaptchaEmojis
+	}
+	if c.EmojiChoices <= 1 {
+		c.EmojiChoices = defaultCaptchaEmojiChoices
+	}
+	if c.EmojiChoices > len(c.Emojis) {
+		c.EmojiChoices = len(c.Emojis)
+	}
+	return c
+}
+
+// default text of challenge messages
+func defaultCaptchaText(user User, answer string) string {
+	mention := fmt.Sprintf(`<a href="%s">%s</a>`, user.InlineLink(), html.EscapeString(user.FirstName))
+	if answer == "" {
+		return fmt.Sprintf("Welcome, %s! Press the button below to start chatting.", mention)
+	}
+	return fmt.Sprintf("Welcome, %s! Choose %s below to start chatting.", mention, answer)
+}
+
+// key of a pending challenge
+type captchaKey struct {
+	chatID int64
+	userID int64
+}
+
+// a challenge waiting for the answer of a member
+type pendingCaptcha struct {
+	chat      Chat
+	user      User
+	answer    string // empty until the challenge message is sent
+	messageID int64
+	attempts  int // number of wrong answers
+	timer     *time.Timer
+}
+
+// state of new member verification
+type captcha struct {
+	config CaptchaConfig
+
+	pending  map[captchaKey]*pendingCaptcha
+	resolved map[captchaKey]time.Time // time of resolution of recent challenges
+	lock     sync.Mutex
+}
+
+// EnableCaptcha enables the verification of new members in groups where the bot is an administrator.
+//
+// When a member joins, the member is restricted to read-only and a challenge message is posted.
+// Restrictions are lifted when the member answers correctly, and the member is kicked after `MaxAttempts` wrong answers or on timeout.
+//
+// It is added with AddUpdateHandler(), and consumes callback queries of its challenges.
+// Joins are detected from `new_chat_members` of messages, and also from `chat_member` updates
+// when they are included in `allowed_updates`.
+//
+//	client.EnableCaptcha(CaptchaConfig{
+//		Challenge: CaptchaChallengeEmoji,
+//		Timeout:   time.Minute,
+//	})
+func (b *Bot) EnableCaptcha(config CaptchaConfig) {
+	c := &captcha{
+		config:   config.withDefaults(),
+		pending:  map[captchaKey]*pendingCaptcha{},
+		resolved: map[captchaKey]time.Time{},
+	}
+
+	b.AddUpdateHandler("captcha", func(ctx *Ctx) error {
+		return c.handle(ctx)
+	})
+}
+
+// handle an update for verification
+func (c *captcha) handle(ctx *Ctx) error {
+	update := ctx.Update
+
+	if update.Message != nil && len(update.Message.NewChatMembers) > 0 {
+		for _, user := range update.Message.NewChatMembers {
+			if err := c.challenge(ctx.Bot, update.Message.Chat, user, update.Message.Date); err != nil {
+				return err
+			}
+		}
+	}
+
+	if updated := update.ChatMember; updated != nil {
+		if updated.Joined() {
+			return c.challenge(ctx.Bot, updated.Chat, updated.NewChatMember.User, updated.Date)
+		} else if updated.Left() {
+			c.cancel(ctx.Bot, captchaKey{chatID: updated.Chat.ID, userID: updated.NewChatMember.User.ID})
+		}
+	}
+
+	if query := update.CallbackQuery; query != nil && query.Data != nil && strings.HasPrefix(*query.Data, captchaCallbackPrefix) {
+		ctx.Consume()
+
+		return c.answer(ctx.Bot, *query)
+	}
+
+	return nil
+}
+
+// restrict given member who joined at `date` (unix timestamp), and post a challenge
+func (c *captcha) challenge(b *Bot, chat Chat, user User, date int) error {
+	if user.IsBot || chat.Type == ChatTypePrivate || chat.Type == ChatTypeChannel {
+		return nil
+	}
+
+	key := captchaKey{chatID: chat.ID, userID: user.ID}
+	pending := &pendingCaptcha{chat: chat, user: user}
+
+	c.lock.Lock()
+	if _, exists := c.pending[key]; exists {
+		c.lock.Unlock()
+		return nil // already challenged (eg. both `new_chat_members` and `chat_member` were received)
+	}
+	if resolvedAt, exists := c.resolved[key]; exists && int64(date) < resolvedAt.Unix() {
+		c.lock.Unlock()
+		return nil // a join which was already resolved (eg. `chat_member` was received after the challenge)
+	}
+	c.pending[key] = pending
+	c.lock.Unlock()
+
+	if res := b.RestrictChatMember(chat.ID, user.ID, ReadOnlyChatPermissions(), nil); !res.Ok {
+		c.remove(key, false)
+		return fmt.Errorf("failed to restrict new member %d in chat %d: %w", user.ID, chat.ID, res.Err())
+	}
+
+	keyboard, answer := c.keyboard(user)
+
+	sent := b.SendMessage(chat.ID, c.config.Text(user, answer), OptionsSendMessage{}.
+		SetParseMode(ParseModeHTML).
+		SetReplyMarkup(keyboard))
+	if !sent.Ok {
+		// do not leave the member restricted forever
+		c.remove(key, false)
+		_ = b.RestrictChatMember(chat.ID, user.ID, c.permissions(b, chat), nil)
+
+		return fmt.Errorf("failed to send challenge to new member %d in chat %d: %w", user.ID, chat.ID, sent.Err())
+	}
+
+	// NOTE: answers are accepted only after the message id is set, so that the message is always deleted
+	c.lock.Lock()
+	if c.pending[key] != pending {
+		c.lock.Unlock()
+
+		_ = b.DeleteMessage(chat.ID, sent.Result.MessageID) // cancelled while sending (eg. the member left)
+		return nil
+	}
+	pending.messageID = sent.Result.MessageID
+	pending.answer = answer
+	if pending.answer == "" {
+		pending.answer = captchaButtonAnswer
+	}
+	pending.timer = time.AfterFunc(c.config.Timeout, func() {
+		b.verbose("new member %d timed out in chat %d", user.ID, chat.ID)
+
+		c.resolve(b, key, false)
+	})
+	c.lock.Unlock()
+
+	b.verbose("challenged new member %d in chat %d", user.ID, chat.ID)
+
+	return nil
+}
+
+// build the inline keyboard of a challenge for given user, and return it with the answer
+func (c *captcha) keyboard(user User) (keyboard InlineKeyboardMarkup, answer string) {
+	prefix := fmt.Sprintf("%s%d:", captchaCallbackPrefix, user.ID)
+
+	choices := []string{captchaButtonAnswer}
+	if c.config.Challenge == CaptchaChallengeEmoji {
+		choices = nil
+		for _, idx := range rand.Perm(len(c.config.Emojis))[:c.config.EmojiChoices] {
+			choices = append(choices, c.config.Emojis[idx])
+		}
+		answer = choices[rand.Intn(len(choices))]
+	}
+
+	buttons := []InlineKeyboardButton{}
+	for _, choice := range choices {
+		text, callbackData := choice, prefix+choice
+		if choice == captchaButtonAnswer {
+			text = c.config.ButtonText
+		}
+		buttons = append(buttons, InlineKeyboardButton{
+			Text:         text,
+			CallbackData: &callbackData,
+		})
+	}
+
+	return InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{buttons}}, answer
+}
+
+// handle a callback query from a challenge message
+func (c *captcha) answer(b *Bot, query CallbackQuery) error {
+	userID, choice, _ := strings.Cut(strings.TrimPrefix(*query.Data, captchaCallbackPrefix), ":")
+	if strconv.FormatInt(query.From.ID, 10) != userID {
+		return b.AnswerCallbackQuery(query.ID, OptionsAnswerCallbackQuery{}.
+			SetText("This is not for you.").
+			SetShowAlert(true)).Err()
+	}
+	if query.Message == nil {
+		return b.AnswerCallbackQuery(query.ID, nil).Err()
+	}
+
+	key := captchaKey{chatID: query.Message.Chat.ID, userID: query.From.ID}
+
+	c.lock.Lock()
+	pending, exists := c.pending[key]
+	ready := exists && pending.answer != ""
+	passed := ready && pending.answer == choice
+	remaining := 0
+	if ready && !passed {
+		pending.attempts++
+		remaining = c.config.MaxAttempts - pending.attempts
+	}
+	c.lock.Unlock()
+
+	if !exists {
+		return b.AnswerCallbackQuery(query.ID, OptionsAnswerCallbackQuery{}.SetText("This challenge has expired.")).Err()
+	}
+	if !ready {
+		return b.AnswerCallbackQuery(query.ID, OptionsAnswerCallbackQuery{}.SetText("Please try again.")).Err()
+	}
+	if !passed && remaining > 0 {
+		return b.AnswerCallbackQuery(query.ID, OptionsAnswerCallbackQuery{}.
+			SetText(fmt.Sprintf("Wrong answer. (%d attempt(s) left)", remaining))).Err()
+	}
+
+	text := "Welcome!"
+	if !passed {
+		text = "Wrong answer."
+	}
+	res := b.AnswerCallbackQuery(query.ID, OptionsAnswerCallbackQuery{}.SetText(text))
+
+	c.resolve(b, key, passed)
+
+	return res.Err()
+}
+
+// finish a pending challenge: lift restrictions of the member if passed, or kick the member
+func (c *captcha) resolve(b *Bot, key captchaKey, passed bool) {
+	pending := c.remove(key, true)
+	if pending == nil {
+		return // already resolved
+	}
+
+	if pending.messageID != 0 {
+		if res := b.DeleteMessage(pending.chat.ID, pending.messageID); !res.Ok {
+			b.error("failed to delete challenge message in chat %d: %s", pending.chat.ID, res.Err())
+		}
+	}
+
+	if passed {
+		permissions := c.permissions(b, pending.chat)
+		if res := b.RestrictChatMember(key.chatID, key.userID, permissions, nil); !res.Ok {
+			b.error("failed to lift restrictions of member %d in chat %d: %s", key.userID, key.chatID, res.Err())
+		}
+
+		if c.config.OnPassed != nil {
+			c.config.OnPassed(b, pending.chat, pending.user)
+		}
+	} else {
+		// kick = ban + unban, so that the member can join again later
+		if res := b.BanChatMember(key.chatID, key.userID, nil); !res.Ok {
+			b.error("failed to kick member %d from chat %d: %s", key.userID, key.chatID, res.Err())
+		} else if res := b.UnbanChatMember(key.chatID, key.userID, true); !res.Ok {
+			b.error("failed to unban kicked member %d in chat %d: %s", key.userID, key.chatID, res.Err())
+		}
+
+		if c.config.OnFailed != nil {
+			c.config.OnFailed(b, pending.chat, pending.user)
+		}
+	}
+}
+
+// cancel a pending challenge of a member who left
+func (c *captcha) cancel(b *Bot, key captchaKey) {
+	if pending := c.remove(key, true); pending != nil && pending.messageID != 0 {
+		_ = b.DeleteMessage(pending.chat.ID, pending.messageID)
+	}
+}
+
+// remove a pending challenge and stop its timer, remembering it as resolved if `resolved` is true
+func (c *captcha) remove(key captchaKey, resolved bool) *pendingCaptcha {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	for k, resolvedAt := range c.resolved {
+		if now.Sub(resolvedAt) > captchaResolvedRetention {
+			delete(c.resolved, k)
+		}
+	}
+
+	pending, exists := c.pending[key]
+	if !exists {
+		return nil
+	}
+	delete(c.pending, key)
+	if resolved {
+		c.resolved[key] = now
+	}
+
+	if pending.timer != nil {
+		pending.timer.Stop()
+	}
+	return pending
+}
+
+// permissions for members who passed
+func (c *captcha) permissions(b *Bot, chat Chat) ChatPermissions {
+	if c.config.Permissions != nil {
+		return *c.config.Permissions
+	}
+	return b.defaultChatPermissions(chat.ID)
+}
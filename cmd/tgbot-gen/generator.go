@@ -0,0 +1,355 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// types which are written by hand and never generated
+var _handwrittenTypes = map[string]bool{
+	"InputFile": true,
+}
+
+// generator generates Go source codes from the specification
+type generator struct {
+	spec    Spec
+	pkg     string
+	methods map[string]bool // nil for all methods
+	types   map[string]bool // nil for all types
+}
+
+// create a new generator
+func newGenerator(spec Spec, pkg string, methods, types map[string]bool) *generator {
+	return &generator{
+		spec:    spec,
+		pkg:     pkg,
+		methods: methods,
+		types:   types,
+	}
+}
+
+// generate functions of output files, keyed by their names
+func (g *generator) files() map[string]func() string {
+	return map[string]func() string{
+		"methods_gen.go":         g.generateMethods,
+		"methods_options_gen.go": g.generateOptions,
+		"types_gen.go":           g.generateTypes,
+	}
+}
+
+// header of generated files
+func (g *generator) header() string {
+	return fmt.Sprintf(`// Code generated by tgbot-gen from %s (%s); DO NOT EDIT.
+
+package %s
+
+`, g.spec.Version, g.spec.ReleaseDate, g.pkg)
+}
+
+// names of methods to be generated
+func (g *generator) methodNames() (names []string) {
+	for _, name := range sortedKeys(g.spec.Methods) {
+		if g.methods == nil || g.methods[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// names of types to be generated
+func (g *generator) typeNames() (names []string) {
+	for _, name := range sortedKeys(g.spec.Types) {
+		if _handwrittenTypes[name] {
+			continue
+		}
+
+		// subtypes are merged into their parent type
+		if len(g.spec.Types[name].SubtypeOf) > 0 {
+			continue
+		}
+		if g.types == nil || g.types[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+////////////////////////////////
+// methods
+//
+
+// generate method wrappers
+func (g *generator) generateMethods() string {
+	var sb strings.Builder
+	sb.WriteString(g.header())
+
+	requestFuncs := map[string]string{}
+
+	for _, name := range g.methodNames() {
+		method := g.spec.Methods[name]
+		funcName := goName(method.Name)
+		responseType, requestFunc := responseTypeOf(method.Returns)
+		requestFuncs[requestFunc] = responseType
+
+		var params []string
+		var essentials []SpecField
+		hasOptions := false
+		for _, field := range method.Fields {
+			if field.Required {
+				params = append(params, fmt.Sprintf("%s %s", paramName(field.Name), goType(field.Types)))
+				essentials = append(essentials, field)
+			} else {
+				hasOptions = true
+			}
+		}
+		if hasOptions {
+			params = append(params, fmt.Sprintf("options Options%s", funcName))
+		}
+
+		fmt.Fprintf(&sb, "// %s\n//\n// %s\n", methodSummary(funcName, method.Description), method.Href)
+		fmt.Fprintf(&sb, "func (b *Bot) %s(%s) (result %s) {\n", funcName, strings.Join(params, ", "), responseType)
+		if hasOptions {
+			sb.WriteString("\tif options == nil {\n\t\toptions = map[string]any{}\n\t}\n")
+		} else {
+			sb.WriteString("\toptions := map[string]any{}\n")
+		}
+		if len(essentials) > 0 {
+			sb.WriteString("\n\t// essential params\n")
+			for _, field := range essentials {
+				fmt.Fprintf(&sb, "\toptions[%q] = %s\n", field.Name, paramName(field.Name))
+			}
+		}
+		fmt.Fprintf(&sb, "\n\treturn b.%s(%q, options)\n}\n\n", requestFunc, method.Name)
+	}
+
+	// list request functions needed by generated methods, for review
+	sb.WriteString("// request functions used by generated methods:\n//\n")
+	for _, requestFunc := range sortedKeys(requestFuncs) {
+		fmt.Fprintf(&sb, "//   - %s (returns %s)\n", requestFunc, requestFuncs[requestFunc])
+	}
+
+	return sb.String()
+}
+
+// response type and request function name for given return types
+func responseTypeOf(returns []string) (responseType, requestFunc string) {
+	if len(returns) == 2 && returns[0] == "Message" && returns[1] == "True" {
+		return "APIResponseMessageOrBool", "requestMessageOrBool"
+	}
+	if len(returns) != 1 {
+		return "APIResponse[any]", "requestAny"
+	}
+
+	t := goTypeOf(returns[0])
+	switch t {
+	case "bool":
+		return "APIResponse[bool]", "requestBool"
+	case "int64":
+		return "APIResponse[int]", "requestInt"
+	case "string":
+		return "APIResponse[string]", "requestString"
+	}
+
+	if strings.HasPrefix(t, "[]") {
+		return fmt.Sprintf("APIResponse[%s]", t), "request" + strings.TrimPrefix(t, "[]") + "s"
+	}
+	return fmt.Sprintf("APIResponse[%s]", t), "request" + t
+}
+
+// one-line summary of a method
+//
+// eg. "Use this method to send text messages." => "SendMessage sends text messages."
+func methodSummary(funcName string, description []string) string {
+	if len(description) == 0 {
+		return funcName + " function"
+	}
+
+	sentence := firstSentence(description[0])
+	if rest, found := strings.CutPrefix(sentence, "Use this method to "); found {
+		verb, object, _ := strings.Cut(rest, " ")
+		return strings.TrimSpace(fmt.Sprintf("%s %s %s", funcName, thirdPerson(verb), object))
+	}
+	if strings.HasPrefix(sentence, "A ") || strings.HasPrefix(sentence, "An ") {
+		return fmt.Sprintf("%s is %s%s", funcName, strings.ToLower(sentence[:1]), sentence[1:])
+	}
+	return fmt.Sprintf("%s: %s", funcName, sentence)
+}
+
+// third person singular form of given verb
+func thirdPerson(verb string) string {
+	switch {
+	case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "sh"), strings.HasSuffix(verb, "ch"), strings.HasSuffix(verb, "x"), strings.HasSuffix(verb, "z"):
+		return verb + "es"
+	case strings.HasSuffix(verb, "y") && len(verb) > 1 && !strings.ContainsAny(verb[len(verb)-2:len(verb)-1], "aeiou"):
+		return verb[:len(verb)-1] + "ies"
+	}
+	return verb + "s"
+}
+
+////////////////////////////////
+// options
+//
+
+// generate Options types of methods
+func (g *generator) generateOptions() string {
+	var sb strings.Builder
+	sb.WriteString(g.header())
+
+	for _, name := range g.methodNames() {
+		method := g.spec.Methods[name]
+		funcName := goName(method.Name)
+		optionsName := "Options" + funcName
+
+		var optionals []SpecField
+		for _, field := range method.Fields {
+			if !field.Required {
+				optionals = append(optionals, field)
+			}
+		}
+		if len(optionals) == 0 {
+			continue
+		}
+
+		var keys []string
+		for _, field := range optionals {
+			keys = append(keys, fmt.Sprintf("`%s`", field.Name))
+		}
+
+		fmt.Fprintf(&sb, "// %s struct for %s().\n//\n", optionsName, funcName)
+		fmt.Fprintf(&sb, "// options include: %s.\n//\n", joinWithAnd(keys))
+		fmt.Fprintf(&sb, "// %s\n", method.Href)
+		fmt.Fprintf(&sb, "type %s MethodOptions\n\n", optionsName)
+
+		for _, field := range optionals {
+			param := paramName(field.Name)
+			t := goType(field.Types)
+
+			fmt.Fprintf(&sb, "// Set%s sets the `%s` value of %s.\n", goName(field.Name), field.Name, optionsName)
+			if t == "any" || t == "[]any" {
+				var types []string
+				for _, ft := range field.Types {
+					types = append(types, goTypeOf(ft))
+				}
+				fmt.Fprintf(&sb, "//\n// `%s` can be one of %s.\n", param, joinWithOr(types))
+			}
+			fmt.Fprintf(&sb, "func (o %s) Set%s(%s %s) %s {\n", optionsName, goName(field.Name), param, t, optionsName)
+			fmt.Fprintf(&sb, "\to[%q] = %s\n\treturn o\n}\n\n", field.Name, param)
+		}
+	}
+
+	return sb.String()
+}
+
+////////////////////////////////
+// types
+//
+
+// generate structs of types
+func (g *generator) generateTypes() string {
+	var sb strings.Builder
+	sb.WriteString(g.header())
+
+	for _, name := range g.typeNames() {
+		typ := g.spec.Types[name]
+		fields := typ.Fields
+
+		// types with subtypes are merged into one struct, like ChatMember or InputMedia
+		if len(typ.Subtypes) > 0 {
+			fields = g.mergedFields(typ.Subtypes)
+		}
+
+		fmt.Fprintf(&sb, "// %s\n//\n", typeSummary(typ))
+		if len(typ.Subtypes) > 0 {
+			fmt.Fprintf(&sb, "// merged from: %s\n//\n", joinWithAnd(typ.Subtypes))
+		}
+		fmt.Fprintf(&sb, "// %s\n", typ.Href)
+
+		if len(fields) == 0 {
+			fmt.Fprintf(&sb, "type %s struct{}\n\n", goName(typ.Name))
+			continue
+		}
+
+		fmt.Fprintf(&sb, "type %s struct {\n", goName(typ.Name))
+		for _, field := range fields {
+			tag := field.Name
+			if !field.Required {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&sb, "\t%s %s `json:\"%s\"`\n", goName(field.Name), structFieldType(field), tag)
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	return sb.String()
+}
+
+// merge fields of given subtypes; fields are required only when they are required in all subtypes
+func (g *generator) mergedFields(subtypes []string) (merged []SpecField) {
+	indices := map[string]int{}
+	counts := map[string]int{}
+
+	for _, subtype := range subtypes {
+		for _, field := range g.spec.Types[subtype].Fields {
+			counts[field.Name]++
+			if idx, exists := indices[field.Name]; exists {
+				merged[idx].Required = merged[idx].Required && field.Required
+				continue
+			}
+			indices[field.Name] = len(merged)
+			merged = append(merged, field)
+		}
+	}
+
+	for i, field := range merged {
+		if counts[field.Name] < len(subtypes) {
+			merged[i].Required = false
+		}
+	}
+
+	return merged
+}
+
+// one-line summary of a type
+//
+// eg. "This object represents a Telegram user or bot." => "User is a struct of a Telegram user or bot"
+func typeSummary(typ SpecType) string {
+	name := goName(typ.Name)
+	if len(typ.Description) == 0 {
+		return name + " struct"
+	}
+
+	sentence := strings.TrimSuffix(firstSentence(typ.Description[0]), ".")
+	if rest, found := strings.CutPrefix(sentence, "This object "); found {
+		verb, object, _ := strings.Cut(rest, " ")
+		switch verb {
+		case "represents", "describes":
+			return fmt.Sprintf("%s is a struct of %s", name, object)
+		default:
+			return fmt.Sprintf("%s is a struct which %s %s", name, verb, object)
+		}
+	}
+	return name + " struct"
+}
+
+// join given words like "a, b, and c"
+func joinWithAnd(words []string) string {
+	return joinWith(words, "and")
+}
+
+// join given words like "a, b, or c"
+func joinWithOr(words []string) string {
+	return joinWith(words, "or")
+}
+
+// join given words with a conjunction
+func joinWith(words []string, conjunction string) string {
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		return words[0]
+	case 2:
+		return fmt.Sprintf("%s %s %s", words[0], conjunction, words[1])
+	}
+	return fmt.Sprintf("%s, %s %s", strings.Join(words[:len(words)-1], ", "), conjunction, words[len(words)-1])
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update golden files with generated ones (`go test ./cmd/tgbot-gen -update`)
+var _update = flag.Bool("update", false, "update golden files in testdata/golden/")
+
+// TestGeneratedFiles tests generated files from testdata/api.json against golden files in testdata/golden/.
+func TestGeneratedFiles(t *testing.T) {
+	spec, err := loadSpec(filepath.Join("testdata", "api.json"))
+	if err != nil {
+		t.Fatalf("failed to load specification: %s", err)
+	}
+
+	g := newGenerator(spec, "telegrambot", nil, nil)
+	for filename, generate := range g.files() {
+		filename, generate := filename, generate
+		t.Run(filename, func(t *testing.T) {
+			generated, err := formatSource(generate())
+			if err != nil {
+				t.Fatalf("failed to format %s: %s", filename, err)
+			}
+
+			golden := filepath.Join("testdata", "golden", filename+".golden")
+			if *_update {
+				if err := os.WriteFile(golden, generated, 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			if !bytes.Equal(generated, expected) {
+				t.Errorf("generated %s differs from %s:\n%s", filename, golden, generated)
+			}
+		})
+	}
+}
+
+// TestGeneratedFilesWithFilters tests generating only given methods and types.
+func TestGeneratedFilesWithFilters(t *testing.T) {
+	spec, err := loadSpec(filepath.Join("testdata", "api.json"))
+	if err != nil {
+		t.Fatalf("failed to load specification: %s", err)
+	}
+
+	g := newGenerator(spec, "telegrambot", splitNames("getMe, sendMessage"), splitNames("User"))
+	if names := g.methodNames(); len(names) != 2 || names[0] != "getMe" || names[1] != "sendMessage" {
+		t.Errorf("unexpected methods: %v", names)
+	}
+	if names := g.typeNames(); len(names) != 1 || names[0] != "User" {
+		t.Errorf("unexpected types: %v", names)
+	}
+}
@@ -0,0 +1,118 @@
+// tgbot-gen: generates Options types, method wrappers, and structs of telegram-bot-go
+// from a machine-readable Bot API specification.
+//
+// The specification is expected in the format of
+// https://github.com/PaulSonOfLars/telegram-bot-api-spec (api.json).
+//
+// Usage:
+//
+//	$ go run ./cmd/tgbot-gen -spec api.json -out ./generated
+//	$ go run ./cmd/tgbot-gen -spec api.json -out ./generated -methods sendMessage,sendPhoto -types Message
+//
+// Generated files are meant to be reviewed and merged into methods.go, methods_options.go, and types.go.
+//
+// last update: 2026.10.17.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path of the Bot API specification (api.json)")
+	outDir := flag.String("out", "generated", "output directory of generated files")
+	pkg := flag.String("package", "telegrambot", "package name of generated files")
+	methods := flag.String("methods", "", "comma-separated names of methods to generate (default: all)")
+	types := flag.String("types", "", "comma-separated names of types to generate (default: all)")
+	flag.Parse()
+
+	if *specPath == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	spec, err := loadSpec(*specPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load specification: %s\n", err)
+		os.Exit(1)
+	}
+
+	g := newGenerator(spec, *pkg, splitNames(*methods), splitNames(*types))
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create output directory: %s\n", err)
+		os.Exit(1)
+	}
+
+	for filename, generate := range g.files() {
+		path := filepath.Join(*outDir, filename)
+		if err := writeSource(path, generate()); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write %s: %s\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("generated: %s\n", path)
+	}
+}
+
+// split comma-separated names into a set
+func splitNames(str string) map[string]bool {
+	if str == "" {
+		return nil
+	}
+
+	names := map[string]bool{}
+	for _, name := range strings.Split(str, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// format and write generated source code to given path
+func writeSource(path, src string) error {
+	formatted, err := formatSource(src)
+	if err != nil {
+		// write unformatted source for debugging
+		_ = os.WriteFile(path, []byte(src), 0644)
+
+		return err
+	}
+
+	return os.WriteFile(path, formatted, 0644)
+}
+
+// format generated source code
+func formatSource(src string) ([]byte, error) {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return nil, fmt.Errorf("generated code is not valid: %s", err)
+	}
+	return formatted, nil
+}
+
+// load the specification from given path
+func loadSpec(path string) (spec Spec, err error) {
+	var bytes []byte
+	if bytes, err = os.ReadFile(path); err == nil {
+		err = json.Unmarshal(bytes, &spec)
+	}
+	return spec, err
+}
+
+// return sorted keys of given map
+func sortedKeys[T any](m map[string]T) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Spec is the machine-readable Bot API specification
+type Spec struct {
+	Version     string                `json:"version"`
+	ReleaseDate string                `json:"release_date"`
+	Changelog   string                `json:"changelog"`
+	Methods     map[string]SpecMethod `json:"methods"`
+	Types       map[string]SpecType   `json:"types"`
+}
+
+// SpecMethod is a method in the specification
+type SpecMethod struct {
+	Name        string      `json:"name"`
+	Href        string      `json:"href"`
+	Description []string    `json:"description,omitempty"`
+	Returns     []string    `json:"returns"`
+	Fields      []SpecField `json:"fields,omitempty"`
+}
+
+// SpecType is a type in the specification
+type SpecType struct {
+	Name        string      `json:"name"`
+	Href        string      `json:"href"`
+	Description []string    `json:"description,omitempty"`
+	Fields      []SpecField `json:"fields,omitempty"`
+	Subtypes    []string    `json:"subtypes,omitempty"`
+	SubtypeOf   []string    `json:"subtype_of,omitempty"`
+}
+
+// SpecField is a field of a method or a type in the specification
+type SpecField struct {
+	Name        string   `json:"name"`
+	Types       []string `json:"types"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+}
+
+// initialisms which are kept in upper case in Go names
+var _initialisms = map[string]bool{
+	"id":    true,
+	"url":   true,
+	"ip":    true,
+	"http":  true,
+	"https": true,
+	"json":  true,
+}
+
+// convert a snake_case or camelCase name to a PascalCase Go name
+//
+// eg. "message_thread_id" => "MessageThreadID", "sendMessage" => "SendMessage"
+func goName(name string) string {
+	var words []string
+	for _, word := range strings.Split(name, "_") {
+		// split camelCase words
+		start := 0
+		for i, r := range word {
+			if i > 0 && unicode.IsUpper(r) {
+				words = append(words, word[start:i])
+				start = i
+			}
+		}
+		words = append(words, word[start:])
+	}
+
+	var sb strings.Builder
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		lower := strings.ToLower(word)
+		if _initialisms[lower] {
+			sb.WriteString(strings.ToUpper(lower))
+		} else {
+			sb.WriteString(strings.ToUpper(lower[:1]) + lower[1:])
+		}
+	}
+	return sb.String()
+}
+
+// convert a snake_case name to a camelCase Go parameter name
+//
+// eg. "message_thread_id" => "messageThreadID", "url" => "url", "type" => "typ"
+func paramName(name string) string {
+	n := goName(name)
+
+	// lower the leading word (or initialism)
+	prefix := n[:1]
+	for initialism := range _initialisms {
+		upper := strings.ToUpper(initialism)
+		if strings.HasPrefix(n, upper) && (len(n) == len(upper) || unicode.IsUpper(rune(n[len(upper)]))) && len(upper) > len(prefix) {
+			prefix = upper
+		}
+	}
+	n = strings.ToLower(prefix) + n[len(prefix):]
+
+	// avoid Go keywords
+	switch n {
+	case "type":
+		return "typ"
+	case "func", "interface", "map", "range", "select", "switch", "default", "go", "chan":
+		return n + "_"
+	}
+	return n
+}
+
+// convert types of a field to a Go type
+func goType(types []string) string {
+	if len(types) == 1 {
+		return goTypeOf(types[0])
+	}
+
+	// special cases
+	joined := strings.Join(types, ",")
+	switch joined {
+	case "Integer,String":
+		return "ChatID"
+	case "InputFile,String":
+		return "InputFile"
+	}
+
+	// arrays of the same kind (eg. "Array of InputMediaAudio", "Array of InputMediaPhoto", ...)
+	allArrays := true
+	for _, t := range types {
+		if !strings.HasPrefix(t, "Array of ") {
+			allArrays = false
+			break
+		}
+	}
+	if allArrays {
+		return "[]any"
+	}
+
+	return "any"
+}
+
+// convert a type name in the specification to a Go type
+func goTypeOf(t string) string {
+	if strings.HasPrefix(t, "Array of ") {
+		return "[]" + goTypeOf(strings.TrimPrefix(t, "Array of "))
+	}
+
+	switch t {
+	case "Integer":
+		return "int64"
+	case "Float", "Float number":
+		return "float64"
+	case "String":
+		return "string"
+	case "Boolean", "True":
+		return "bool"
+	case "InputFile":
+		return "InputFile"
+	}
+	return goName(t) // eg. "MessageId" => "MessageID"
+}
+
+// check if given Go type is a struct type (not a primitive, slice, or interface)
+func isStructType(t string) bool {
+	switch t {
+	case "int64", "float64", "string", "bool", "any", "ChatID", "InputFile":
+		return false
+	}
+	return !strings.HasPrefix(t, "[]")
+}
+
+// Go type of given field as a struct member
+func structFieldType(field SpecField) string {
+	t := goType(field.Types)
+	if field.Required {
+		return t
+	}
+
+	// optional strings and structs are pointers
+	if t == "string" || isStructType(t) {
+		return "*" + t
+	}
+	return t
+}
+
+// return the first sentence of given description
+func firstSentence(description string) string {
+	if idx := strings.Index(description, ". "); idx >= 0 {
+		return description[:idx+1]
+	}
+	return description
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	bot "github.com/git2akh/telegram-bot-go"
+)
+
+// flags shared by all commands
+type commonFlags struct {
+	token   string
+	verbose bool
+	debug   bool
+}
+
+// create a flag set with common flags
+func newFlagSet(name string, common *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&common.token, "token", "", fmt.Sprintf("bot token (default: $%s)", envToken))
+	fs.BoolVar(&common.verbose, "verbose", false, "print verbose log messages")
+	fs.BoolVar(&common.debug, "debug", false, "print each request and its response")
+	return fs
+}
+
+// create a new bot client with given common flags
+func newClient(common commonFlags) (*bot.Bot, error) {
+	token := common.token
+	if token == "" {
+		token = os.Getenv(envToken)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("bot token is not given (use `-token` flag or `%s` environment variable)", envToken)
+	}
+
+	client := bot.NewClient(token)
+	client.Verbose = common.verbose
+	client.Debug = common.debug
+
+	return client, nil
+}
+
+// parse given string as a chat id (numeric id or "@channelusername")
+func parseChatID(str string) (bot.ChatID, error) {
+	if str == "" {
+		return nil, fmt.Errorf("chat id is not given")
+	}
+	if strings.HasPrefix(str, "@") {
+		return str, nil
+	}
+
+	id, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chat id: %s", str)
+	}
+	return id, nil
+}
+
+// print given value as indented JSON to stdout
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// convert a failed response's description to an error
+func failure(description *string) error {
+	if description != nil {
+		return fmt.Errorf("%s", *description)
+	}
+	return fmt.Errorf("request failed")
+}
+
+// tgbot me
+func runMe(args []string) error {
+	var common commonFlags
+	fs := newFlagSet("me", &common)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := newClient(common)
+	if err != nil {
+		return err
+	}
+
+	if me := client.GetMe(); me.Ok {
+		return printJSON(me.Result)
+	} else {
+		return failure(me.Description)
+	}
+}
+
+// tgbot send -chat <chat id> -text <text>
+func runSend(args []string) error {
+	var common commonFlags
+	fs := newFlagSet("send", &common)
+	chat := fs.String("chat", "", "target chat id or @channelusername")
+	text := fs.String("text", "", "text to send (read from stdin if empty)")
+	parseMode := fs.String("parse-mode", "", "parse mode: Markdown, MarkdownV2, or HTML")
+	silent := fs.Bool("silent", false, "send without notification")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	chatID, err := parseChatID(*chat)
+	if err != nil {
+		return err
+	}
+	if *text == "" {
+		bytes, err := readStdin()
+		if err != nil {
+			return err
+		}
+		*text = string(bytes)
+	}
+
+	client, err := newClient(common)
+	if err != nil {
+		return err
+	}
+
+	options := bot.OptionsSendMessage{}
+	if *parseMode != "" {
+		options.SetParseMode(bot.ParseMode(*parseMode))
+	}
+	if *silent {
+		options.SetDisableNotification(true)
+	}
+
+	if sent := client.SendMessage(chatID, *text, options); sent.Ok {
+		return printJSON(sent.Result)
+	} else {
+		return failure(sent.Description)
+	}
+}
+
+// tgbot upload -chat <chat id> -file <filepath> [-type document]
+func runUpload(args []string) error {
+	var common commonFlags
+	fs := newFlagSet("upload", &common)
+	chat := fs.String("chat", "", "target chat id or @channelusername")
+	file := fs.String("file", "", "path of the file to upload")
+	typ := fs.String("type", "document", "type of the file: document, photo, video, audio, voice, or animation")
+	caption := fs.String("caption", "", "caption of the file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	chatID, err := parseChatID(*chat)
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("file is not given")
+	}
+	if _, err := os.Stat(*file); err != nil {
+		return err
+	}
+
+	client, err := newClient(common)
+	if err != nil {
+		return err
+	}
+
+	input := bot.InputFileFromFilepath(*file)
+
+	var sent bot.APIResponse[bot.Message]
+	switch *typ {
+	case "document":
+		sent = client.SendDocument(chatID, input, bot.OptionsSendDocument{}.SetCaption(*caption))
+	case "photo":
+		sent = client.SendPhoto(chatID, input, bot.OptionsSendPhoto{}.SetCaption(*caption))
+	case "video":
+		sent = client.SendVideo(chatID, input, bot.OptionsSendVideo{}.SetCaption(*caption))
+	case "audio":
+		sent = client.SendAudio(chatID, input, bot.OptionsSendAudio{}.SetCaption(*caption))
+	case "voice":
+		sent = client.SendVoice(chatID, input, bot.OptionsSendVoice{}.SetCaption(*caption))
+	case "animation":
+		sent = client.SendAnimation(chatID, input, bot.OptionsSendAnimation{}.SetCaption(*caption))
+	default:
+		return fmt.Errorf("unsupported file type: %s", *typ)
+	}
+
+	if sent.Ok {
+		return printJSON(sent.Result)
+	}
+	return failure(sent.Description)
+}
+
+// tgbot webhook set|delete|info
+func runWebhook(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("subcommand is not given (set, delete, or info)")
+	}
+
+	var common commonFlags
+	fs := newFlagSet("webhook "+args[0], &common)
+
+	switch args[0] {
+	case "set":
+		host := fs.String("host", "", "hostname of the webhook")
+		port := fs.Int("port", 443, "port number of the webhook (443, 80, 88, or 8443)")
+		cert := fs.String("cert", "", "path of the (self-signed) certificate file")
+		maxConnections := fs.Int("max-connections", 0, "maximum number of simultaneous connections (1~100)")
+		drop := fs.Bool("drop-pending", false, "drop pending updates")
+		secret := fs.String("secret", "", "secret token of webhook requests (default: derived from the bot token)")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *host == "" {
+			return fmt.Errorf("host is not given")
+		}
+
+		client, err := newClient(common)
+		if err != nil {
+			return err
+		}
+
+		options := bot.OptionsSetWebhook{}
+		if *cert != "" {
+			options.SetCertificate(*cert)
+		}
+		if *maxConnections > 0 {
+			options.SetMaxConnections(*maxConnections)
+		}
+		if *drop {
+			options.SetDropPendingUpdates(true)
+		}
+		if *secret != "" {
+			options.SetSecretToken(*secret)
+		}
+
+		if set := client.SetWebhook(*host, *port, options); !set.Ok {
+			return failure(set.Description)
+		}
+		fmt.Fprintf(os.Stderr, "secret token: %s\n", client.WebhookSecretToken())
+		return printWebhookInfo(client)
+	case "delete":
+		drop := fs.Bool("drop-pending", false, "drop pending updates")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		client, err := newClient(common)
+		if err != nil {
+			return err
+		}
+
+		if deleted := client.DeleteWebhook(*drop); !deleted.Ok {
+			return failure(deleted.Description)
+		}
+		return printWebhookInfo(client)
+	case "info":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		client, err := newClient(common)
+		if err != nil {
+			return err
+		}
+
+		return printWebhookInfo(client)
+	}
+
+	return fmt.Errorf("unknown subcommand: %s", args[0])
+}
+
+// print webhook info of the bot
+func printWebhookInfo(client *bot.Bot) error {
+	if info := client.GetWebhookInfo(); info.Ok {
+		return printJSON(info.Result)
+	} else {
+		return failure(info.Description)
+	}
+}
+
+// tgbot updates [-offset <offset>] [-limit <limit>]
+func runUpdates(args []string) error {
+	var common commonFlags
+	fs := newFlagSet("updates", &common)
+	offset := fs.Int64("offset", 0, "identifier of the first update to be returned")
+	limit := fs.Int("limit", 100, "number of updates to be retrieved (1~100)")
+	timeout := fs.Int("timeout", 0, "timeout in seconds for long polling")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := newClient(common)
+	if err != nil {
+		return err
+	}
+
+	options := bot.OptionsGetUpdates{}.
+		SetLimit(*limit).
+		SetTimeout(*timeout)
+	if *offset != 0 {
+		options.SetOffset(*offset)
+	}
+
+	if updates := client.GetUpdates(options); updates.Ok {
+		return printJSON(updates.Result)
+	} else {
+		return failure(updates.Description)
+	}
+}
+
+// tgbot admins -chat <chat id>
+func runAdmins(args []string) error {
+	var common commonFlags
+	fs := newFlagSet("admins", &common)
+	chat := fs.String("chat", "", "target chat id or @channelusername")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	chatID, err := parseChatID(*chat)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(common)
+	if err != nil {
+		return err
+	}
+
+	if admins := client.GetChatAdministrators(chatID); admins.Ok {
+		return printJSON(admins.Result)
+	} else {
+		return failure(admins.Description)
+	}
+}
+
+// read all bytes from stdin
+func readStdin() ([]byte, error) {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.Mode()&os.ModeCharDevice != 0 {
+		return nil, fmt.Errorf("text is not given")
+	}
+
+	return io.ReadAll(os.Stdin)
+}
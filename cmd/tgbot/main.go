@@ -0,0 +1,69 @@
+// tgbot: a command line tool built on telegram-bot-go for ad-hoc operations.
+//
+// last update: 2026.10.17.
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	envToken = "TELEGRAM_BOT_TOKEN"
+)
+
+// command is a subcommand of this tool
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+// subcommands
+var _commands []command
+
+func init() {
+	_commands = []command{
+		{"new", "scaffold a new bot project (new [-module <module path>] [-commands <name,...>] <directory>)", runNew},
+		{"me", "print info of the bot", runMe},
+		{"send", "send a text message", runSend},
+		{"upload", "upload a file", runUpload},
+		{"webhook", "set, delete, or inspect the webhook (webhook set|delete|info)", runWebhook},
+		{"updates", "dump updates as JSON", runUpdates},
+		{"admins", "export administrators of a chat as JSON", runAdmins},
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	name, args := os.Args[1], os.Args[2:]
+	for _, cmd := range _commands {
+		if cmd.name == name {
+			if err := cmd.run(args); err != nil {
+				fmt.Fprintf(os.Stderr, "tgbot %s: %s\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	if name != "help" && name != "-h" && name != "--help" {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", name)
+	}
+	printUsage()
+	os.Exit(1)
+}
+
+// print usage of this tool
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: tgbot <command> [flags]\n\nCommands:\n")
+	for _, cmd := range _commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nBot token is read from `-token` flag or `%s` environment variable.\n", envToken)
+}
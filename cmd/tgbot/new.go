@@ -0,0 +1,149 @@
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/new/*.tmpl
+var _newTemplates embed.FS
+
+// template file names and their output file names
+var _newFiles = map[string]string{
+	"go.mod.tmpl":      "go.mod",
+	"main.go.tmpl":     "main.go",
+	"handlers.go.tmpl": "handlers.go",
+	"env.example.tmpl": ".env.example",
+	"README.md.tmpl":   "README.md",
+}
+
+// values for rendering templates
+type projectValues struct {
+	Name     string
+	Module   string
+	Commands []projectCommand
+}
+
+// a bot command whose handler is registered with HandleCommand() in the new project
+type projectCommand struct {
+	Name        string // name without '/'
+	Usage       string // arguments (eg. "<text>")
+	Description string
+	Handler     string // name of the handler function
+	Builtin     bool   // whether the handler is implemented in the template or not
+}
+
+// commands of every new project
+var _builtinCommands = []projectCommand{
+	{Name: "start", Description: "start this bot", Handler: "handleStart", Builtin: true},
+	{Name: "echo", Usage: "<text>", Description: "echo given text", Handler: "handleEcho", Builtin: true},
+	{Name: "help", Description: "show available commands", Handler: "handleHelp", Builtin: true},
+}
+
+// valid names of bot commands
+var _commandNameRegex = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// tgbot new [-module <module path>] [-commands <name,...>] <directory>
+func runNew(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	module := fs.String("module", "", "module path of the new project (default: name of the directory)")
+	commands := fs.String("commands", "", "comma-separated names of additional commands, generated with stub handlers (eg. ping,stats)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 {
+		return fmt.Errorf("directory is not given")
+	}
+
+	dir := fs.Arg(0)
+	values := projectValues{
+		Name:   filepath.Base(dir),
+		Module: *module,
+	}
+	if values.Module == "" {
+		values.Module = values.Name
+	}
+	var err error
+	if values.Commands, err = projectCommands(*commands); err != nil {
+		return err
+	}
+
+	if err := scaffold(dir, values); err != nil {
+		return err
+	}
+
+	fmt.Printf("created a new bot project in %s\n\n", dir)
+	fmt.Printf("  $ cd %s\n  $ go mod tidy\n  $ %s=<your bot token> go run .\n", dir, envToken)
+
+	return nil
+}
+
+// builtin commands followed by given comma-separated ones
+func projectCommands(names string) ([]projectCommand, error) {
+	commands := append([]projectCommand{}, _builtinCommands...)
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+		if name == "" {
+			continue
+		}
+		if !_commandNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid command name: '%s' (1-32 lowercase letters, digits, or underscores)", name)
+		}
+		for _, command := range commands {
+			if command.Name == name {
+				return nil, fmt.Errorf("duplicated command name: '%s'", name)
+			}
+		}
+
+		handler := "handle"
+		for _, word := range strings.Split(name, "_") {
+			if word != "" {
+				handler += strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+		commands = append(commands, projectCommand{
+			Name:        name,
+			Description: fmt.Sprintf("handle /%s", name),
+			Handler:     handler,
+		})
+	}
+
+	return commands, nil
+}
+
+// create a minimal bot project in given directory
+//
+// (the directory should not exist, or be empty)
+func scaffold(dir string, values projectValues) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("directory is not empty: %s", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for tmplName, filename := range _newFiles {
+		tmpl, err := template.ParseFS(_newTemplates, "templates/new/"+tmplName)
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %s", tmplName, err)
+		}
+
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, values); err != nil {
+			return fmt.Errorf("failed to render template %s: %s", tmplName, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, filename), []byte(sb.String()), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package telegrambot
+
+// Pluggable JSON codec for encoding params and decoding responses/updates.
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONCodec is an interface for encoding and decoding JSON.
+//
+// Faster implementations (eg. github.com/bytedance/sonic, github.com/goccy/go-json)
+// can be plugged in with Bot.SetJSONCodec().
+type JSONCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONStreamCodec is a JSONCodec which can also decode a value directly from a reader.
+//
+// Webhook requests are decoded from their bodies without being buffered, when the codec implements this interface
+// (and neither an update filter nor verbose logging needs the whole body).
+type JSONStreamCodec interface {
+	JSONCodec
+
+	Decode(r io.Reader, v any) error
+}
+
+// StdJSONCodec is a JSONCodec with `encoding/json`.
+type StdJSONCodec struct{}
+
+// Marshal encodes given value with json.Marshal.
+func (StdJSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes given data with json.Unmarshal.
+func (StdJSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// StdJSONStreamCodec is a JSONStreamCodec with `encoding/json`.
+//
+// It keeps memory usage flat with large webhook requests, but allocates more than StdJSONCodec
+// (which decodes bodies read into pooled buffers) with small ones.
+type StdJSONStreamCodec struct {
+	StdJSONCodec
+}
+
+// Decode decodes a value from given reader with json.Decoder.
+func (StdJSONStreamCodec) Decode(r io.Reader, v any) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// SetJSONCodec sets the JSON codec for encoding params and decoding responses/updates.
+//
+// (default: StdJSONCodec)
+//
+//	type sonicCodec struct{}
+//
+//	func (sonicCodec) Marshal(v any) ([]byte, error)      { return sonic.Marshal(v) }
+//	func (sonicCodec) Unmarshal(data []byte, v any) error { return sonic.Unmarshal(data, v) }
+//
+//	client.SetJSONCodec(sonicCodec{})
+func (b *Bot) SetJSONCodec(codec JSONCodec) {
+	if _, isStd := codec.(StdJSONCodec); isStd {
+		codec = nil
+	}
+	b.codec = codec
+}
+
+// get the JSON codec of the bot
+func (b *Bot) jsonCodec() JSONCodec {
+	if b.codec == nil {
+		return StdJSONCodec{}
+	}
+	return b.codec
+}
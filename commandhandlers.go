@@ -0,0 +1,222 @@
+package telegrambot
+
+// Handling bot commands in messages, with their arguments and deep-link payloads.
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf16"
+)
+
+// name of the update handler for commands registered with HandleCommand()
+const commandsHandlerName = "commands"
+
+// Command is a bot command parsed from a message
+type Command struct {
+	Name    string   // name of the command, without '/' and the bot's username (eg. "start")
+	Mention string   // username of the bot in the command (eg. "MyBot" of "/start@MyBot"), "" if not mentioned
+	Args    []string // arguments separated by whitespaces
+	RawArgs string   // text after the command, with leading and trailing whitespaces trimmed
+
+	Message Message // message of the command
+}
+
+// DeepLinkPayload returns the payload of a deep link (eg. "abc" of "https://t.me/MyBot?start=abc"),
+// and whether this command is a `/start` with a payload or not.
+func (c Command) DeepLinkPayload() (payload string, ok bool) {
+	if !strings.EqualFold(c.Name, "start") || c.RawArgs == "" {
+		return "", false
+	}
+	return c.RawArgs, true
+}
+
+// ParseCommand parses the bot command at the beginning of given message's text, and returns whether it exists or not.
+//
+//	"/start@MyBot arg1  arg2" => Command{Name: "start", Mention: "MyBot", Args: []string{"arg1", "arg2"}, RawArgs: "arg1  arg2"}
+func ParseCommand(message Message) (command Command, ok bool) {
+	if message.Text == nil {
+		return command, false
+	}
+
+	for _, entity := range message.Entities {
+		if entity.Type == MessageEntityTypeBotCommand && entity.Offset == 0 {
+			text := *message.Text
+			encoded := utf16.Encode([]rune(text))
+			if entity.Length <= 1 || entity.Length > len(encoded) {
+				return command, false // invalid entity
+			}
+			token := string(utf16.Decode(encoded[:entity.Length]))
+			if !strings.HasPrefix(token, "/") || strings.TrimSpace(token) != token {
+				return command, false
+			}
+
+			command.Name, command.Mention, _ = strings.Cut(strings.TrimPrefix(token, "/"), "@")
+			command.RawArgs = strings.TrimSpace(strings.TrimPrefix(text, token))
+			command.Args = strings.Fields(command.RawArgs)
+			command.Message = message
+
+			return command, true
+		}
+	}
+	return command, false
+}
+
+// CommandHandler is a function which handles a bot command.
+type CommandHandler func(ctx *Ctx, command Command) error
+
+// a registered command
+type registeredCommand struct {
+	name        string
+	description string // description for SyncCommands() ("" for not publishing)
+	handler     CommandHandler
+}
+
+// commands registered with HandleCommand()
+type commandHandlers struct {
+	commands map[string]*registeredCommand
+	names    []string // names of commands in registered order
+	username string   // username of this bot (fetched with getMe when needed)
+
+	lock sync.RWMutex
+}
+
+// HandleCommand registers a handler for bot command `name` (without '/', eg. "start").
+//
+// Commands are handled (and consumed) by a handler added with AddUpdateHandler() on the first registration,
+// and registering the same name again replaces the handler.
+//
+// Commands which mention other bots (eg. "/start@OtherBot" in groups) are ignored,
+// so the username of this bot is fetched with getMe when a command with a mention is received.
+//
+//	client.HandleCommand("start", func(ctx *Ctx, command Command) error {
+//		if payload, ok := command.DeepLinkPayload(); ok {
+//			return handleInvitation(ctx, payload)
+//		}
+//		return command.Message.Reply(ctx.Bot, "Hello!", nil).Err()
+//	})
+func (b *Bot) HandleCommand(name string, handler CommandHandler) {
+	b.commands.lock.Lock()
+	defer b.commands.lock.Unlock()
+
+	b.registerCommandLocked(name).handler = handler
+}
+
+// DescribeCommand sets the description of bot command `name`, to be published with SyncCommands().
+func (b *Bot) DescribeCommand(name, description string) {
+	b.commands.lock.Lock()
+	defer b.commands.lock.Unlock()
+
+	b.registerCommandLocked(name).description = description
+}
+
+// SyncCommands publishes registered commands which have descriptions (see DescribeCommand) with setMyCommands,
+// in registered order.
+//
+// Call it once after registering commands:
+//
+//	client.HandleCommand("help", handleHelp)
+//	client.DescribeCommand("help", "Show help")
+//	client.SyncCommands(nil)
+func (b *Bot) SyncCommands(options OptionsSetMyCommands) (result APIResponse[bool]) {
+	b.commands.lock.RLock()
+	commands := []BotCommand{}
+	for _, name := range b.commands.names {
+		if command := b.commands.commands[name]; command.description != "" {
+			commands = append(commands, BotCommand{Command: command.name, Description: command.description})
+		}
+	}
+	b.commands.lock.RUnlock()
+
+	if len(commands) == 0 {
+		errStr := "no described commands to publish"
+
+		b.error(errStr)
+
+		return APIResponse[bool]{Ok: false, Description: &errStr}
+	}
+
+	return b.SetMyCommands(commands, options)
+}
+
+// registered command of given name, added (along with the update handler) if it does not exist
+//
+// NOTE: `b.commands.lock` should be locked
+func (b *Bot) registerCommandLocked(name string) *registeredCommand {
+	name = strings.ToLower(strings.TrimPrefix(name, "/"))
+
+	if b.commands.commands == nil {
+		b.commands.commands = map[string]*registeredCommand{}
+
+		b.AddUpdateHandler(commandsHandlerName, b.handleCommand)
+	}
+
+	command, exists := b.commands.commands[name]
+	if !exists {
+		command = &registeredCommand{name: name}
+		b.commands.commands[name] = command
+		b.commands.names = append(b.commands.names, name)
+	}
+	return command
+}
+
+// handle a message with a registered command which is addressed to this bot
+func (b *Bot) handleCommand(ctx *Ctx) error {
+	if ctx.Update.Message == nil {
+		return nil
+	}
+
+	command, ok := ParseCommand(*ctx.Update.Message)
+	if !ok {
+		return nil
+	}
+
+	b.commands.lock.RLock()
+	var handler CommandHandler
+	if registered, exists := b.commands.commands[strings.ToLower(command.Name)]; exists {
+		handler = registered.handler
+	}
+	b.commands.lock.RUnlock()
+
+	if handler == nil {
+		return nil
+	}
+
+	if command.Mention != "" {
+		username, err := b.commandsUsername()
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(command.Mention, username) {
+			return nil // addressed to another bot
+		}
+	}
+
+	ctx.Consume()
+	return handler(ctx, command)
+}
+
+// username of this bot, fetched with getMe and cached
+func (b *Bot) commandsUsername() (string, error) {
+	b.commands.lock.RLock()
+	username := b.commands.username
+	b.commands.lock.RUnlock()
+
+	if username != "" {
+		return username, nil
+	}
+
+	res := b.GetMe()
+	if !res.Ok {
+		return "", fmt.Errorf("failed to fetch the username of this bot for commands: %w", res.Err())
+	}
+	if res.Result == nil || res.Result.Username == nil {
+		return "", fmt.Errorf("failed to fetch the username of this bot for commands: no username")
+	}
+
+	b.commands.lock.Lock()
+	b.commands.username = *res.Result.Username
+	b.commands.lock.Unlock()
+
+	return *res.Result.Username, nil
+}
@@ -0,0 +1,182 @@
+package telegrambot
+
+// Publishing localized command menus for multiple scopes at once.
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LocalizedBotCommand is a bot command with translated descriptions
+type LocalizedBotCommand struct {
+	Command     string
+	Description string // description for users without a translation
+
+	Translations map[string]string // descriptions by IETF language tags (eg. "ko", "ja")
+}
+
+// BotCommandsBundle is a set of localized commands for multiple scopes
+type BotCommandsBundle struct {
+	Commands []LocalizedBotCommand
+
+	// scopes of the commands, eg. BotCommandScopeAllPrivateChats (default: BotCommandScopeDefault)
+	Scopes []any
+
+	// language codes whose commands are deleted (so that the default ones are shown) unless they have translations
+	//
+	// Telegram gives no list of configured languages, so list languages which were published before here
+	// for removing their stale commands.
+	Languages []string
+}
+
+// a scope and a language code of a commands list
+type commandsTarget struct {
+	scope        any
+	languageCode string // "" for the default
+}
+
+// options of setMyCommands for this target
+func (t commandsTarget) setOptions() OptionsSetMyCommands {
+	options := OptionsSetMyCommands{}.SetScope(t.scope)
+	if t.languageCode != "" {
+		options.SetLanguageCode(t.languageCode)
+	}
+	return options
+}
+
+// options of deleteMyCommands for this target
+func (t commandsTarget) deleteOptions() OptionsDeleteMyCommands {
+	options := OptionsDeleteMyCommands{}.SetScope(t.scope)
+	if t.languageCode != "" {
+		options.SetLanguageCode(t.languageCode)
+	}
+	return options
+}
+
+// options of getMyCommands for this target
+func (t commandsTarget) getOptions() OptionsGetMyCommands {
+	options := OptionsGetMyCommands{}.SetScope(t.scope)
+	if t.languageCode != "" {
+		options.SetLanguageCode(t.languageCode)
+	}
+	return options
+}
+
+// SetMyCommandsBundle publishes localized commands for all scopes and languages of the bundle,
+// with setMyCommands (or deleteMyCommands for languages without translations).
+//
+// Current commands of each scope and language are fetched before, and when one of the calls fails,
+// already published ones are rolled back to them.
+//
+//	client.SetMyCommandsBundle(BotCommandsBundle{
+//		Commands: []LocalizedBotCommand{
+//			{Command: "help", Description: "Show help", Translations: map[string]string{"ko": "도움말 보기"}},
+//			{Command: "settings", Description: "Change settings", Translations: map[string]string{"ko": "설정 변경"}},
+//		},
+//		Scopes: []any{
+//			BotCommandScopeAllPrivateChats{Type: BotCommandScopeTypeAllPrivateChats},
+//			BotCommandScopeAllGroupChats{Type: BotCommandScopeTypeAllGroupChats},
+//		},
+//		Languages: []string{"ja"}, // published before, but not translated anymore
+//	})
+func (b *Bot) SetMyCommandsBundle(bundle BotCommandsBundle) (result APIResponse[bool]) {
+	scopes := bundle.Scopes
+	if len(scopes) == 0 {
+		scopes = []any{BotCommandScopeDefault{Type: BotCommandScopeTypeDefault}}
+	}
+
+	// language codes of translations and given languages, in order
+	languages := map[string]bool{}
+	for _, command := range bundle.Commands {
+		for languageCode := range command.Translations {
+			languages[languageCode] = true
+		}
+	}
+	for _, languageCode := range bundle.Languages {
+		if _, exists := languages[languageCode]; !exists {
+			languages[languageCode] = false
+		}
+	}
+	languageCodes := []string{""}
+	for languageCode := range languages {
+		if languageCode != "" {
+			languageCodes = append(languageCodes, languageCode)
+		}
+	}
+	sort.Strings(languageCodes[1:])
+
+	// fetch current commands for rollback
+	targets := []commandsTarget{}
+	previous := [][]BotCommand{}
+	for _, scope := range scopes {
+		for _, languageCode := range languageCodes {
+			target := commandsTarget{scope: scope, languageCode: languageCode}
+
+			res := b.GetMyCommands(target.getOptions())
+			if !res.Ok {
+				return b.commandsBundleFailure(fmt.Sprintf("failed to fetch commands for rollback (language: '%s')", languageCode), res.Description, res.ErrorCode)
+			}
+
+			targets = append(targets, target)
+			previous = append(previous, *res.Result)
+		}
+	}
+
+	// publish
+	for i, target := range targets {
+		commands := []BotCommand{}
+		if target.languageCode == "" || languages[target.languageCode] {
+			for _, command := range bundle.Commands {
+				description := command.Description
+				if translated, exists := command.Translations[target.languageCode]; exists {
+					description = translated
+				}
+				commands = append(commands, BotCommand{Command: command.Command, Description: description})
+			}
+		}
+
+		var res APIResponse[bool]
+		if len(commands) > 0 {
+			res = b.SetMyCommands(commands, target.setOptions())
+		} else {
+			res = b.DeleteMyCommands(target.deleteOptions())
+		}
+
+		if !res.Ok {
+			b.rollbackMyCommands(targets[:i], previous[:i])
+
+			return b.commandsBundleFailure(fmt.Sprintf("failed to publish commands (language: '%s'), rolled back", target.languageCode), res.Description, res.ErrorCode)
+		}
+	}
+
+	ok := true
+	return APIResponse[bool]{Ok: true, Result: &ok}
+}
+
+// restore commands of given targets to the previous ones
+func (b *Bot) rollbackMyCommands(targets []commandsTarget, previous [][]BotCommand) {
+	for i := len(targets) - 1; i >= 0; i-- {
+		var res APIResponse[bool]
+		if len(previous[i]) > 0 {
+			res = b.SetMyCommands(previous[i], targets[i].setOptions())
+		} else {
+			res = b.DeleteMyCommands(targets[i].deleteOptions())
+		}
+
+		if !res.Ok {
+			b.error("failed to roll back commands (language: '%s'): %s", targets[i].languageCode, res.Err())
+		}
+	}
+}
+
+// failed response of SetMyCommandsBundle
+func (b *Bot) commandsBundleFailure(message string, description *string, errorCode int) APIResponse[bool] {
+	errStr := message
+	if description != nil {
+		errStr = fmt.Sprintf("%s: %s", message, *description)
+	}
+
+	b.error(errStr)
+
+	return APIResponse[bool]{Ok: false, Description: &errStr, ErrorCode: errorCode}
+}
@@ -0,0 +1,291 @@
+package telegrambot
+
+// Multi-step conversations with users, as finite state machines of named steps.
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// default values of ConversationsConfig
+const (
+	defaultConversationTimeout = 10 * time.Minute
+)
+
+// ConversationsConfig is a configuration for EnableConversations()
+type ConversationsConfig struct {
+	// store of conversations' states (default: a new MemoryCacheStore)
+	Store CacheStore
+
+	// conversations are dropped when users do not respond for this duration (default: 10 minutes)
+	Timeout time.Duration
+
+	// commands (without '/') which cancel the current conversation (default: "cancel")
+	CancelCommands []string
+
+	// called when a conversation is cancelled with one of CancelCommands (eg. for replying "Cancelled.")
+	OnCancel func(ctx *Ctx, state ConversationState) error
+}
+
+// fill default values of the config
+func (c ConversationsConfig) withDefaults() ConversationsConfig {
+	if c.Store == nil {
+		c.Store = NewMemoryCacheStore()
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultConversationTimeout
+	}
+	if len(c.CancelCommands) == 0 {
+		c.CancelCommands = []string{"cancel"}
+	}
+	return c
+}
+
+// ConversationState is a state of the conversation which a user has in a chat (stored as JSON)
+type ConversationState struct {
+	Name      string            `json:"name"`           // name of the registered conversation
+	Step      string            `json:"step"`           // name of the current step
+	Data      map[string]string `json:"data,omitempty"` // data collected in the previous steps
+	UpdatedAt time.Time         `json:"updated_at"`     // when the state was changed
+}
+
+// ConversationStep is a function which handles a user's response in a step of a conversation.
+//
+// Without Conversation.Next() or Conversation.End(), the conversation stays in the current step.
+// When it returns an error, the state is not changed.
+type ConversationStep func(ctx *Ctx, conversation *Conversation) error
+
+// Conversation is a conversation in progress, passed to ConversationStep
+type Conversation struct {
+	ConversationState
+
+	ChatID int64
+	UserID int64
+
+	ended bool
+}
+
+// Next moves the conversation to given step, which will handle the user's next response.
+func (c *Conversation) Next(step string) {
+	c.Step = step
+}
+
+// End ends the conversation.
+func (c *Conversation) End() {
+	c.ended = true
+}
+
+// Set stores a value collected in the conversation.
+func (c *Conversation) Set(key, value string) {
+	c.Data[key] = value
+}
+
+// Get returns a value stored with Set(), and whether it exists or not.
+func (c *Conversation) Get(key string) (value string, exists bool) {
+	value, exists = c.Data[key]
+	return value, exists
+}
+
+// a registered conversation
+type conversationFlow struct {
+	first string                      // name of the first step
+	steps map[string]ConversationStep // steps by names
+}
+
+// Conversations manages users' conversations, keyed by chats and users.
+type Conversations struct {
+	bot    *Bot
+	config ConversationsConfig
+
+	flows map[string]conversationFlow
+	lock  sync.RWMutex
+}
+
+// EnableConversations enables multi-step conversations, and returns the Conversations for registering and starting them.
+//
+// It is added with AddUpdateHandler(), and messages (and callback queries) of users in conversations are handled
+// (and consumed) by their current steps, so add it before other handlers which should not receive them.
+//
+//	conversations := client.EnableConversations(ConversationsConfig{})
+//	conversations.Register("signup", "name", map[string]ConversationStep{
+//		"name": func(ctx *Ctx, conversation *Conversation) error {
+//			conversation.Set("name", *ctx.Update.Message.Text)
+//			conversation.Next("age")
+//			return ctx.Update.Message.Reply(ctx.Bot, "How old are you?", nil).Err()
+//		},
+//		"age": func(ctx *Ctx, conversation *Conversation) error {
+//			conversation.End()
+//			name, _ := conversation.Get("name")
+//			return ctx.Update.Message.Reply(ctx.Bot, "Welcome, "+name+"!", nil).Err()
+//		},
+//	})
+//	client.HandleCommand("signup", func(ctx *Ctx, command Command) error {
+//		if err := conversations.Start(command.Message.Chat.ID, command.Message.From.ID, "signup"); err != nil {
+//			return err
+//		}
+//		return command.Message.Reply(ctx.Bot, "What is your name? (/cancel to stop)", nil).Err()
+//	})
+func (b *Bot) EnableConversations(config ConversationsConfig) *Conversations {
+	c := &Conversations{
+		bot:    b,
+		config: config.withDefaults(),
+		flows:  map[string]conversationFlow{},
+	}
+
+	b.AddUpdateHandler("conversations", func(ctx *Ctx) error {
+		return c.handle(ctx)
+	})
+
+	return c
+}
+
+// Register registers a conversation with given name, name of its first step, and its steps by names.
+//
+// Registering with an existing name replaces the conversation.
+func (c *Conversations) Register(name, first string, steps map[string]ConversationStep) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	copied := map[string]ConversationStep{}
+	for step, handler := range steps {
+		copied[step] = handler
+	}
+	c.flows[name] = conversationFlow{first: first, steps: copied}
+}
+
+// Start starts the registered conversation of given name with the user, from its first step.
+//
+// The user's current conversation (if any) is replaced.
+func (c *Conversations) Start(chatID, userID int64, name string) error {
+	c.lock.RLock()
+	flow, exists := c.flows[name]
+	c.lock.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("no such conversation: '%s'", name)
+	}
+
+	c.save(chatID, userID, ConversationState{
+		Name: name,
+		Step: flow.first,
+		Data: map[string]string{},
+	})
+
+	return nil
+}
+
+// Current returns the state of the user's current conversation, and whether it exists (and is not timed out) or not.
+func (c *Conversations) Current(chatID, userID int64) (state ConversationState, exists bool) {
+	if value, exists := c.config.Store.Get(conversationStateKey(chatID, userID)); exists {
+		decoded, err := decodeStoreValue[ConversationState](value)
+		if err != nil {
+			c.bot.error("ignoring conversation state of user %d in chat %d: %s", userID, chatID, err)
+		} else if time.Since(decoded.UpdatedAt) < c.config.Timeout {
+			if decoded.Data == nil {
+				decoded.Data = map[string]string{}
+			}
+			return decoded, true
+		}
+	}
+	return state, false
+}
+
+// Cancel ends the user's current conversation, without calling OnCancel.
+func (c *Conversations) Cancel(chatID, userID int64) {
+	c.config.Store.Delete(conversationStateKey(chatID, userID))
+}
+
+// save the state of a user's conversation
+func (c *Conversations) save(chatID, userID int64, state ConversationState) {
+	state.UpdatedAt = time.Now()
+
+	encoded, err := encodeStoreValue(state)
+	if err != nil {
+		c.bot.error("failed to encode conversation state of user %d in chat %d: %s", userID, chatID, err)
+		return
+	}
+	c.config.Store.Set(conversationStateKey(chatID, userID), encoded, c.config.Timeout)
+}
+
+// handle a response of a user in a conversation
+func (c *Conversations) handle(ctx *Ctx) error {
+	chatID, userID, ok := conversationParticipant(ctx.Update)
+	if !ok {
+		return nil
+	}
+
+	state, exists := c.Current(chatID, userID)
+	if !exists {
+		return nil
+	}
+
+	// cancellation
+	if ctx.Update.Message != nil {
+		if command, ok := ParseCommand(*ctx.Update.Message); ok {
+			for _, cancel := range c.config.CancelCommands {
+				if strings.EqualFold(command.Name, cancel) {
+					ctx.Consume()
+
+					c.Cancel(chatID, userID)
+					if c.config.OnCancel != nil {
+						return c.config.OnCancel(ctx, state)
+					}
+					return nil
+				}
+			}
+		}
+	}
+
+	c.lock.RLock()
+	step, exists := c.flows[state.Name].steps[state.Step]
+	c.lock.RUnlock()
+
+	if !exists {
+		c.Cancel(chatID, userID)
+
+		return fmt.Errorf("no step '%s' in conversation '%s', dropped", state.Step, state.Name)
+	}
+
+	ctx.Consume()
+
+	// NOTE: data are copied, so failed steps do not change the state
+	data := map[string]string{}
+	for key, value := range state.Data {
+		data[key] = value
+	}
+	conversation := &Conversation{
+		ConversationState: ConversationState{Name: state.Name, Step: state.Step, Data: data},
+		ChatID:            chatID,
+		UserID:            userID,
+	}
+
+	if err := step(ctx, conversation); err != nil {
+		return err
+	}
+
+	if conversation.ended {
+		c.Cancel(chatID, userID)
+	} else {
+		c.save(chatID, userID, conversation.ConversationState)
+	}
+
+	return nil
+}
+
+// ids of the chat and the user who sent given update, for identifying conversations
+func conversationParticipant(update Update) (chatID, userID int64, ok bool) {
+	if message := update.Message; message != nil && message.From != nil {
+		return message.Chat.ID, message.From.ID, true
+	}
+	if query := update.CallbackQuery; query != nil && query.Message != nil {
+		return query.Message.Chat.ID, query.From.ID, true
+	}
+	return 0, 0, false
+}
+
+// key of users' conversation states in the store
+func conversationStateKey(chatID, userID int64) string {
+	return fmt.Sprintf("conversation:%d:%d", chatID, userID)
+}
@@ -0,0 +1,58 @@
+package telegrambot
+
+// Downloading remote files for uploading them again, for methods which do not accept remote urls.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+)
+
+// maximum size of remote files downloaded for uploading (same as the upload limit of the API)
+const maxReuploadFileSize = 50 * 1024 * 1024
+
+// download given url to a temporary file, without reading more than `maxBytes` bytes
+//
+// (the returned file should be removed by the caller)
+func (b *Bot) downloadToTempFile(url string, maxBytes int64) (filepath string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultUploadTimeout)
+	defer cancel()
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, "GET", url, nil); err != nil {
+		return "", fmt.Errorf("failed to download '%s': %w", url, err)
+	}
+	var resp *http.Response
+	if resp, err = b.httpClient.Do(req); err != nil {
+		return "", fmt.Errorf("failed to download '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to download '%s': http status %d", url, resp.StatusCode)
+	}
+	if resp.ContentLength > maxBytes {
+		return "", fmt.Errorf("failed to download '%s': too large (%d > %d bytes)", url, resp.ContentLength, maxBytes)
+	}
+
+	var file *os.File
+	if file, err = os.CreateTemp("", "telegrambot-*"+path.Ext(resp.Request.URL.Path)); err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer file.Close()
+
+	// read one more byte for detecting files over the limit
+	var written int64
+	if written, err = io.Copy(file, io.LimitReader(resp.Body, maxBytes+1)); err == nil && written > maxBytes {
+		err = fmt.Errorf("too large (> %d bytes)", maxBytes)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to download '%s': %w", url, err)
+	}
+
+	return file.Name(), nil
+}
@@ -0,0 +1,717 @@
+package telegrambot
+
+// Convenience wrappers of Bot's methods which return (result, error) instead of APIResponse.
+
+import "time"
+
+// Easy is a thin wrapper of Bot whose methods return (result, error) instead of APIResponse.
+//
+// Failed responses are converted into *APIError, and methods which return only `true` on success return only an error.
+//
+//	msg, err := bot.Easy().SendMessage(chatID, "hello", nil)
+//	if err != nil {
+//		var apiErr *telegrambot.APIError
+//		if errors.As(err, &apiErr) && apiErr.ErrorCode == 403 {
+//			// blocked by the user
+//		}
+//	}
+type Easy struct {
+	b *Bot
+}
+
+// Easy returns the convenience wrapper of this bot.
+func (b *Bot) Easy() Easy {
+	return Easy{b: b}
+}
+
+// Bot returns the wrapped bot.
+func (e Easy) Bot() *Bot {
+	return e.b
+}
+
+// GetUpdates retrieves updates from Telegram bot API.
+func (e Easy) GetUpdates(options OptionsGetUpdates) ([]Update, error) {
+	return resultOf(e.b.GetUpdates(options))
+}
+
+// SetWebhook sets various options for receiving incoming updates.
+func (e Easy) SetWebhook(host string, port int, options OptionsSetWebhook) error {
+	return e.b.SetWebhook(host, port, options).Err()
+}
+
+// DeleteWebhook deletes webhook for this bot.
+func (e Easy) DeleteWebhook(dropPendingUpdates bool) error {
+	return e.b.DeleteWebhook(dropPendingUpdates).Err()
+}
+
+// GetWebhookInfo gets webhook info for this bot.
+func (e Easy) GetWebhookInfo() (WebhookInfo, error) {
+	return resultOf(e.b.GetWebhookInfo())
+}
+
+// GetMe gets info of this bot.
+func (e Easy) GetMe() (User, error) {
+	return resultOf(e.b.GetMe())
+}
+
+// LogOut logs this bot from cloud Bot API server.
+func (e Easy) LogOut() error {
+	return e.b.LogOut().Err()
+}
+
+// Close closes this bot from local Bot API server.
+func (e Easy) Close() error {
+	return e.b.Close().Err()
+}
+
+// SendMessage sends a message to the bot.
+func (e Easy) SendMessage(chatID ChatID, text string, options OptionsSendMessage) (Message, error) {
+	return resultOf(e.b.SendMessage(chatID, text, options))
+}
+
+// ForwardMessage forwards a message.
+func (e Easy) ForwardMessage(chatID, fromChatID ChatID, messageID int64, options OptionsForwardMessage) (Message, error) {
+	return resultOf(e.b.ForwardMessage(chatID, fromChatID, messageID, options))
+}
+
+// CopyMessage copies a message.
+func (e Easy) CopyMessage(chatID, fromChatID ChatID, messageID int64, options OptionsCopyMessage) (MessageID, error) {
+	return resultOf(e.b.CopyMessage(chatID, fromChatID, messageID, options))
+}
+
+// ForwardMessages forwards multiple messages at once.
+func (e Easy) ForwardMessages(chatID, fromChatID ChatID, messageIDs []int64, options OptionsForwardMessages) ([]MessageID, error) {
+	return resultOf(e.b.ForwardMessages(chatID, fromChatID, messageIDs, options))
+}
+
+// CopyMessages copies multiple messages at once.
+func (e Easy) CopyMessages(chatID, fromChatID ChatID, messageIDs []int64, options OptionsCopyMessages) ([]MessageID, error) {
+	return resultOf(e.b.CopyMessages(chatID, fromChatID, messageIDs, options))
+}
+
+// SendPhoto sends a photo.
+func (e Easy) SendPhoto(chatID ChatID, photo InputFile, options OptionsSendPhoto) (Message, error) {
+	return resultOf(e.b.SendPhoto(chatID, photo, options))
+}
+
+// SendAudio sends an audio file. (.mp3 format only, will be played with external players)
+func (e Easy) SendAudio(chatID ChatID, audio InputFile, options OptionsSendAudio) (Message, error) {
+	return resultOf(e.b.SendAudio(chatID, audio, options))
+}
+
+// SendDocument sends a general file.
+func (e Easy) SendDocument(chatID ChatID, document InputFile, options OptionsSendDocument) (Message, error) {
+	return resultOf(e.b.SendDocument(chatID, document, options))
+}
+
+// SendSticker sends a sticker.
+func (e Easy) SendSticker(chatID ChatID, sticker InputFile, options OptionsSendSticker) (Message, error) {
+	return resultOf(e.b.SendSticker(chatID, sticker, options))
+}
+
+// GetStickerSet gets a sticker set.
+func (e Easy) GetStickerSet(name string) (StickerSet, error) {
+	return resultOf(e.b.GetStickerSet(name))
+}
+
+// GetCustomEmojiStickers gets custom emoji stickers.
+func (e Easy) GetCustomEmojiStickers(customEmojiIDs []string) ([]Sticker, error) {
+	return resultOf(e.b.GetCustomEmojiStickers(customEmojiIDs))
+}
+
+// UploadStickerFile uploads a sticker file.
+func (e Easy) UploadStickerFile(userID int64, sticker InputFile, stickerFormat StickerFormat) (File, error) {
+	return resultOf(e.b.UploadStickerFile(userID, sticker, stickerFormat))
+}
+
+// CreateNewStickerSet creates a new sticker set.
+func (e Easy) CreateNewStickerSet(userID int64, name, title string, stickers []InputSticker, stickerFormat StickerFormat, options OptionsCreateNewStickerSet) error {
+	return e.b.CreateNewStickerSet(userID, name, title, stickers, stickerFormat, options).Err()
+}
+
+// AddStickerToSet adds a sticker to set.
+func (e Easy) AddStickerToSet(userID int64, name string, sticker InputSticker, options OptionsAddStickerToSet) error {
+	return e.b.AddStickerToSet(userID, name, sticker, options).Err()
+}
+
+// SetStickerPositionInSet sets sticker position in set.
+func (e Easy) SetStickerPositionInSet(sticker string, position int) error {
+	return e.b.SetStickerPositionInSet(sticker, position).Err()
+}
+
+// DeleteStickerFromSet deletes a sticker from set.
+func (e Easy) DeleteStickerFromSet(sticker string) error {
+	return e.b.DeleteStickerFromSet(sticker).Err()
+}
+
+// SetStickerSetThumbnail sets a thumbnail of a sticker set.
+func (e Easy) SetStickerSetThumbnail(name string, userID int64, options OptionsSetStickerSetThumbnail) error {
+	return e.b.SetStickerSetThumbnail(name, userID, options).Err()
+}
+
+// SetCustomEmojiStickerSetThumbnail sets the custom emoji sticker set's thumbnail.
+func (e Easy) SetCustomEmojiStickerSetThumbnail(name string, options OptionsSetCustomEmojiStickerSetThumbnail) error {
+	return e.b.SetCustomEmojiStickerSetThumbnail(name, options).Err()
+}
+
+// SetStickerSetTitle sets the title of sticker set.
+func (e Easy) SetStickerSetTitle(name, title string) error {
+	return e.b.SetStickerSetTitle(name, title).Err()
+}
+
+// DeleteStickerSet deletes a sticker set.
+func (e Easy) DeleteStickerSet(name string) error {
+	return e.b.DeleteStickerSet(name).Err()
+}
+
+// SetStickerEmojiList sets the emoji list of sticker set.
+func (e Easy) SetStickerEmojiList(sticker string, emojiList []string) error {
+	return e.b.SetStickerEmojiList(sticker, emojiList).Err()
+}
+
+// SetStickerKeywords sets the keywords of sticker.
+func (e Easy) SetStickerKeywords(sticker string, keywords []string) error {
+	return e.b.SetStickerKeywords(sticker, keywords).Err()
+}
+
+// SetStickerMaskPosition sets mask position of sticker.
+func (e Easy) SetStickerMaskPosition(sticker string, options OptionsSetStickerMaskPosition) error {
+	return e.b.SetStickerMaskPosition(sticker, options).Err()
+}
+
+// SendVideo sends a video file.
+func (e Easy) SendVideo(chatID ChatID, video InputFile, options OptionsSendVideo) (Message, error) {
+	return resultOf(e.b.SendVideo(chatID, video, options))
+}
+
+// SendAnimation sends an animation.
+func (e Easy) SendAnimation(chatID ChatID, animation InputFile, options OptionsSendAnimation) (Message, error) {
+	return resultOf(e.b.SendAnimation(chatID, animation, options))
+}
+
+// SendVoice sends a voice file. (.ogg format only, will be played with Telegram itself))
+func (e Easy) SendVoice(chatID ChatID, voice InputFile, options OptionsSendVoice) (Message, error) {
+	return resultOf(e.b.SendVoice(chatID, voice, options))
+}
+
+// SendVideoNote sends a video note.
+func (e Easy) SendVideoNote(chatID ChatID, videoNote InputFile, options OptionsSendVideoNote) (Message, error) {
+	return resultOf(e.b.SendVideoNote(chatID, videoNote, options))
+}
+
+// SendMediaGroup sends a group of photos or videos as an album.
+func (e Easy) SendMediaGroup(chatID ChatID, media []InputMedia, options OptionsSendMediaGroup) ([]Message, error) {
+	return resultOf(e.b.SendMediaGroup(chatID, media, options))
+}
+
+// SendLocation sends locations.
+func (e Easy) SendLocation(chatID ChatID, latitude, longitude float64, options OptionsSendLocation) (Message, error) {
+	return resultOf(e.b.SendLocation(chatID, latitude, longitude, options))
+}
+
+// SendVenue sends venues.
+func (e Easy) SendVenue(chatID ChatID, latitude, longitude float64, title, address string, options OptionsSendVenue) (Message, error) {
+	return resultOf(e.b.SendVenue(chatID, latitude, longitude, title, address, options))
+}
+
+// SendContact sends contacts.
+func (e Easy) SendContact(chatID ChatID, phoneNumber, firstName string, options OptionsSendContact) (Message, error) {
+	return resultOf(e.b.SendContact(chatID, phoneNumber, firstName, options))
+}
+
+// SendPoll sends a poll.
+func (e Easy) SendPoll(chatID ChatID, question string, pollOptions []string, options OptionsSendPoll) (Message, error) {
+	return resultOf(e.b.SendPoll(chatID, question, pollOptions, options))
+}
+
+// StopPoll stops a poll.
+func (e Easy) StopPoll(chatID ChatID, messageID int64, options OptionsStopPoll) (Poll, error) {
+	return resultOf(e.b.StopPoll(chatID, messageID, options))
+}
+
+// SendDice sends a random dice.
+func (e Easy) SendDice(chatID ChatID, options OptionsSendDice) (Message, error) {
+	return resultOf(e.b.SendDice(chatID, options))
+}
+
+// SendChatAction sends chat actions.
+func (e Easy) SendChatAction(chatID ChatID, action ChatAction, options OptionsSendChatAction) error {
+	return e.b.SendChatAction(chatID, action, options).Err()
+}
+
+// SetMessageReaction changes the chosen reactions on a message.
+func (e Easy) SetMessageReaction(chatID ChatID, messageID int64, options OptionsSetMessageReaction) error {
+	return e.b.SetMessageReaction(chatID, messageID, options).Err()
+}
+
+// GetUserChatBoosts gets the boosts added to a chat by a user.
+func (e Easy) GetUserChatBoosts(chatID ChatID, userID int64) (UserChatBoosts, error) {
+	return resultOf(e.b.GetUserChatBoosts(chatID, userID))
+}
+
+// GetBusinessConnection gets the connection of the bot with a business account.
+func (e Easy) GetBusinessConnection(businessConnectionID string) (BusinessConnection, error) {
+	return resultOf(e.b.GetBusinessConnection(businessConnectionID))
+}
+
+// GetUserProfilePhotos gets user profile photos.
+func (e Easy) GetUserProfilePhotos(userID int64, options OptionsGetUserProfilePhotos) (UserProfilePhotos, error) {
+	return resultOf(e.b.GetUserProfilePhotos(userID, options))
+}
+
+// GetFile gets file info and prepare for download.
+func (e Easy) GetFile(fileID string) (File, error) {
+	return resultOf(e.b.GetFile(fileID))
+}
+
+// BanChatMember bans a chat member.
+func (e Easy) BanChatMember(chatID ChatID, userID int64, options OptionsBanChatMember) error {
+	return e.b.BanChatMember(chatID, userID, options).Err()
+}
+
+// LeaveChat leaves a chat.
+func (e Easy) LeaveChat(chatID ChatID) error {
+	return e.b.LeaveChat(chatID).Err()
+}
+
+// UnbanChatMember unbans a chat member.
+func (e Easy) UnbanChatMember(chatID ChatID, userID int64, onlyIfBanned bool) error {
+	return e.b.UnbanChatMember(chatID, userID, onlyIfBanned).Err()
+}
+
+// RestrictChatMember restricts a chat member.
+func (e Easy) RestrictChatMember(chatID ChatID, userID int64, permissions ChatPermissions, options OptionsRestrictChatMember) error {
+	return e.b.RestrictChatMember(chatID, userID, permissions, options).Err()
+}
+
+// PromoteChatMember promotes a chat member.
+func (e Easy) PromoteChatMember(chatID ChatID, userID int64, options OptionsPromoteChatMember) error {
+	return e.b.PromoteChatMember(chatID, userID, options).Err()
+}
+
+// SetChatAdministratorCustomTitle sets chat administrator's custom title.
+func (e Easy) SetChatAdministratorCustomTitle(chatID ChatID, userID int64, customTitle string) error {
+	return e.b.SetChatAdministratorCustomTitle(chatID, userID, customTitle).Err()
+}
+
+// BanChatSenderChat bans a channel chat in a supergroup or a channel.
+func (e Easy) BanChatSenderChat(chatID ChatID, senderChatID int64) error {
+	return e.b.BanChatSenderChat(chatID, senderChatID).Err()
+}
+
+// UnbanChatSenderChat unbans a previously banned channel chat in a supergroup or a channel.
+func (e Easy) UnbanChatSenderChat(chatID ChatID, senderChatID int64) error {
+	return e.b.UnbanChatSenderChat(chatID, senderChatID).Err()
+}
+
+// SetChatPermissions sets permissions of a chat.
+func (e Easy) SetChatPermissions(chatID ChatID, permissions ChatPermissions, options OptionsSetChatPermissions) error {
+	return e.b.SetChatPermissions(chatID, permissions, options).Err()
+}
+
+// ExportChatInviteLink exports a chat invite link.
+func (e Easy) ExportChatInviteLink(chatID ChatID) (string, error) {
+	return resultOf(e.b.ExportChatInviteLink(chatID))
+}
+
+// CreateChatInviteLink creates a chat invite link.
+func (e Easy) CreateChatInviteLink(chatID ChatID, options OptionsCreateChatInviteLink) (ChatInviteLink, error) {
+	return resultOf(e.b.CreateChatInviteLink(chatID, options))
+}
+
+// EditChatInviteLink edits a chat invite link.
+func (e Easy) EditChatInviteLink(chatID ChatID, inviteLink string, options OptionsCreateChatInviteLink) (ChatInviteLink, error) {
+	return resultOf(e.b.EditChatInviteLink(chatID, inviteLink, options))
+}
+
+// RevokeChatInviteLink revoks a chat invite link.
+func (e Easy) RevokeChatInviteLink(chatID ChatID, inviteLink string) (ChatInviteLink, error) {
+	return resultOf(e.b.RevokeChatInviteLink(chatID, inviteLink))
+}
+
+// ApproveChatJoinRequest approves chat join request.
+func (e Easy) ApproveChatJoinRequest(chatID ChatID, userID int64) error {
+	return e.b.ApproveChatJoinRequest(chatID, userID).Err()
+}
+
+// DeclineChatJoinRequest declines chat join request.
+func (e Easy) DeclineChatJoinRequest(chatID ChatID, userID int64) error {
+	return e.b.DeclineChatJoinRequest(chatID, userID).Err()
+}
+
+// SetChatPhoto sets a chat photo.
+func (e Easy) SetChatPhoto(chatID ChatID, photo InputFile) error {
+	return e.b.SetChatPhoto(chatID, photo).Err()
+}
+
+// DeleteChatPhoto deletes a chat photo.
+func (e Easy) DeleteChatPhoto(chatID ChatID) error {
+	return e.b.DeleteChatPhoto(chatID).Err()
+}
+
+// SetChatTitle sets a chat title.
+func (e Easy) SetChatTitle(chatID ChatID, title string) error {
+	return e.b.SetChatTitle(chatID, title).Err()
+}
+
+// SetChatDescription sets a chat description.
+func (e Easy) SetChatDescription(chatID ChatID, description string) error {
+	return e.b.SetChatDescription(chatID, description).Err()
+}
+
+// PinChatMessage pins a chat message.
+func (e Easy) PinChatMessage(chatID ChatID, messageID int64, options OptionsPinChatMessage) error {
+	return e.b.PinChatMessage(chatID, messageID, options).Err()
+}
+
+// UnpinChatMessage unpins a chat message.
+func (e Easy) UnpinChatMessage(chatID ChatID, options OptionsUnpinChatMessage) error {
+	return e.b.UnpinChatMessage(chatID, options).Err()
+}
+
+// UnpinAllChatMessages unpins all chat messages.
+func (e Easy) UnpinAllChatMessages(chatID ChatID) error {
+	return e.b.UnpinAllChatMessages(chatID).Err()
+}
+
+// GetChat gets a chat.
+func (e Easy) GetChat(chatID ChatID) (Chat, error) {
+	return resultOf(e.b.GetChat(chatID))
+}
+
+// GetChatAdministrators gets chat administrators.
+func (e Easy) GetChatAdministrators(chatID ChatID) ([]ChatMember, error) {
+	return resultOf(e.b.GetChatAdministrators(chatID))
+}
+
+// GetChatMemberCount gets chat members' count.
+func (e Easy) GetChatMemberCount(chatID ChatID) (int, error) {
+	return resultOf(e.b.GetChatMemberCount(chatID))
+}
+
+// GetChatMember gets a chat member.
+func (e Easy) GetChatMember(chatID ChatID, userID int64) (ChatMember, error) {
+	return resultOf(e.b.GetChatMember(chatID, userID))
+}
+
+// SetChatStickerSet sets a chat sticker set.
+func (e Easy) SetChatStickerSet(chatID ChatID, stickerSetName string) error {
+	return e.b.SetChatStickerSet(chatID, stickerSetName).Err()
+}
+
+// DeleteChatStickerSet deletes a chat sticker set.
+func (e Easy) DeleteChatStickerSet(chatID ChatID) error {
+	return e.b.DeleteChatStickerSet(chatID).Err()
+}
+
+// AnswerCallbackQuery answers a callback query.
+func (e Easy) AnswerCallbackQuery(callbackQueryID string, options OptionsAnswerCallbackQuery) error {
+	return e.b.AnswerCallbackQuery(callbackQueryID, options).Err()
+}
+
+// GetMyCommands fetches commands of this bot.
+func (e Easy) GetMyCommands(options OptionsGetMyCommands) ([]BotCommand, error) {
+	return resultOf(e.b.GetMyCommands(options))
+}
+
+// SetMyName changes the bot's name.
+func (e Easy) SetMyName(name string, options OptionsSetMyName) error {
+	return e.b.SetMyName(name, options).Err()
+}
+
+// GetMyName fetches the bot's name.
+func (e Easy) GetMyName(options OptionsGetMyName) (BotName, error) {
+	return resultOf(e.b.GetMyName(options))
+}
+
+// SetMyDescription sets the bot's description.
+func (e Easy) SetMyDescription(options OptionsSetMyDescription) error {
+	return e.b.SetMyDescription(options).Err()
+}
+
+// GetMyDescription gets the bot's description.
+func (e Easy) GetMyDescription(options OptionsGetMyDescription) (BotDescription, error) {
+	return resultOf(e.b.GetMyDescription(options))
+}
+
+// SetMyShortDescription sets the bot's short description.
+func (e Easy) SetMyShortDescription(options OptionsSetMyShortDescription) error {
+	return e.b.SetMyShortDescription(options).Err()
+}
+
+// GetMyShortDescription gets the bot's short description.
+func (e Easy) GetMyShortDescription(options OptionsGetMyShortDescription) (BotShortDescription, error) {
+	return resultOf(e.b.GetMyShortDescription(options))
+}
+
+// SetMyCommands sets commands of this bot.
+func (e Easy) SetMyCommands(commands []BotCommand, options OptionsSetMyCommands) error {
+	return e.b.SetMyCommands(commands, options).Err()
+}
+
+// DeleteMyCommands deletes commands of this bot.
+func (e Easy) DeleteMyCommands(options OptionsDeleteMyCommands) error {
+	return e.b.DeleteMyCommands(options).Err()
+}
+
+// SetChatMenuButton sets chat menu button.
+func (e Easy) SetChatMenuButton(options OptionsSetChatMenuButton) error {
+	return e.b.SetChatMenuButton(options).Err()
+}
+
+// GetChatMenuButton fetches current chat menu button.
+func (e Easy) GetChatMenuButton(options OptionsGetChatMenuButton) (MenuButton, error) {
+	return resultOf(e.b.GetChatMenuButton(options))
+}
+
+// SetMyDefaultAdministratorRights sets my default administrator rights.
+func (e Easy) SetMyDefaultAdministratorRights(options OptionsSetMyDefaultAdministratorRights) error {
+	return e.b.SetMyDefaultAdministratorRights(options).Err()
+}
+
+// GetMyDefaultAdministratorRights gets my default administrator rights.
+func (e Easy) GetMyDefaultAdministratorRights(options OptionsGetMyDefaultAdministratorRights) error {
+	return e.b.GetMyDefaultAdministratorRights(options).Err()
+}
+
+// EditMessageText edits text of a message.
+//
+// Returned message is nil when the API returned `true` instead of a message.
+func (e Easy) EditMessageText(text string, options OptionsEditMessageText) (*Message, error) {
+	res := e.b.EditMessageText(text, options)
+	return res.ResultMessage, res.Err()
+}
+
+// EditMessageCaption edits caption of a message.
+//
+// Returned message is nil when the API returned `true` instead of a message.
+func (e Easy) EditMessageCaption(options OptionsEditMessageCaption) (*Message, error) {
+	res := e.b.EditMessageCaption(options)
+	return res.ResultMessage, res.Err()
+}
+
+// EditMessageMedia edites a media message.
+//
+// Returned message is nil when the API returned `true` instead of a message.
+func (e Easy) EditMessageMedia(media InputMedia, options OptionsEditMessageMedia) (*Message, error) {
+	res := e.b.EditMessageMedia(media, options)
+	return res.ResultMessage, res.Err()
+}
+
+// EditMessageReplyMarkup edits reply markup of a message.
+//
+// Returned message is nil when the API returned `true` instead of a message.
+func (e Easy) EditMessageReplyMarkup(options OptionsEditMessageReplyMarkup) (*Message, error) {
+	res := e.b.EditMessageReplyMarkup(options)
+	return res.ResultMessage, res.Err()
+}
+
+// EditMessageLiveLocation edits live location of a message.
+//
+// Returned message is nil when the API returned `true` instead of a message.
+func (e Easy) EditMessageLiveLocation(latitude, longitude float64, options OptionsEditMessageLiveLocation) (*Message, error) {
+	res := e.b.EditMessageLiveLocation(latitude, longitude, options)
+	return res.ResultMessage, res.Err()
+}
+
+// StopMessageLiveLocation stops live location of a message.
+//
+// Returned message is nil when the API returned `true` instead of a message.
+func (e Easy) StopMessageLiveLocation(options OptionsStopMessageLiveLocation) (*Message, error) {
+	res := e.b.StopMessageLiveLocation(options)
+	return res.ResultMessage, res.Err()
+}
+
+// DeleteMessage deletes a message.
+func (e Easy) DeleteMessage(chatID ChatID, messageID int64) error {
+	return e.b.DeleteMessage(chatID, messageID).Err()
+}
+
+// DeleteMessages deletes multiple messages at once.
+func (e Easy) DeleteMessages(chatID ChatID, messageIDs []int64) error {
+	return e.b.DeleteMessages(chatID, messageIDs).Err()
+}
+
+// AnswerInlineQuery sends answers to an inline query.
+func (e Easy) AnswerInlineQuery(inlineQueryID string, results []any, options OptionsAnswerInlineQuery) error {
+	return e.b.AnswerInlineQuery(inlineQueryID, results, options).Err()
+}
+
+// SendInvoice sends an invoice.
+//
+// Deprecated: use SendInvoiceWithBuilder() instead, which validates the invoice before sending it.
+func (e Easy) SendInvoice(chatID ChatID, title, description, payload, providerToken, currency string, prices []LabeledPrice, options OptionsSendInvoice) (Message, error) {
+	return resultOf(e.b.SendInvoice(chatID, title, description, payload, providerToken, currency, prices, options))
+}
+
+// CreateInvoiceLink creates a link for an invoice.
+func (e Easy) CreateInvoiceLink(title, description, payload, providerToken, currency string, prices []LabeledPrice, options OptionsCreateInvoiceLink) (string, error) {
+	return resultOf(e.b.CreateInvoiceLink(title, description, payload, providerToken, currency, prices, options))
+}
+
+// AnswerShippingQuery answers a shipping query.
+func (e Easy) AnswerShippingQuery(shippingQueryID string, ok bool, shippingOptions []ShippingOption, errorMessage *string) error {
+	return e.b.AnswerShippingQuery(shippingQueryID, ok, shippingOptions, errorMessage).Err()
+}
+
+// AnswerPreCheckoutQuery answers a pre-checkout query.
+func (e Easy) AnswerPreCheckoutQuery(preCheckoutQueryID string, ok bool, errorMessage *string) error {
+	return e.b.AnswerPreCheckoutQuery(preCheckoutQueryID, ok, errorMessage).Err()
+}
+
+// SendGame sends a game.
+func (e Easy) SendGame(chatID ChatID, gameShortName string, options OptionsSendGame) (Message, error) {
+	return resultOf(e.b.SendGame(chatID, gameShortName, options))
+}
+
+// SetGameScore sets score of a game.
+//
+// Returned message is nil when the API returned `true` instead of a message.
+func (e Easy) SetGameScore(userID int64, score int, options OptionsSetGameScore) (*Message, error) {
+	res := e.b.SetGameScore(userID, score, options)
+	return res.ResultMessage, res.Err()
+}
+
+// GetGameHighScores gets high scores of a game.
+func (e Easy) GetGameHighScores(userID int64, options OptionsGetGameHighScores) ([]GameHighScore, error) {
+	return resultOf(e.b.GetGameHighScores(userID, options))
+}
+
+// AnswerWebAppQuery answers a web app's query
+func (e Easy) AnswerWebAppQuery(webAppQueryID string, res InlineQueryResult) (SentWebAppMessage, error) {
+	return resultOf(e.b.AnswerWebAppQuery(webAppQueryID, res))
+}
+
+// CreateForumTopic creates a topic in a forum supergroup chat.
+func (e Easy) CreateForumTopic(chatID ChatID, name string, options OptionsCreateForumTopic) (ForumTopic, error) {
+	return resultOf(e.b.CreateForumTopic(chatID, name, options))
+}
+
+// EditForumTopic edits a forum topic.
+func (e Easy) EditForumTopic(chatID ChatID, messageThreadID int64, options OptionsEditForumTopic) error {
+	return e.b.EditForumTopic(chatID, messageThreadID, options).Err()
+}
+
+// CloseForumTopic closes a forum topic.
+func (e Easy) CloseForumTopic(chatID ChatID, messageThreadID int64) error {
+	return e.b.CloseForumTopic(chatID, messageThreadID).Err()
+}
+
+// ReopenForumTopic reopens a forum topic.
+func (e Easy) ReopenForumTopic(chatID ChatID, messageThreadID int64) error {
+	return e.b.ReopenForumTopic(chatID, messageThreadID).Err()
+}
+
+// DeleteForumTopic deletes a forum topic.
+func (e Easy) DeleteForumTopic(chatID ChatID, messageThreadID int64) error {
+	return e.b.DeleteForumTopic(chatID, messageThreadID).Err()
+}
+
+// UnpinAllForumTopicMessages unpins all forum topic messages.
+func (e Easy) UnpinAllForumTopicMessages(chatID ChatID, messageThreadID int64) error {
+	return e.b.UnpinAllForumTopicMessages(chatID, messageThreadID).Err()
+}
+
+// EditGeneralForumTopic edites general forum topic.
+func (e Easy) EditGeneralForumTopic(chatID ChatID, name string) error {
+	return e.b.EditGeneralForumTopic(chatID, name).Err()
+}
+
+// CloseGeneralForumTopic closes general forum topic.
+func (e Easy) CloseGeneralForumTopic(chatID ChatID) error {
+	return e.b.CloseGeneralForumTopic(chatID).Err()
+}
+
+// ReopenGeneralForumTopic reopens general forum topic.
+func (e Easy) ReopenGeneralForumTopic(chatID ChatID) error {
+	return e.b.ReopenGeneralForumTopic(chatID).Err()
+}
+
+// HideGeneralForumTopic hides general forum topic.
+func (e Easy) HideGeneralForumTopic(chatID ChatID) error {
+	return e.b.HideGeneralForumTopic(chatID).Err()
+}
+
+// UnhideGeneralForumTopic unhides general forum topic.
+func (e Easy) UnhideGeneralForumTopic(chatID ChatID) error {
+	return e.b.UnhideGeneralForumTopic(chatID).Err()
+}
+
+// GetForumTopicIconStickers fetches forum topic icon stickers.
+func (e Easy) GetForumTopicIconStickers() ([]Sticker, error) {
+	return resultOf(e.b.GetForumTopicIconStickers())
+}
+
+// SendLongMessage sends a text message, splitting it into multiple messages if it is longer than MessageTextMaxLength.
+//
+// When it fails, returned messages are the ones sent so far.
+func (e Easy) SendLongMessage(chatID ChatID, text string, options OptionsSendMessage) ([]Message, error) {
+	return resultOf(e.b.SendLongMessage(chatID, text, options))
+}
+
+// SendLargeMediaGroup sends media as multiple media groups, if there are more than MediaGroupMaxItems items.
+//
+// When it fails, returned messages are the ones sent so far.
+func (e Easy) SendLargeMediaGroup(chatID ChatID, media []InputMedia, options OptionsSendMediaGroup) ([]Message, error) {
+	return resultOf(e.b.SendLargeMediaGroup(chatID, media, options))
+}
+
+// GetUpdatesLazy retrieves updates from Telegram bot API, without decoding their full payloads.
+func (e Easy) GetUpdatesLazy(options OptionsGetUpdates) ([]*LazyUpdate, error) {
+	return resultOf(e.b.GetUpdatesLazy(options))
+}
+
+// SendInvoiceWithBuilder sends an invoice built with InvoiceBuilder, after validating it.
+func (e Easy) SendInvoiceWithBuilder(chatID ChatID, invoice *InvoiceBuilder, options OptionsSendInvoice) (Message, error) {
+	return resultOf(e.b.SendInvoiceWithBuilder(chatID, invoice, options))
+}
+
+// CreateInvoiceLinkWithBuilder creates a link for an invoice built with InvoiceBuilder, after validating it.
+func (e Easy) CreateInvoiceLinkWithBuilder(invoice *InvoiceBuilder, options OptionsCreateInvoiceLink) (string, error) {
+	return resultOf(e.b.CreateInvoiceLinkWithBuilder(invoice, options))
+}
+
+// React sets given emojis as the bot's reactions on a message, replacing the previous ones. (see Bot.React)
+func (e Easy) React(chatID ChatID, messageID int64, emojis ...string) error {
+	return e.b.React(chatID, messageID, emojis...).Err()
+}
+
+// ClearReactions clears the bot's reactions on a message.
+func (e Easy) ClearReactions(chatID ChatID, messageID int64) error {
+	return e.b.ClearReactions(chatID, messageID).Err()
+}
+
+// TempBan bans a user from a chat for given duration (0 for forever), and logs it to the audit chat.
+func (e Easy) TempBan(chatID ChatID, userID int64, duration time.Duration, reason string) error {
+	return e.b.TempBan(chatID, userID, duration, reason).Err()
+}
+
+// Kick removes a user from a chat (the user can join again), and logs it to the audit chat.
+func (e Easy) Kick(chatID ChatID, userID int64, reason string) error {
+	return e.b.Kick(chatID, userID, reason).Err()
+}
+
+// Mute restricts a user to read-only in a chat for given duration (0 for forever), and logs it to the audit chat.
+func (e Easy) Mute(chatID ChatID, userID int64, duration time.Duration, reason string) error {
+	return e.b.Mute(chatID, userID, duration, reason).Err()
+}
+
+// Unmute lifts restrictions of a user in a chat to the chat's default permissions, and logs it to the audit chat.
+func (e Easy) Unmute(chatID ChatID, userID int64, reason string) error {
+	return e.b.Unmute(chatID, userID, reason).Err()
+}
+
+// Warn gives a warning to a user in a chat, and returns the number of warnings of the user.
+func (e Easy) Warn(chatID ChatID, userID int64, reason string) (int, error) {
+	return resultOf(e.b.Warn(chatID, userID, reason))
+}
+
+// Send sends given content to a chat with the matching sendXXX method, and returns sent messages.
+func (e Easy) Send(chatID ChatID, item Sendable, options MethodOptions) ([]Message, error) {
+	return resultOf(e.b.Send(chatID, item, options))
+}
+
+// SetMyCommandsBundle publishes localized commands for all scopes and languages of the bundle, with rollback on failures.
+func (e Easy) SetMyCommandsBundle(bundle BotCommandsBundle) (bool, error) {
+	return resultOf(e.b.SetMyCommandsBundle(bundle))
+}
@@ -0,0 +1,308 @@
+package telegrambot
+
+// Errors of API responses.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIError is an error of a failed API response
+type APIError struct {
+	ErrorCode   int                    // `error_code` of the response (0 if the request itself failed)
+	Description string                 // `description` of the response
+	Parameters  *APIResponseParameters // `parameters` of the response, if any
+}
+
+// Error returns the description of this error.
+func (e *APIError) Error() string {
+	if e.ErrorCode != 0 {
+		return fmt.Sprintf("telegram api error %d: %s", e.ErrorCode, e.Description)
+	}
+	return e.Description
+}
+
+// RetryAfter returns how long to wait before retrying, if this error is a 429 error. (0 otherwise)
+func (e *APIError) RetryAfter() time.Duration {
+	if e.Parameters == nil {
+		return 0
+	}
+	return time.Duration(e.Parameters.RetryAfter) * time.Second
+}
+
+// MigrateToChatID returns the id of the supergroup which the group was migrated to, if any. (0 otherwise)
+func (e *APIError) MigrateToChatID() int64 {
+	if e.Parameters == nil {
+		return 0
+	}
+	return e.Parameters.MigrateToChatID
+}
+
+// Is checks if this error matches given target, for errors.Is() with well-known errors. (eg. ErrTooManyRequests)
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRequestFailed:
+		return e.ErrorCode == 0
+	case ErrTooManyRequests:
+		return e.ErrorCode == 429
+	case ErrForbidden:
+		return IsForbidden(e)
+	case ErrBlockedByUser:
+		return IsBlockedByUser(e)
+	case ErrUserDeactivated:
+		return IsUserDeactivated(e)
+	case ErrKickedFromChat:
+		return IsKickedFromChat(e)
+	case ErrChatNotFound:
+		return IsChatNotFound(e)
+	case ErrChatMigrated:
+		return e.MigrateToChatID() != 0
+	case ErrMessageNotModified:
+		return IsMessageNotModified(e)
+	case ErrMessageNotFound:
+		return IsMessageNotFound(e)
+	case ErrQueryTooOld:
+		return IsQueryTooOld(e)
+	case ErrNotEnoughRights:
+		return IsNotEnoughRights(e)
+	case ErrInvalidFileID:
+		return IsInvalidFileID(e)
+	case ErrUnauthorized:
+		return IsUnauthorized(e)
+	case ErrNotFound:
+		return IsNotFound(e)
+	case ErrServerError:
+		return IsServerError(e)
+	}
+	return false
+}
+
+// return an *APIError from given response values, or nil if `ok` is true
+func errorOf(ok bool, errorCode int, description *string, parameters *APIResponseParameters) error {
+	if ok {
+		return nil
+	}
+
+	err := &APIError{
+		ErrorCode:  errorCode,
+		Parameters: parameters,
+	}
+	if description != nil {
+		err.Description = *description
+	} else {
+		err.Description = "unknown error"
+	}
+	return err
+}
+
+// return the result of given response, or an *APIError if it failed
+//
+// (result can be non-zero even when it failed, eg. messages sent so far with SendLongMessage)
+func resultOf[T any](response APIResponse[T]) (result T, err error) {
+	if response.Result != nil {
+		result = *response.Result
+	}
+	return result, response.Err()
+}
+
+// Err returns nil if this response is ok, or an *APIError otherwise.
+func (r APIResponse[T]) Err() error {
+	return errorOf(r.Ok, r.ErrorCode, r.Description, r.Parameters)
+}
+
+// MustResult returns the result of this response, or panics with its *APIError if it failed.
+//
+// (zero value of T is returned when the response is ok but has no result)
+func (r APIResponse[T]) MustResult() (result T) {
+	if err := r.Err(); err != nil {
+		panic(err)
+	}
+	if r.Result != nil {
+		result = *r.Result
+	}
+	return result
+}
+
+// Err returns nil if this response is ok, or an *APIError otherwise.
+func (r APIResponseMessageOrBool) Err() error {
+	return errorOf(r.Ok, r.ErrorCode, r.Description, r.Parameters)
+}
+
+// MustResult returns the resulting message of this response (nil when the result was `true`),
+// or panics with its *APIError if it failed.
+func (r APIResponseMessageOrBool) MustResult() *Message {
+	if err := r.Err(); err != nil {
+		panic(err)
+	}
+	return r.ResultMessage
+}
+
+////////////////////////////////
+// well-known errors
+//
+
+// Descriptions (or their distinctive parts) of well-known errors
+//
+// NOTE: Telegram may append details to descriptions, so they are matched as substrings (case-insensitive).
+const (
+	ErrorDescriptionBlockedByUser           = "Forbidden: bot was blocked by the user"
+	ErrorDescriptionUserDeactivated         = "Forbidden: user is deactivated"
+	ErrorDescriptionKickedFromGroup         = "Forbidden: bot was kicked from the group chat"
+	ErrorDescriptionKickedFromSupergroup    = "Forbidden: bot was kicked from the supergroup chat"
+	ErrorDescriptionNotMemberOfChannel      = "Forbidden: bot is not a member of the channel chat"
+	ErrorDescriptionCannotInitiate          = "Forbidden: bot can't initiate conversation with a user"
+	ErrorDescriptionChatNotFound            = "Bad Request: chat not found"
+	ErrorDescriptionUserNotFound            = "Bad Request: user not found"
+	ErrorDescriptionMessageNotModified      = "Bad Request: message is not modified"
+	ErrorDescriptionMessageToEditNotFound   = "Bad Request: message to edit not found"
+	ErrorDescriptionMessageToDeleteNotFound = "Bad Request: message to delete not found"
+	ErrorDescriptionMessageCantBeEdited     = "Bad Request: message can't be edited"
+	ErrorDescriptionMessageCantBeDeleted    = "Bad Request: message can't be deleted"
+	ErrorDescriptionQueryTooOld             = "Bad Request: query is too old and response timeout expired or query ID is invalid"
+	ErrorDescriptionNotEnoughRights         = "Bad Request: not enough rights"
+	ErrorDescriptionTooManyRequests         = "Too Many Requests: retry after"
+	ErrorDescriptionWrongFileIdentifier     = "Bad Request: wrong file identifier"
+	ErrorDescriptionWrongRemoteFileID       = "Bad Request: wrong remote file identifier"
+	ErrorDescriptionFileReferenceExpired    = "Bad Request: FILE_REFERENCE_EXPIRED"
+)
+
+// Well-known errors, matched with errors.Is()
+//
+//	if err := client.SendMessage(chatID, text, nil).Err(); errors.Is(err, ErrTooManyRequests) {
+//		var apiErr *APIError
+//		if errors.As(err, &apiErr) {
+//			time.Sleep(apiErr.RetryAfter())
+//		}
+//	} else if errors.Is(err, ErrRequestFailed) {
+//		// network failure, Telegram returned no error
+//	}
+var (
+	ErrRequestFailed      = errors.New("request failed")          // the request itself failed (eg. network errors, or invalid payloads)
+	ErrTooManyRequests    = errors.New("too many requests")       // see IsTooManyRequests()
+	ErrForbidden          = errors.New("forbidden")               // see IsForbidden()
+	ErrBlockedByUser      = errors.New("blocked by user")         // see IsBlockedByUser()
+	ErrUserDeactivated    = errors.New("user deactivated")        // see IsUserDeactivated()
+	ErrKickedFromChat     = errors.New("kicked from chat")        // see IsKickedFromChat()
+	ErrChatNotFound       = errors.New("chat not found")          // see IsChatNotFound()
+	ErrChatMigrated       = errors.New("chat migrated")           // the group was migrated to a supergroup (see APIError.MigrateToChatID())
+	ErrMessageNotModified = errors.New("message not modified")    // see IsMessageNotModified()
+	ErrMessageNotFound    = errors.New("message not found")       // see IsMessageNotFound()
+	ErrQueryTooOld        = errors.New("query too old")           // see IsQueryTooOld()
+	ErrNotEnoughRights    = errors.New("not enough rights")       // see IsNotEnoughRights()
+	ErrInvalidFileID      = errors.New("invalid file identifier") // see IsInvalidFileID()
+	ErrUnauthorized       = errors.New("unauthorized")            // see IsUnauthorized()
+	ErrNotFound           = errors.New("not found")               // see IsNotFound()
+	ErrServerError        = errors.New("server error")            // see IsServerError()
+)
+
+// return the *APIError in given error chain, if any
+func apiErrorOf(err error) (apiErr *APIError, ok bool) {
+	ok = errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// check if given error is an *APIError with given code (0 for any) whose description contains given one
+func isAPIError(err error, errorCode int, description string) bool {
+	apiErr, ok := apiErrorOf(err)
+	if !ok || (errorCode != 0 && apiErr.ErrorCode != errorCode) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Description), strings.ToLower(description))
+}
+
+// IsForbidden checks if given error is a 403 error. (eg. blocked, kicked, or deactivated)
+//
+// Messages cannot be sent to the chat (or user) until it changes.
+func IsForbidden(err error) bool {
+	apiErr, ok := apiErrorOf(err)
+	return ok && apiErr.ErrorCode == 403
+}
+
+// IsBlockedByUser checks if given error means that the bot was blocked by the user.
+func IsBlockedByUser(err error) bool {
+	return isAPIError(err, 403, ErrorDescriptionBlockedByUser)
+}
+
+// IsUserDeactivated checks if given error means that the user's account was deleted.
+func IsUserDeactivated(err error) bool {
+	return isAPIError(err, 403, ErrorDescriptionUserDeactivated)
+}
+
+// IsKickedFromChat checks if given error means that the bot was kicked from the group or supergroup chat,
+// or is not a member of the channel chat.
+func IsKickedFromChat(err error) bool {
+	return isAPIError(err, 403, ErrorDescriptionKickedFromGroup) ||
+		isAPIError(err, 403, ErrorDescriptionKickedFromSupergroup) ||
+		isAPIError(err, 403, ErrorDescriptionNotMemberOfChannel)
+}
+
+// IsChatNotFound checks if given error means that the chat was not found.
+func IsChatNotFound(err error) bool {
+	return isAPIError(err, 400, ErrorDescriptionChatNotFound)
+}
+
+// IsMessageNotModified checks if given error means that an edit was the same as the current message.
+//
+// It is usually safe to ignore.
+func IsMessageNotModified(err error) bool {
+	return isAPIError(err, 400, ErrorDescriptionMessageNotModified)
+}
+
+// IsMessageNotFound checks if given error means that the message to edit (or delete) was not found.
+func IsMessageNotFound(err error) bool {
+	return isAPIError(err, 400, ErrorDescriptionMessageToEditNotFound) ||
+		isAPIError(err, 400, ErrorDescriptionMessageToDeleteNotFound)
+}
+
+// IsQueryTooOld checks if given error means that the callback (or inline) query is too old to be answered.
+func IsQueryTooOld(err error) bool {
+	return isAPIError(err, 400, ErrorDescriptionQueryTooOld)
+}
+
+// IsNotEnoughRights checks if given error means that the bot lacks administrator rights for the request.
+func IsNotEnoughRights(err error) bool {
+	return isAPIError(err, 400, ErrorDescriptionNotEnoughRights)
+}
+
+// IsInvalidFileID checks if given error means that a file id is wrong, or is not valid anymore.
+func IsInvalidFileID(err error) bool {
+	return isAPIError(err, 400, ErrorDescriptionWrongFileIdentifier) ||
+		isAPIError(err, 400, ErrorDescriptionWrongRemoteFileID) ||
+		isAPIError(err, 400, ErrorDescriptionFileReferenceExpired)
+}
+
+// IsTooManyRequests checks if given error is a 429 error, and returns how long to wait before retrying.
+func IsTooManyRequests(err error) (retryAfter time.Duration, is bool) {
+	apiErr, ok := apiErrorOf(err)
+	if !ok || apiErr.ErrorCode != 429 {
+		return 0, false
+	}
+	if apiErr.Parameters != nil {
+		retryAfter = time.Duration(apiErr.Parameters.RetryAfter) * time.Second
+	}
+	return retryAfter, true
+}
+
+// IsUnauthorized checks if given error is a 401 error, which means that the bot token is invalid (or revoked).
+//
+// No request will succeed until the token is replaced.
+func IsUnauthorized(err error) bool {
+	apiErr, ok := apiErrorOf(err)
+	return ok && apiErr.ErrorCode == 401
+}
+
+// IsNotFound checks if given error is a 404 error, which means that the method does not exist,
+// or the bot token is malformed.
+func IsNotFound(err error) bool {
+	apiErr, ok := apiErrorOf(err)
+	return ok && apiErr.ErrorCode == 404
+}
+
+// IsServerError checks if given error is a 5xx error of the API server (or a proxy in front of it),
+// so the same request may succeed later.
+func IsServerError(err error) bool {
+	apiErr, ok := apiErrorOf(err)
+	return ok && apiErr.ErrorCode >= 500 && apiErr.ErrorCode < 600
+}
@@ -0,0 +1,207 @@
+package telegrambot
+
+// Failing over to a secondary API server when the primary one keeps failing, and failing back when it recovers.
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+)
+
+// default values of FailoverConfig
+const (
+	defaultFailoverMaxFailures         = 3
+	defaultFailoverHealthCheckInterval = 30 * time.Second
+)
+
+// APIEndpoint is an API server in use
+type APIEndpoint string
+
+// APIEndpoint strings
+const (
+	APIEndpointPrimary   APIEndpoint = "primary"
+	APIEndpointSecondary APIEndpoint = "secondary"
+)
+
+// FailoverConfig is a configuration for SetFailover()
+type FailoverConfig struct {
+	// base url of the secondary API server, followed by the bot token and method name (eg. "http://localhost:8081/bot")
+	SecondaryBaseURL string
+
+	MaxFailures         int           // consecutive failures (network errors, or 5xx) of the primary server before failing over (default: 3)
+	HealthCheckInterval time.Duration // interval of checking the primary server with getMe while failed over (default: 30 seconds)
+
+	OnStateChange func(b *Bot, from, to APIEndpoint) // called when the API server in use changes
+}
+
+// failover is the state of failover
+type failover struct {
+	config FailoverConfig
+
+	endpoint APIEndpoint
+	failures int
+	lock     sync.Mutex
+}
+
+// SetAPIBaseURL sets the base url of the (primary) API server, followed by the bot token and method name.
+// (default: "https://api.telegram.org/bot")
+//
+// eg. "http://localhost:8081/bot" for a local Bot API server (or use SetLocalServer() for setting file downloads too)
+func (b *Bot) SetAPIBaseURL(baseURL string) {
+	b.apiBaseURL = baseURL
+}
+
+// SetFailover enables failing over to a secondary API server (eg. a standby local Bot API server),
+// and returns a function for stopping the health checks.
+//
+// When requests to the primary server fail consecutively (with network errors, or 5xx errors), following requests
+// are sent to the secondary server. While failed over, the primary server is checked periodically with getMe,
+// and requests are sent to it again when it succeeds.
+//
+// NOTE: the bot should be usable on both servers (see https://core.telegram.org/bots/api#using-a-local-bot-api-server).
+//
+//	stop := client.SetFailover(FailoverConfig{
+//		SecondaryBaseURL: "http://standby:8081/bot",
+//		OnStateChange: func(b *Bot, from, to APIEndpoint) {
+//			log.Printf("api server changed: %s => %s", from, to)
+//		},
+//	})
+//	defer stop()
+func (b *Bot) SetFailover(config FailoverConfig) (stop func()) {
+	if config.MaxFailures <= 0 {
+		config.MaxFailures = defaultFailoverMaxFailures
+	}
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = defaultFailoverHealthCheckInterval
+	}
+
+	f := &failover{
+		config:   config,
+		endpoint: APIEndpointPrimary,
+	}
+	b.failover = f
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(config.HealthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if f.current() == APIEndpointSecondary && b.checkPrimaryAPIServer() {
+					b.switchAPIEndpoint(APIEndpointPrimary)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// APIEndpoint returns the API server in use.
+func (b *Bot) APIEndpoint() APIEndpoint {
+	if b.failover == nil {
+		return APIEndpointPrimary
+	}
+	return b.failover.current()
+}
+
+// base url of the API server in use
+func (b *Bot) currentAPIBaseURL() string {
+	if b.failover != nil && b.failover.current() == APIEndpointSecondary {
+		return b.failover.config.SecondaryBaseURL
+	}
+	return b.apiBaseURL
+}
+
+// endpoint in use
+func (f *failover) current() APIEndpoint {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.endpoint
+}
+
+// count a result of a request to the API server, and fail over if needed
+func (b *Bot) reportAPIResult(resp []byte, err error) {
+	f := b.failover
+
+	f.lock.Lock()
+	if f.endpoint != APIEndpointPrimary {
+		f.lock.Unlock()
+		return
+	}
+	if !b.isServerFailure(resp, err) {
+		f.failures = 0
+		f.lock.Unlock()
+		return
+	}
+	f.failures++
+	failedOver := f.failures >= f.config.MaxFailures
+	f.lock.Unlock()
+
+	if failedOver {
+		b.error("primary api server failed %d times consecutively, failing over", f.config.MaxFailures)
+
+		b.switchAPIEndpoint(APIEndpointSecondary)
+	}
+}
+
+// change the endpoint in use
+func (b *Bot) switchAPIEndpoint(to APIEndpoint) {
+	f := b.failover
+
+	f.lock.Lock()
+	from := f.endpoint
+	f.endpoint, f.failures = to, 0
+	f.lock.Unlock()
+
+	if from == to {
+		return
+	}
+
+	b.verbose("api server changed: %s => %s", from, to)
+
+	if f.config.OnStateChange != nil {
+		f.config.OnStateChange(b, from, to)
+	}
+}
+
+// check if the primary API server is healthy
+func (b *Bot) checkPrimaryAPIServer() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultMethodTimeout)
+	defer cancel()
+
+	resp, err := b.requestURLEncodedFormDataContext(ctx, b.apiBaseURL+b.token+"/getMe", map[string]any{})
+	return err == nil && bytes.HasPrefix(bytes.TrimSpace(resp), []byte(`{"ok":true`))
+}
+
+// check if given response (or error) means that the API server failed
+func (b *Bot) isServerFailure(resp []byte, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	resp = bytes.TrimSpace(resp)
+	if len(resp) == 0 || resp[0] != '{' { // eg. an html error page of a proxy
+		return true
+	}
+	if bytes.HasPrefix(resp, []byte(`{"ok":true`)) {
+		return false
+	}
+
+	var res struct {
+		ErrorCode int `json:"error_code"`
+	}
+	if b.jsonCodec().Unmarshal(resp, &res) != nil {
+		return true
+	}
+	return res.ErrorCode >= 500
+}
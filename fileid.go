@@ -0,0 +1,167 @@
+package telegrambot
+
+// Caching file ids of uploaded files, and uploading them again when their file ids become invalid.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// params of sendXXX methods which can have uploaded files, and fields of sent messages for them
+var _fileIDParams = map[string]func(m Message) (fileID string, ok bool){
+	"photo": func(m Message) (string, bool) {
+		if len(m.Photo) > 0 {
+			return m.LargestPhoto().FileID, true
+		}
+		return "", false
+	},
+	"audio": func(m Message) (string, bool) {
+		if m.Audio != nil {
+			return m.Audio.FileID, true
+		}
+		return "", false
+	},
+	"document": func(m Message) (string, bool) {
+		if m.Document != nil {
+			return m.Document.FileID, true
+		}
+		return "", false
+	},
+	"sticker": func(m Message) (string, bool) {
+		if m.Sticker != nil {
+			return m.Sticker.FileID, true
+		}
+		return "", false
+	},
+	"video": func(m Message) (string, bool) {
+		if m.Video != nil {
+			return m.Video.FileID, true
+		}
+		return "", false
+	},
+	"animation": func(m Message) (string, bool) {
+		if m.Animation != nil {
+			return m.Animation.FileID, true
+		}
+		return "", false
+	},
+	"voice": func(m Message) (string, bool) {
+		if m.Voice != nil {
+			return m.Voice.FileID, true
+		}
+		return "", false
+	},
+	"video_note": func(m Message) (string, bool) {
+		if m.VideoNote != nil {
+			return m.VideoNote.FileID, true
+		}
+		return "", false
+	},
+}
+
+// SetFileIDCache sets the cache store of file ids, and their TTL. (never expire if `ttl` <= 0)
+//
+// File ids of files uploaded with sendXXX methods are cached (keyed by their file paths, urls, or
+// hashes of bytes), and can be reused with CachedInputFile().
+// When a cached file id turns out to be invalid, the file is uploaded again and its new file id is cached.
+//
+// If `store` is nil, a new MemoryCacheStore will be used.
+//
+//	client.SetFileIDCache(nil, 0)
+//
+//	// uploaded on the first call, and sent with the cached file id afterwards
+//	client.SendPhoto(chatID, client.CachedInputFile(InputFileFromFilepath("./logo.png")), nil)
+func (b *Bot) SetFileIDCache(store CacheStore, ttl time.Duration) {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+
+	b.fileIDCache = store
+	b.fileIDCacheTTL = ttl
+}
+
+// CachedInputFile returns an InputFile with the cached file id of given file (with it as the source),
+// or the file itself if its file id is not cached yet.
+func (b *Bot) CachedInputFile(source InputFile) InputFile {
+	if b.fileIDCache != nil {
+		if key, ok := fileIDCacheKey(source); ok {
+			if value, exists := b.fileIDCache.Get(key); exists {
+				if fileID, ok := value.(string); ok {
+					return InputFileFromFileIDWithSource(fileID, source)
+				}
+			}
+		}
+	}
+	return source
+}
+
+// key of the cached file id of given file (not ok if it is not a file to be uploaded)
+func fileIDCacheKey(file InputFile) (key string, ok bool) {
+	switch {
+	case file.Filepath != nil:
+		return "fileid:path:" + *file.Filepath, true
+	case file.URL != nil:
+		return "fileid:url:" + *file.URL, true
+	case len(file.Bytes) > 0:
+		hash := sha256.Sum256(file.Bytes)
+		return "fileid:sha256:" + hex.EncodeToString(hash[:]), true
+	}
+	return "", false
+}
+
+// send a message with a file, caching its file id, and uploading it again when its file id is invalid
+//
+// (not ok if `params` has no file to be handled)
+func (b *Bot) requestMessageWithFileID(method string, params map[string]any) (result APIResponse[Message], ok bool) {
+	var param string
+	var file InputFile
+	for key := range _fileIDParams {
+		if f, exists := params[key].(InputFile); exists && (f.Source != nil || (b.fileIDCache != nil && f.FileID == nil)) {
+			param, file = key, f
+			break
+		}
+	}
+	if param == "" {
+		return result, false
+	}
+
+	if file.FileID == nil { // upload
+		result = b.requestMessageOnce(method, params)
+		b.cacheFileID(file, param, result)
+		return result, true
+	}
+
+	params[param] = InputFile{FileID: file.FileID}
+	result = b.requestMessageOnce(method, params)
+	if result.Ok || !IsInvalidFileID(result.Err()) {
+		return result, true
+	}
+
+	b.verbose("file id of '%s' is invalid, uploading it again", param)
+
+	source := *file.Source
+	if key, ok := fileIDCacheKey(source); ok && b.fileIDCache != nil {
+		b.fileIDCache.Delete(key)
+	}
+
+	params[param] = source
+	result = b.requestMessageOnce(method, params)
+	b.cacheFileID(source, param, result)
+
+	return result, true
+}
+
+// cache the file id of given uploaded file from the sent message
+func (b *Bot) cacheFileID(file InputFile, param string, result APIResponse[Message]) {
+	if b.fileIDCache == nil || !result.Ok || result.Result == nil {
+		return
+	}
+	key, ok := fileIDCacheKey(file)
+	if !ok {
+		return
+	}
+	if fileID, ok := _fileIDParams[param](*result.Result); ok && fileID != "" {
+		b.fileIDCache.Set(key, fileID, b.fileIDCacheTTL)
+	}
+}
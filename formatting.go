@@ -0,0 +1,330 @@
+package telegrambot
+
+// Building formatted texts programmatically, as texts with entities, or as escaped MarkdownV2 or HTML texts.
+//
+// https://core.telegram.org/bots/api#formatting-options
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormattedText is a text built with formatting functions (eg. Bold(), Link()), nested in any depth.
+//
+// It can be sent as a text with entities, or as a MarkdownV2 or HTML text which is escaped correctly:
+//
+//	formatted := Concat("Hello, ", Bold(name), "! See ", Link("the docs", docsURL), ".")
+//
+//	text, entities := formatted.Entities()
+//	bot.SendMessage(chatID, text, OptionsSendMessage{}.SetEntities(entities))
+//	// or
+//	bot.SendMessage(chatID, formatted.MarkdownV2(), OptionsSendMessage{}.SetParseMode(ParseModeMarkdownV2))
+//	// or
+//	bot.SendMessage(chatID, formatted.HTML(), OptionsSendMessage{}.SetParseMode(ParseModeHTML))
+type FormattedText struct {
+	text     string          // plain text (only when it has no children)
+	entity   *MessageEntity  // entity wrapping the children (nil for plain texts and concatenations)
+	children []FormattedText // nested texts
+}
+
+// Concat concatenates given parts into a FormattedText.
+//
+// Parts can be FormattedTexts, strings, or any other values (formatted with fmt.Sprint).
+func Concat(parts ...any) FormattedText {
+	return FormattedText{children: formattedChildren(parts)}
+}
+
+// Append returns a new FormattedText with given parts appended.
+func (f FormattedText) Append(parts ...any) FormattedText {
+	return Concat(append([]any{f}, parts...)...)
+}
+
+// Bold returns given parts in bold.
+func Bold(parts ...any) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeBold}, parts)
+}
+
+// Italic returns given parts in italic.
+func Italic(parts ...any) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeItalic}, parts)
+}
+
+// Underline returns given parts underlined.
+func Underline(parts ...any) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeUnderline}, parts)
+}
+
+// Strikethrough returns given parts struck through.
+func Strikethrough(parts ...any) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeStrikethrough}, parts)
+}
+
+// Spoiler returns given parts as a spoiler.
+func Spoiler(parts ...any) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeSpoiler}, parts)
+}
+
+// Link returns `text` (a FormattedText, a string, or any other value) as a link to `url`.
+func Link(text any, url string) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeTextLink, URL: &url}, []any{text})
+}
+
+// TextMention returns `text` (a FormattedText, a string, or any other value) as a mention of `user`.
+// (for users without usernames)
+func TextMention(text any, user User) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeTextMention, User: &user}, []any{text})
+}
+
+// CustomEmoji returns `emoji` as a custom emoji with `customEmojiID`. (`emoji` is shown where custom emojis are not supported)
+func CustomEmoji(emoji, customEmojiID string) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeCustomEmoji, CustomEmojiID: &customEmojiID}, []any{emoji})
+}
+
+// Code returns `code` as an inline code. (it cannot be nested with other formats)
+func Code(code string) FormattedText {
+	return formattedEntity(MessageEntity{Type: MessageEntityTypeCode}, []any{code})
+}
+
+// Pre returns `code` as a pre-formatted block, in programming `language` ("" for none).
+func Pre(code, language string) FormattedText {
+	entity := MessageEntity{Type: MessageEntityTypePre}
+	if language != "" {
+		entity.Language = &language
+	}
+	return formattedEntity(entity, []any{code})
+}
+
+// String returns the plain text without formatting.
+func (f FormattedText) String() string {
+	var builder strings.Builder
+	f.walk(func(text string) {
+		builder.WriteString(text)
+	}, nil, nil)
+	return builder.String()
+}
+
+// Entities returns the plain text and its entities, for `text` and `entities` (or `caption` and `caption_entities`).
+//
+// Empty entities are omitted.
+func (f FormattedText) Entities() (text string, entities []MessageEntity) {
+	var builder strings.Builder
+	offset := 0
+	opened := []int{} // indices of opened entities, -1 for texts without entities
+
+	f.walk(func(text string) {
+		builder.WriteString(text)
+		offset += utf16Len(text)
+	}, func(entity MessageEntity) {
+		entity.Offset = offset
+		entities = append(entities, entity)
+		opened = append(opened, len(entities)-1)
+	}, func(MessageEntity) {
+		index := opened[len(opened)-1]
+		opened = opened[:len(opened)-1]
+		entities[index].Length = offset - entities[index].Offset
+	})
+
+	nonEmpty := []MessageEntity{}
+	for _, entity := range entities {
+		if entity.Length > 0 {
+			nonEmpty = append(nonEmpty, entity)
+		}
+	}
+
+	return builder.String(), nonEmpty
+}
+
+// MarkdownV2 returns the text with MarkdownV2 markups, for `parse_mode` of ParseModeMarkdownV2.
+func (f FormattedText) MarkdownV2() string {
+	var builder strings.Builder
+	var last byte // last character of the last markup (0 after texts), for separating adjacent markups
+
+	markup := func(s string) {
+		if last != 0 && s[0] == last && strings.IndexByte("_*~|`", last) >= 0 {
+			// empty entity as a separator, eg. "___italic underline_**__"
+			if last == '*' {
+				builder.WriteString("__")
+			} else {
+				builder.WriteString("**")
+			}
+		}
+		builder.WriteString(s)
+		last = s[len(s)-1]
+	}
+
+	var inCode bool
+	f.walk(func(text string) {
+		if inCode {
+			builder.WriteString(EscapeMarkdownV2Code(text))
+		} else {
+			builder.WriteString(EscapeMarkdownV2(text))
+		}
+		if text != "" {
+			last = 0
+		}
+	}, func(entity MessageEntity) {
+		switch entity.Type {
+		case MessageEntityTypeBold:
+			markup("*")
+		case MessageEntityTypeItalic:
+			markup("_")
+		case MessageEntityTypeUnderline:
+			markup("__")
+		case MessageEntityTypeStrikethrough:
+			markup("~")
+		case MessageEntityTypeSpoiler:
+			markup("||")
+		case MessageEntityTypeTextLink, MessageEntityTypeTextMention:
+			markup("[")
+		case MessageEntityTypeCustomEmoji:
+			markup("![")
+		case MessageEntityTypeCode:
+			markup("`")
+			inCode = true
+		case MessageEntityTypePre:
+			if entity.Language != nil {
+				markup("```" + EscapeMarkdownV2Code(*entity.Language) + "\n")
+			} else {
+				markup("```\n")
+			}
+			inCode = true
+		}
+	}, func(entity MessageEntity) {
+		switch entity.Type {
+		case MessageEntityTypeBold:
+			markup("*")
+		case MessageEntityTypeItalic:
+			markup("_")
+		case MessageEntityTypeUnderline:
+			markup("__")
+		case MessageEntityTypeStrikethrough:
+			markup("~")
+		case MessageEntityTypeSpoiler:
+			markup("||")
+		case MessageEntityTypeTextLink:
+			markup("](" + EscapeMarkdownV2URL(*entity.URL) + ")")
+		case MessageEntityTypeTextMention:
+			markup(fmt.Sprintf("](tg://user?id=%d)", entity.User.ID))
+		case MessageEntityTypeCustomEmoji:
+			markup("](tg://emoji?id=" + EscapeMarkdownV2URL(*entity.CustomEmojiID) + ")")
+		case MessageEntityTypeCode:
+			markup("`")
+			inCode = false
+		case MessageEntityTypePre:
+			markup("\n```")
+			inCode = false
+		}
+	})
+
+	return builder.String()
+}
+
+// HTML returns the text with HTML tags, for `parse_mode` of ParseModeHTML.
+func (f FormattedText) HTML() string {
+	var builder strings.Builder
+
+	f.walk(func(text string) {
+		builder.WriteString(EscapeHTML(text))
+	}, func(entity MessageEntity) {
+		switch entity.Type {
+		case MessageEntityTypeBold:
+			builder.WriteString("<b>")
+		case MessageEntityTypeItalic:
+			builder.WriteString("<i>")
+		case MessageEntityTypeUnderline:
+			builder.WriteString("<u>")
+		case MessageEntityTypeStrikethrough:
+			builder.WriteString("<s>")
+		case MessageEntityTypeSpoiler:
+			builder.WriteString("<tg-spoiler>")
+		case MessageEntityTypeTextLink:
+			builder.WriteString(`<a href="` + EscapeHTML(*entity.URL) + `">`)
+		case MessageEntityTypeTextMention:
+			builder.WriteString(fmt.Sprintf(`<a href="tg://user?id=%d">`, entity.User.ID))
+		case MessageEntityTypeCustomEmoji:
+			builder.WriteString(`<tg-emoji emoji-id="` + EscapeHTML(*entity.CustomEmojiID) + `">`)
+		case MessageEntityTypeCode:
+			builder.WriteString("<code>")
+		case MessageEntityTypePre:
+			if entity.Language != nil {
+				builder.WriteString(`<pre><code class="language-` + EscapeHTML(*entity.Language) + `">`)
+			} else {
+				builder.WriteString("<pre>")
+			}
+		}
+	}, func(entity MessageEntity) {
+		switch entity.Type {
+		case MessageEntityTypeBold:
+			builder.WriteString("</b>")
+		case MessageEntityTypeItalic:
+			builder.WriteString("</i>")
+		case MessageEntityTypeUnderline:
+			builder.WriteString("</u>")
+		case MessageEntityTypeStrikethrough:
+			builder.WriteString("</s>")
+		case MessageEntityTypeSpoiler:
+			builder.WriteString("</tg-spoiler>")
+		case MessageEntityTypeTextLink, MessageEntityTypeTextMention:
+			builder.WriteString("</a>")
+		case MessageEntityTypeCustomEmoji:
+			builder.WriteString("</tg-emoji>")
+		case MessageEntityTypeCode:
+			builder.WriteString("</code>")
+		case MessageEntityTypePre:
+			if entity.Language != nil {
+				builder.WriteString("</code></pre>")
+			} else {
+				builder.WriteString("</pre>")
+			}
+		}
+	})
+
+	return builder.String()
+}
+
+// EscapeHTML escapes given text for HTML texts and attribute values. ('<', '>', '&', and '"')
+func EscapeHTML(text string) string {
+	return htmlEscaper.Replace(text)
+}
+
+// replacer for EscapeHTML()
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// walk the text in order, calling `text` for plain texts, and `open`/`close` for entities (nil for ignoring them)
+func (f FormattedText) walk(text func(string), open, close func(MessageEntity)) {
+	if f.entity != nil && open != nil {
+		open(*f.entity)
+	}
+
+	if f.children == nil {
+		text(f.text)
+	}
+	for _, child := range f.children {
+		child.walk(text, open, close)
+	}
+
+	if f.entity != nil && close != nil {
+		close(*f.entity)
+	}
+}
+
+// FormattedText of given entity wrapping parts
+func formattedEntity(entity MessageEntity, parts []any) FormattedText {
+	return FormattedText{entity: &entity, children: formattedChildren(parts)}
+}
+
+// FormattedTexts of given parts
+func formattedChildren(parts []any) []FormattedText {
+	children := []FormattedText{}
+	for _, part := range parts {
+		switch p := part.(type) {
+		case FormattedText:
+			children = append(children, p)
+		case string:
+			children = append(children, FormattedText{text: p})
+		default:
+			children = append(children, FormattedText{text: fmt.Sprint(p)})
+		}
+	}
+	return children
+}
@@ -0,0 +1,144 @@
+package telegrambot
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// add golden files in given testdata directory to the seed corpus
+func addSeedCorpus(f *testing.F, dir string) {
+	files, _ := filepath.Glob(filepath.Join("testdata", dir, "*.json"))
+	for _, file := range files {
+		if data, err := os.ReadFile(file); err == nil {
+			f.Add(data)
+		}
+	}
+
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{"update_id":1,"message":{"message_id":1,"chat":{"id":1,"type":"private"},"photo":[]}}`))
+}
+
+// FuzzUpdateDecoding fuzzes the decoding of Update and its helper functions.
+func FuzzUpdateDecoding(f *testing.F) {
+	addSeedCorpus(f, "updates")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var update Update
+		if err := json.Unmarshal(data, &update); err != nil {
+			return
+		}
+
+		_ = update.String()
+		if update.HasMessage() {
+			_ = update.Message.LargestPhoto()
+		}
+
+		if _, err := json.Marshal(update); err != nil {
+			t.Errorf("failed to marshal decoded update: %s", err)
+		}
+	})
+}
+
+// FuzzAPIResponseDecoding fuzzes the decoding of API responses.
+func FuzzAPIResponseDecoding(f *testing.F) {
+	addSeedCorpus(f, "responses")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var message APIResponse[Message]
+		_ = json.Unmarshal(data, &message)
+
+		var messages APIResponse[[]Message]
+		_ = json.Unmarshal(data, &messages)
+
+		var updates APIResponse[[]Update]
+		_ = json.Unmarshal(data, &updates)
+
+		var members APIResponse[[]ChatMember]
+		_ = json.Unmarshal(data, &members)
+
+		var boolean APIResponse[bool]
+		_ = json.Unmarshal(data, &boolean)
+	})
+}
+
+// FuzzParseCommand fuzzes the parsing of bot commands with arbitrary texts and entities.
+func FuzzParseCommand(f *testing.F) {
+	f.Add("/start@MyBot arg1  arg2", 12)
+	f.Add("", 6)
+	f.Add("   ", 3)
+	f.Add("/é😀 z", 4)
+
+	f.Fuzz(func(t *testing.T, text string, length int) {
+		message := Message{
+			Text:     &text,
+			Entities: []MessageEntity{{Type: MessageEntityTypeBotCommand, Offset: 0, Length: length}},
+		}
+
+		_, _ = ParseCommand(message)
+	})
+}
+
+// FuzzWebhookHandler fuzzes the request body path of the webhook handler.
+func FuzzWebhookHandler(f *testing.F) {
+	addSeedCorpus(f, "updates")
+
+	b := NewClient("01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST")
+	b.updateHandler = func(b *Bot, update Update, err error) {
+		_ = update.String()
+	}
+	_stderr.SetOutput(bytes.NewBuffer(nil)) // suppress error logs while fuzzing
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req := httptest.NewRequest(http.MethodPost, b.getWebhookPath(), bytes.NewReader(data))
+		recorder := httptest.NewRecorder()
+
+		b.handleWebhook(recorder, req)
+	})
+}
+
+// FuzzCallbackDataCodec fuzzes the round trip of CallbackDataCodec, and the decoding of arbitrary callback data.
+func FuzzCallbackDataCodec(f *testing.F) {
+	type payload struct {
+		Text   string
+		Number int64
+		Flag   bool
+	}
+
+	f.Add("up", int64(42), true)
+	f.Add("a:b%3A%", int64(-1), false)
+	f.Add("", int64(0), false)
+
+	codec := NewCallbackDataCodec([]byte("secret"))
+
+	f.Fuzz(func(t *testing.T, text string, number int64, flag bool) {
+		var decoded payload
+		_ = codec.Decode("p", "p:"+text, &decoded)
+
+		original := payload{Text: text, Number: number, Flag: flag}
+		data, err := codec.Encode("p", original)
+		if err != nil {
+			return // too long
+		}
+		if len(data) > 64 {
+			t.Fatalf("encoded callback data exceed 64 bytes: '%s'", data)
+		}
+
+		decoded = payload{}
+		if err := codec.Decode("p", data, &decoded); err != nil {
+			t.Fatalf("failed to decode '%s': %s", data, err)
+		}
+		if decoded != original {
+			t.Errorf("decoded %+v, expected %+v", decoded, original)
+		}
+
+		if err := codec.Decode("p", data[:len(data)-1]+"x", &decoded); err == nil && data[len(data)-1] != 'x' {
+			t.Errorf("decoded '%s' with a forged signature", data)
+		}
+	})
+}
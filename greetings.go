@@ -0,0 +1,383 @@
+package telegrambot
+
+// Templated welcome/goodbye messages for members who join or leave chats, configured per chat.
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GreetingMediaType is a type of media attached to greeting messages
+type GreetingMediaType string
+
+// GreetingMediaType strings
+const (
+	GreetingMediaTypePhoto     GreetingMediaType = "photo"
+	GreetingMediaTypeVideo     GreetingMediaType = "video"
+	GreetingMediaTypeAnimation GreetingMediaType = "animation"
+)
+
+// GreetingMessage is a template of welcome or goodbye messages
+//
+// Placeholders in `Text` are replaced with values of the member and the chat, escaped for `ParseMode`:
+// `{first_name}`, `{last_name}`, `{full_name}`, `{username}`, `{user_id}`, `{mention}`, and `{chat_title}`.
+type GreetingMessage struct {
+	Text      string
+	ParseMode *ParseMode // nil for plain text
+
+	Media     *InputFile        // media sent with `Text` as its caption (nil for text only)
+	MediaType GreetingMediaType // type of `Media` (default: GreetingMediaTypePhoto)
+
+	AutoDelete time.Duration // sent messages are deleted after this duration (0 for never)
+}
+
+// GreetingConfig is a configuration of greeting messages for a chat
+type GreetingConfig struct {
+	Welcome *GreetingMessage // sent when a member joins (nil for none)
+	Goodbye *GreetingMessage // sent when a member leaves (nil for none)
+}
+
+// how long joins/leaves are remembered, not to greet twice for both `new_chat_members` and `chat_member`
+const greetingDedupeTTL = time.Minute
+
+// Greetings sends welcome and goodbye messages, configured per chat.
+type Greetings struct {
+	bot           *Bot
+	store         CacheStore
+	defaultConfig GreetingConfig
+
+	recent *MemoryCacheStore // recently greeted joins/leaves
+}
+
+// EnableGreetings enables welcome and goodbye messages, and returns the Greetings for configuring chats.
+//
+// Configurations of chats are kept in `store` (eg. a persistent CacheStore implementation),
+// and `defaultConfig` is used for chats without their own configurations.
+// If `store` is nil, a new MemoryCacheStore will be used.
+//
+// It is added with AddUpdateHandler(). Joins and leaves are detected from `new_chat_members` and
+// `left_chat_member` of messages, and also from `chat_member` updates when they are included in `allowed_updates`.
+//
+//	parseMode := ParseModeHTML
+//	greetings := client.EnableGreetings(nil, GreetingConfig{
+//		Welcome: &GreetingMessage{Text: "Welcome to {chat_title}, {mention}!", ParseMode: &parseMode, AutoDelete: time.Minute},
+//		Goodbye: &GreetingMessage{Text: "Bye, {full_name}."},
+//	})
+//	greetings.Set(chatID, GreetingConfig{}) // no greetings in this chat
+func (b *Bot) EnableGreetings(store CacheStore, defaultConfig GreetingConfig) *Greetings {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+
+	g := &Greetings{
+		bot:           b,
+		store:         store,
+		defaultConfig: defaultConfig,
+		recent:        NewMemoryCacheStore(),
+	}
+
+	b.AddUpdateHandler("greetings", func(ctx *Ctx) error {
+		return g.handle(ctx.Update)
+	})
+
+	return g
+}
+
+// Set sets the configuration of given chat.
+//
+// It fails if media of the configuration is an InputFile with `Reader`, which cannot be stored.
+func (g *Greetings) Set(chatID int64, config GreetingConfig) error {
+	stored, err := newStoredGreetingConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to store greeting config of chat %d: %w", chatID, err)
+	}
+	encoded, err := encodeStoreValue(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode greeting config of chat %d: %w", chatID, err)
+	}
+
+	g.store.Set(greetingConfigKey(chatID), encoded, 0)
+	return nil
+}
+
+// Get returns the configuration of given chat. (the default configuration if not set)
+func (g *Greetings) Get(chatID int64) GreetingConfig {
+	if value, exists := g.store.Get(greetingConfigKey(chatID)); exists {
+		stored, err := decodeStoreValue[storedGreetingConfig](value)
+		if err == nil {
+			return stored.config()
+		}
+		g.bot.error("ignoring greeting config of chat %d: %s", chatID, err)
+	}
+	return g.defaultConfig
+}
+
+// Delete deletes the configuration of given chat, so that the default configuration is used.
+func (g *Greetings) Delete(chatID int64) {
+	g.store.Delete(greetingConfigKey(chatID))
+}
+
+// key of chat configurations in the store
+func greetingConfigKey(chatID int64) string {
+	return fmt.Sprintf("greetings:%d", chatID)
+}
+
+// GreetingConfig in the store (as JSON)
+type storedGreetingConfig struct {
+	Welcome *storedGreetingMessage `json:"welcome,omitempty"`
+	Goodbye *storedGreetingMessage `json:"goodbye,omitempty"`
+}
+
+// GreetingMessage in the store
+type storedGreetingMessage struct {
+	Text       string            `json:"text"`
+	ParseMode  *ParseMode        `json:"parse_mode,omitempty"`
+	Media      *storedInputFile  `json:"media,omitempty"`
+	MediaType  GreetingMediaType `json:"media_type,omitempty"`
+	AutoDelete time.Duration     `json:"auto_delete,omitempty"`
+}
+
+// InputFile in the store (without `Reader`)
+type storedInputFile struct {
+	Filepath *string          `json:"filepath,omitempty"`
+	URL      *string          `json:"url,omitempty"`
+	Bytes    []byte           `json:"bytes,omitempty"`
+	FileID   *string          `json:"file_id,omitempty"`
+	Filename *string          `json:"filename,omitempty"`
+	Source   *storedInputFile `json:"source,omitempty"`
+}
+
+// convert GreetingConfig for the store
+func newStoredGreetingConfig(config GreetingConfig) (stored storedGreetingConfig, err error) {
+	if stored.Welcome, err = newStoredGreetingMessage(config.Welcome); err != nil {
+		return stored, err
+	}
+	stored.Goodbye, err = newStoredGreetingMessage(config.Goodbye)
+	return stored, err
+}
+
+// convert GreetingMessage for the store
+func newStoredGreetingMessage(message *GreetingMessage) (*storedGreetingMessage, error) {
+	if message == nil {
+		return nil, nil
+	}
+
+	media, err := newStoredInputFile(message.Media)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storedGreetingMessage{
+		Text:       message.Text,
+		ParseMode:  message.ParseMode,
+		Media:      media,
+		MediaType:  message.MediaType,
+		AutoDelete: message.AutoDelete,
+	}, nil
+}
+
+// convert InputFile for the store
+func newStoredInputFile(file *InputFile) (*storedInputFile, error) {
+	if file == nil {
+		return nil, nil
+	}
+	if file.Reader != nil {
+		return nil, fmt.Errorf("InputFile with a reader cannot be stored")
+	}
+
+	source, err := newStoredInputFile(file.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storedInputFile{
+		Filepath: file.Filepath,
+		URL:      file.URL,
+		Bytes:    file.Bytes,
+		FileID:   file.FileID,
+		Filename: file.Filename,
+		Source:   source,
+	}, nil
+}
+
+// convert back to GreetingConfig
+func (s storedGreetingConfig) config() GreetingConfig {
+	return GreetingConfig{
+		Welcome: s.Welcome.message(),
+		Goodbye: s.Goodbye.message(),
+	}
+}
+
+// convert back to GreetingMessage
+func (s *storedGreetingMessage) message() *GreetingMessage {
+	if s == nil {
+		return nil
+	}
+
+	return &GreetingMessage{
+		Text:       s.Text,
+		ParseMode:  s.ParseMode,
+		Media:      s.Media.inputFile(),
+		MediaType:  s.MediaType,
+		AutoDelete: s.AutoDelete,
+	}
+}
+
+// convert back to InputFile
+func (s *storedInputFile) inputFile() *InputFile {
+	if s == nil {
+		return nil
+	}
+
+	return &InputFile{
+		Filepath: s.Filepath,
+		URL:      s.URL,
+		Bytes:    s.Bytes,
+		FileID:   s.FileID,
+		Filename: s.Filename,
+		Source:   s.Source.inputFile(),
+	}
+}
+
+// handle an update for greetings
+func (g *Greetings) handle(update Update) error {
+	if message := update.Message; message != nil {
+		for _, user := range message.NewChatMembers {
+			if err := g.greet(message.Chat, user, true); err != nil {
+				return err
+			}
+		}
+		if message.LeftChatMember != nil {
+			return g.greet(message.Chat, *message.LeftChatMember, false)
+		}
+	}
+
+	if updated := update.ChatMember; updated != nil {
+		if updated.Joined() {
+			return g.greet(updated.Chat, updated.NewChatMember.User, true)
+		} else if updated.Left() {
+			return g.greet(updated.Chat, updated.NewChatMember.User, false)
+		}
+	}
+
+	return nil
+}
+
+// send a welcome (or goodbye) message to given member
+func (g *Greetings) greet(chat Chat, user User, joined bool) error {
+	if user.IsBot {
+		return nil
+	}
+
+	config := g.Get(chat.ID)
+	template := config.Goodbye
+	if joined {
+		template = config.Welcome
+	}
+	if template == nil {
+		return nil
+	}
+
+	// greet only once for both `new_chat_members` and `chat_member`
+	key := fmt.Sprintf("%d:%d:%t", chat.ID, user.ID, joined)
+	if _, exists := g.recent.Get(key); exists {
+		return nil
+	}
+	g.recent.Set(key, true, greetingDedupeTTL)
+
+	res := g.send(chat, template, template.render(chat, user))
+	if !res.Ok {
+		return fmt.Errorf("failed to send greeting to chat %d: %w", chat.ID, res.Err())
+	}
+
+	if template.AutoDelete > 0 {
+		messageID := res.Result.MessageID
+		time.AfterFunc(template.AutoDelete, func() {
+			if res := g.bot.DeleteMessage(chat.ID, messageID); !res.Ok {
+				g.bot.error("failed to delete greeting in chat %d: %s", chat.ID, res.Err())
+			}
+		})
+	}
+
+	return nil
+}
+
+// send a rendered greeting message with its media
+func (g *Greetings) send(chat Chat, template *GreetingMessage, text string) APIResponse[Message] {
+	if template.Media == nil {
+		options := OptionsSendMessage{}
+		if template.ParseMode != nil {
+			options.SetParseMode(*template.ParseMode)
+		}
+		return g.bot.SendMessage(chat.ID, text, options)
+	}
+
+	switch template.MediaType {
+	case GreetingMediaTypeVideo:
+		options := OptionsSendVideo{}.SetCaption(text)
+		if template.ParseMode != nil {
+			options.SetParseMode(*template.ParseMode)
+		}
+		return g.bot.SendVideo(chat.ID, *template.Media, options)
+	case GreetingMediaTypeAnimation:
+		options := OptionsSendAnimation{}.SetCaption(text)
+		if template.ParseMode != nil {
+			options.SetParseMode(*template.ParseMode)
+		}
+		return g.bot.SendAnimation(chat.ID, *template.Media, options)
+	default:
+		options := OptionsSendPhoto{}.SetCaption(text)
+		if template.ParseMode != nil {
+			options.SetParseMode(*template.ParseMode)
+		}
+		return g.bot.SendPhoto(chat.ID, *template.Media, options)
+	}
+}
+
+// replace placeholders of the template with escaped values
+func (m GreetingMessage) render(chat Chat, user User) string {
+	escape := func(str string) string { return str }
+	mention := func(name string) string { return name }
+	if m.ParseMode != nil {
+		switch *m.ParseMode {
+		case ParseModeHTML:
+			escape = html.EscapeString
+			mention = func(name string) string {
+				return fmt.Sprintf(`<a href="%s">%s</a>`, user.InlineLink(), html.EscapeString(name))
+			}
+		case ParseModeMarkdownV2:
+			escape = EscapeMarkdownV2
+			mention = func(name string) string {
+				return fmt.Sprintf("[%s](%s)", EscapeMarkdownV2(name), EscapeMarkdownV2URL(user.InlineLink()))
+			}
+		}
+	}
+
+	fullName := user.FirstName
+	lastName := ""
+	if user.LastName != nil && *user.LastName != "" {
+		lastName = *user.LastName
+		fullName += " " + lastName
+	}
+	username := ""
+	if user.Username != nil {
+		username = "@" + *user.Username
+	}
+	chatTitle := ""
+	if chat.Title != nil {
+		chatTitle = *chat.Title
+	}
+
+	return strings.NewReplacer(
+		"{first_name}", escape(user.FirstName),
+		"{last_name}", escape(lastName),
+		"{full_name}", escape(fullName),
+		"{username}", escape(username),
+		"{user_id}", strconv.FormatInt(user.ID, 10),
+		"{mention}", mention(fullName),
+		"{chat_title}", escape(chatTitle),
+	).Replace(m.Text)
+}
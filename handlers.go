@@ -0,0 +1,232 @@
+package telegrambot
+
+// Multiple update handlers, which receive every update in order.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Ctx is the context of an update, passed to handlers added with AddUpdateHandler().
+type Ctx struct {
+	context.Context
+
+	Bot    *Bot
+	Update Update
+
+	consumed bool
+}
+
+// Consume marks the update as consumed, so handlers added after the current one will not receive it.
+func (c *Ctx) Consume() {
+	c.consumed = true
+}
+
+// Consumed returns whether the update was marked as consumed or not.
+func (c *Ctx) Consumed() bool {
+	return c.consumed
+}
+
+// Handler is a function which handles an update.
+//
+// Returned error (or a recovered panic) is reported to the handler error hook, and does not affect other handlers.
+type Handler func(ctx *Ctx) error
+
+// a named handler
+type namedHandler struct {
+	name    string
+	handler Handler
+}
+
+// HandlerError is an error (or a recovered panic, or a timeout) from a handler
+type HandlerError struct {
+	Handler  string // name of the handler
+	UpdateID int64
+	Err      error  // context.DeadlineExceeded if the handler timed out
+	Panic    any    // recovered value, if the handler panicked
+	Stack    []byte // stack trace of the panic
+}
+
+// Error returns the description of this error.
+func (e *HandlerError) Error() string {
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return fmt.Sprintf("handler '%s' timed out while handling update %d", e.Handler, e.UpdateID)
+	}
+	if e.Panic != nil {
+		return fmt.Sprintf("handler '%s' panicked while handling update %d: %v", e.Handler, e.UpdateID, e.Panic)
+	}
+	return fmt.Sprintf("handler '%s' failed to handle update %d: %s", e.Handler, e.UpdateID, e.Err)
+}
+
+// Unwrap returns the error returned from the handler.
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// handlers added with AddUpdateHandler()
+type updateHandlers struct {
+	handlers  []namedHandler
+	errorHook func(b *Bot, err *HandlerError)
+
+	lock sync.RWMutex
+}
+
+// AddUpdateHandler adds a handler which receives every update (from webhook, polling, or update sources).
+//
+// Handlers are called sequentially in the order they were added, and the handler given to
+// StartWebhookServerAndWait() or StartMonitoringUpdates*() (can be nil when handlers are added) is called last.
+//
+// Each handler is isolated from the others: its error or panic is reported to the handler error hook
+// (see SetHandlerErrorHook), and the next handler is called anyway.
+// A handler can stop the propagation of an update with Ctx.Consume().
+//
+//	client.AddUpdateHandler("analytics", func(ctx *Ctx) error {
+//		return analytics.Track(ctx.Update)
+//	})
+//	client.AddUpdateHandler("moderation", func(ctx *Ctx) error {
+//		if isSpam(ctx.Update) {
+//			ctx.Consume() // not to be handled by the main logic
+//		}
+//		return nil
+//	})
+func (b *Bot) AddUpdateHandler(name string, handler Handler) {
+	b.handlers.lock.Lock()
+	defer b.handlers.lock.Unlock()
+
+	b.handlers.handlers = append(b.handlers.handlers, namedHandler{name: name, handler: handler})
+}
+
+// SetHandlerErrorHook sets the hook for errors and panics of handlers added with AddUpdateHandler().
+//
+// Errors are logged with the default hook (nil).
+func (b *Bot) SetHandlerErrorHook(hook func(b *Bot, err *HandlerError)) {
+	b.handlers.lock.Lock()
+	defer b.handlers.lock.Unlock()
+
+	b.handlers.errorHook = hook
+}
+
+// check if any handler was added with AddUpdateHandler()
+func (b *Bot) hasUpdateHandlers() bool {
+	b.handlers.lock.RLock()
+	defer b.handlers.lock.RUnlock()
+
+	return len(b.handlers.handlers) > 0
+}
+
+// call added handlers with given update in order, and return whether it was consumed (or timed out) or not
+func (b *Bot) dispatchToHandlers(ctx context.Context, update Update, progress *handlerProgress) (consumed bool) {
+	b.handlers.lock.RLock()
+	handlers := b.handlers.handlers
+	b.handlers.lock.RUnlock()
+
+	c := &Ctx{
+		Context: ctx,
+		Bot:     b,
+		Update:  update,
+	}
+
+	for _, handler := range handlers {
+		progress.set(handler.name)
+
+		if err := b.callHandler(c, handler); err != nil {
+			// NOTE: errors after the deadline are not reported, as the timeout was already reported
+			if ctx.Err() == nil || err.Panic != nil {
+				b.reportHandlerError(err)
+			}
+		}
+
+		if c.consumed {
+			b.verbose("update %d consumed by handler: %s", update.UpdateID, handler.name)
+			return true
+		}
+		if ctx.Err() != nil {
+			return true // timed out (already reported)
+		}
+	}
+
+	return false
+}
+
+// name of the handler for the update handler given to StartWebhookServerAndWait() or StartMonitoringUpdates*()
+const defaultHandlerName = "default"
+
+// name of the handler which is currently handling an update
+type handlerProgress struct {
+	name string
+	lock sync.Mutex
+}
+
+// set the name of the current handler
+func (p *handlerProgress) set(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.name = name
+}
+
+// name of the current handler
+func (p *handlerProgress) current() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.name
+}
+
+// SetUpdateTimeout sets the deadline for handling each update. (0 for no deadline)
+//
+// When handlers take longer than `timeout`, Ctx of handlers is cancelled, the timeout is reported
+// to the handler error hook (see SetHandlerErrorHook), and the worker is freed for other updates.
+// Remaining handlers are not called for the update.
+//
+// NOTE: Handlers should watch Ctx.Done() (or pass Ctx to their I/O) to stop as soon as possible,
+// because they cannot be stopped forcibly.
+func (b *Bot) SetUpdateTimeout(timeout time.Duration) {
+	b.updateTimeout = timeout
+}
+
+// call a handler, recovering from its panic
+func (b *Bot) callHandler(ctx *Ctx, handler namedHandler) (err *HandlerError) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if b.metrics != nil {
+				b.metrics.observePanic(handler.name)
+			}
+
+			err = &HandlerError{
+				Handler:  handler.name,
+				UpdateID: ctx.Update.UpdateID,
+				Panic:    recovered,
+				Stack:    debug.Stack(),
+			}
+		}
+	}()
+
+	if e := handler.handler(ctx); e != nil {
+		return &HandlerError{
+			Handler:  handler.name,
+			UpdateID: ctx.Update.UpdateID,
+			Err:      e,
+		}
+	}
+	return nil
+}
+
+// report an error of a handler to the hook (or log it)
+func (b *Bot) reportHandlerError(err *HandlerError) {
+	b.handlers.lock.RLock()
+	hook := b.handlers.errorHook
+	b.handlers.lock.RUnlock()
+
+	if hook != nil {
+		hook(b, err)
+	} else if err.Panic != nil {
+		b.error("%s\n%s", err, err.Stack)
+	} else {
+		b.error("%s", err)
+	}
+}
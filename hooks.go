@@ -0,0 +1,136 @@
+package telegrambot
+
+// Hooks called before and after each API request, for logging, metrics, or auditing.
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RequestEvent is a completed API request, passed to AfterResponseHooks
+type RequestEvent struct {
+	Method     string
+	Params     map[string]string // params of the request, with files elided
+	Started    time.Time
+	Duration   time.Duration // including retries
+	StatusCode int           // http status code of the response (0 if the request itself failed)
+
+	Ok          bool
+	ErrorCode   int    // `error_code` of the response (0 if ok, or the request itself failed)
+	Description string // `description` of the response (or the error of the request)
+	Err         error  // error of the request itself (eg. network errors)
+}
+
+// BeforeRequestHook is a function which is called before each API request,
+// with its method name and params. (files elided)
+type BeforeRequestHook func(method string, params map[string]string)
+
+// AfterResponseHook is a function which is called after each API request completes.
+type AfterResponseHook func(event RequestEvent)
+
+// hooks added with AddBeforeRequestHook() and AddAfterResponseHook()
+type requestHooks struct {
+	before []BeforeRequestHook
+	after  []AfterResponseHook
+
+	lock sync.RWMutex
+}
+
+// AddBeforeRequestHook adds a hook which is called before each API request. (in added order)
+//
+// Requests which fail the payload validation (see SetPayloadLimits()) are not sent, so hooks are not called for them.
+//
+// NOTE: Hooks are called synchronously on each API call, so they should return quickly.
+func (b *Bot) AddBeforeRequestHook(hook BeforeRequestHook) {
+	b.hooks.lock.Lock()
+	defer b.hooks.lock.Unlock()
+
+	b.hooks.before = append(b.hooks.before, hook)
+}
+
+// AddAfterResponseHook adds a hook which is called after each API request completes. (in added order)
+//
+//	client.AddAfterResponseHook(func(event RequestEvent) {
+//		if !event.Ok {
+//			log.Printf("%s failed in %s: %s", event.Method, event.Duration, event.Description)
+//		}
+//	})
+//
+// NOTE: Hooks are called synchronously on each API call, so they should return quickly.
+func (b *Bot) AddAfterResponseHook(hook AfterResponseHook) {
+	b.hooks.lock.Lock()
+	defer b.hooks.lock.Unlock()
+
+	b.hooks.after = append(b.hooks.after, hook)
+}
+
+// whether any hook was added or not
+func (b *Bot) hasRequestHooks() bool {
+	b.hooks.lock.RLock()
+	defer b.hooks.lock.RUnlock()
+
+	return len(b.hooks.before) > 0 || len(b.hooks.after) > 0
+}
+
+// call hooks added with AddBeforeRequestHook()
+func (b *Bot) callBeforeRequestHooks(method string, params map[string]string) {
+	b.hooks.lock.RLock()
+	hooks := b.hooks.before
+	b.hooks.lock.RUnlock()
+
+	for _, hook := range hooks {
+		hook(method, params)
+	}
+}
+
+// call hooks added with AddAfterResponseHook()
+func (b *Bot) callAfterResponseHooks(method string, params map[string]string, started time.Time, resp []byte, err error) {
+	b.hooks.lock.RLock()
+	hooks := b.hooks.after
+	b.hooks.lock.RUnlock()
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	event := RequestEvent{
+		Method:   method,
+		Params:   params,
+		Started:  started,
+		Duration: time.Since(started),
+		Err:      err,
+	}
+	event.Ok, event.ErrorCode, event.Description = b.responseSummary(resp, err)
+	if err == nil {
+		event.StatusCode = 200
+		if event.ErrorCode != 0 {
+			event.StatusCode = event.ErrorCode // NOTE: `error_code` is the http status code (see Bot.checkHTTPStatus())
+		}
+	}
+
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+// `ok`, `error_code`, and `description` of given response (or the error of the request)
+func (b *Bot) responseSummary(resp []byte, err error) (ok bool, errorCode int, description string) {
+	if err != nil {
+		return false, 0, b.redact(err.Error())
+	}
+
+	var result struct {
+		Ok          bool    `json:"ok"`
+		ErrorCode   int     `json:"error_code,omitempty"`
+		Description *string `json:"description,omitempty"`
+	}
+	if err := b.jsonCodec().Unmarshal(resp, &result); err != nil {
+		return false, 0, fmt.Sprintf("json parse error: %s", err)
+	}
+
+	if result.Description != nil {
+		description = *result.Description
+	}
+	return result.Ok, result.ErrorCode, description
+}
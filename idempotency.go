@@ -0,0 +1,115 @@
+package telegrambot
+
+// Suppressing duplicated sends of messages with client-side idempotency keys.
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// library-local option key for idempotency keys (not sent to the API)
+	idempotencyKeyKey = "__idempotency_key"
+
+	// default window in which sends with the same idempotency key are suppressed
+	defaultIdempotencyWindow = 1 * time.Hour
+
+	// description of failed responses for suppressed sends
+	idempotencyDuplicateDescription = "duplicate send suppressed"
+)
+
+// record of a send with an idempotency key (stored as JSON)
+type idempotentSend struct {
+	Message *Message `json:"message,omitempty"` // sent message (nil if the result is unknown yet, or the request failed without a response)
+}
+
+// SetIdempotencyStore sets the store of idempotency keys, and the window in which sends with the same key are suppressed.
+//
+// Share an AtomicCacheStore (eg. with Redis) between processes for suppressing duplicates across them.
+// With stores which do not implement AtomicCacheStore, duplicates are suppressed only within this process.
+//
+// If `store` is nil, a new MemoryCacheStore will be used. (default window: 1 hour)
+func (b *Bot) SetIdempotencyStore(store CacheStore, window time.Duration) {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+	if window <= 0 {
+		window = defaultIdempotencyWindow
+	}
+
+	b.idempotencyStore = store
+	b.idempotencyWindow = window
+}
+
+// IsDuplicateSend checks if given error means that a send was suppressed because of its idempotency key,
+// and the result of the previous send with the key is unknown. (eg. it timed out)
+func IsDuplicateSend(err error) bool {
+	return isAPIError(err, 0, idempotencyDuplicateDescription)
+}
+
+// send a message, suppressing it if a message with the same idempotency key was sent already
+//
+// A key is recorded before sending, and:
+//   - when the message is sent, the message is returned for subsequent sends with the key,
+//   - when Telegram rejects the message, the key is removed so that it can be retried,
+//   - when the result is unknown (eg. a network timeout), subsequent sends with the key fail with IsDuplicateSend().
+//
+// (not ok if `params` has no idempotency key)
+func (b *Bot) requestMessageIdempotently(method string, params map[string]any) (result APIResponse[Message], ok bool) {
+	key, exists := params[idempotencyKeyKey].(string)
+	delete(params, idempotencyKeyKey)
+	if !exists || key == "" {
+		return result, false
+	}
+	storeKey := "idempotency:" + key
+
+	if !b.claimIdempotencyKey(storeKey) {
+		if value, exists := b.idempotencyStore.Get(storeKey); exists {
+			if sent, err := decodeStoreValue[idempotentSend](value); err == nil && sent.Message != nil {
+				b.verbose("suppressed duplicate send with idempotency key '%s'", key)
+
+				return APIResponse[Message]{Ok: true, Result: sent.Message}, true
+			}
+		}
+
+		errStr := fmt.Sprintf("%s: result of the previous %s with idempotency key '%s' is unknown", idempotencyDuplicateDescription, method, key)
+
+		b.error(errStr)
+
+		return APIResponse[Message]{Ok: false, Description: &errStr}, true
+	}
+
+	result = b.requestMessage(method, params)
+
+	if result.Ok && result.Result != nil {
+		if encoded, err := encodeStoreValue(idempotentSend{Message: result.Result}); err == nil {
+			b.idempotencyStore.Set(storeKey, encoded, b.idempotencyWindow)
+		} else {
+			b.error("failed to encode the result of send with idempotency key '%s': %s", key, err)
+		}
+	} else if result.ErrorCode != 0 || IsValidationError(result.Err()) { // rejected by Telegram (or not sent at all), so it is safe to retry
+		b.idempotencyStore.Delete(storeKey)
+	}
+
+	return result, true
+}
+
+// record given key of an idempotency store as being sent, and return false if it was recorded already
+//
+// With an AtomicCacheStore, the key is claimed atomically across processes.
+func (b *Bot) claimIdempotencyKey(storeKey string) (claimed bool) {
+	pending, _ := encodeStoreValue(idempotentSend{})
+
+	if store, ok := b.idempotencyStore.(AtomicCacheStore); ok {
+		return store.SetIfNotExists(storeKey, pending, b.idempotencyWindow)
+	}
+
+	b.idempotencyLock.Lock()
+	defer b.idempotencyLock.Unlock()
+
+	if _, exists := b.idempotencyStore.Get(storeKey); exists {
+		return false
+	}
+	b.idempotencyStore.Set(storeKey, pending, b.idempotencyWindow)
+	return true
+}
@@ -0,0 +1,102 @@
+package telegrambot
+
+// Deterministic ids of inline query results, derived from their contents.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// length of ids generated from contents (in hex characters, up to 64 bytes are allowed by the API)
+const inlineQueryResultIDLength = 32
+
+// InlineQueryResultID returns a deterministic id of an inline query result, which is a hash of its contents.
+//
+// The result's current id is ignored, so the same contents always produce the same id.
+func InlineQueryResultID(result any) (id string, err error) {
+	value, header, err := inlineQueryResultHeader(result)
+	if err != nil {
+		return "", err
+	}
+	field := header.FieldByName("ID")
+	original := field.String()
+	field.SetString("")
+
+	bytes, err := json.Marshal(value.Interface())
+	field.SetString(original)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal inline query result: %w", err)
+	}
+	hash := sha256.Sum256(bytes)
+
+	return hex.EncodeToString(hash[:])[:inlineQueryResultIDLength], nil
+}
+
+// StableInlineQueryResults sets deterministic ids (see InlineQueryResultID()) to inline query results,
+// and removes duplicated results (with the same contents) from them, keeping their order.
+//
+// Results given as pointers (eg. from NewInlineQueryResultArticle()) are updated in place.
+// With stable ids, results cached by Telegram (`cache_time`) and results on other pages (`next_offset`)
+// are identified consistently across repeated queries.
+//
+//	article, _ := NewInlineQueryResultArticle(title, text, description)
+//	results, err := StableInlineQueryResults([]any{article, ...})
+//	if err == nil {
+//		bot.AnswerInlineQuery(query.ID, results, nil)
+//	}
+func StableInlineQueryResults(results []any) (stable []any, err error) {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		if ids[i], err = InlineQueryResultID(result); err != nil {
+			return nil, fmt.Errorf("result #%d: %w", i, err)
+		}
+	}
+
+	stable = []any{}
+
+	added := map[string]bool{}
+	for i, result := range results {
+		id := ids[i]
+		if added[id] {
+			continue
+		}
+		added[id] = true
+
+		value, header, _ := inlineQueryResultHeader(result)
+		header.FieldByName("ID").SetString(id)
+
+		stable = append(stable, value.Interface())
+	}
+
+	return stable, nil
+}
+
+// settable copy (or pointee) of an inline query result, and its embedded InlineQueryResult
+func inlineQueryResultHeader(result any) (value, header reflect.Value, err error) {
+	value = reflect.ValueOf(result)
+
+	elem := value
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		elem = value.Elem()
+	} else if value.Kind() == reflect.Struct {
+		// copy for updating its fields
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		value, elem = copied, copied
+	}
+
+	if elem.Kind() != reflect.Struct {
+		return value, header, fmt.Errorf("not an inline query result: %T", result)
+	}
+	if elem.Type() == reflect.TypeOf(InlineQueryResult{}) {
+		return value, elem, nil
+	}
+	if header = elem.FieldByName("InlineQueryResult"); !header.IsValid() || header.Type() != reflect.TypeOf(InlineQueryResult{}) {
+		return value, header, fmt.Errorf("not an inline query result: %T", result)
+	}
+
+	return value, header, nil
+}
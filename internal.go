@@ -0,0 +1,148 @@
+package telegrambot
+
+// Internal http server for profiling and runtime stats, separated from the webhook server.
+
+import (
+	"encoding/json"
+	"expvar"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	"time"
+)
+
+const (
+	// environment variable for the address of the internal server (eg. "127.0.0.1:6060")
+	envInternalServerAddr = "TELEGRAM_BOT_INTERNAL_ADDR"
+)
+
+// time when this package was loaded
+var _startedAt = time.Now()
+
+// RuntimeStats is a struct of basic runtime stats
+type RuntimeStats struct {
+	GoVersion     string  `json:"go_version"`
+	NumCPU        int     `json:"num_cpu"`
+	NumGoroutine  int     `json:"num_goroutine"`
+	UptimeSeconds float64 `json:"uptime_seconds"`
+
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64 `json:"heap_inuse_bytes"`
+	HeapObjects    uint64 `json:"heap_objects"`
+	SysBytes       uint64 `json:"sys_bytes"`
+	TotalAlloc     uint64 `json:"total_alloc_bytes"`
+	NumGC          uint32 `json:"num_gc"`
+	PauseTotalNs   uint64 `json:"gc_pause_total_ns"`
+}
+
+// GetRuntimeStats returns basic runtime stats of the current process.
+func GetRuntimeStats() RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return RuntimeStats{
+		GoVersion:     runtime.Version(),
+		NumCPU:        runtime.NumCPU(),
+		NumGoroutine:  runtime.NumGoroutine(),
+		UptimeSeconds: time.Since(_startedAt).Seconds(),
+
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapInuseBytes: mem.HeapInuse,
+		HeapObjects:    mem.HeapObjects,
+		SysBytes:       mem.Sys,
+		TotalAlloc:     mem.TotalAlloc,
+		NumGC:          mem.NumGC,
+		PauseTotalNs:   mem.PauseTotalNs,
+	}
+}
+
+// InternalServeMux returns the ServeMux of the internal server, for adding more handlers to it.
+//
+// It serves:
+//
+//	/debug/pprof/   (net/http/pprof)
+//	/debug/vars     (expvar)
+//	/debug/runtime  (RuntimeStats in JSON)
+func (b *Bot) InternalServeMux() *http.ServeMux {
+	b.internalLock.Lock()
+	defer b.internalLock.Unlock()
+
+	if b.internalMux == nil {
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		mux.Handle("/debug/vars", expvar.Handler())
+		mux.HandleFunc("/debug/runtime", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(GetRuntimeStats())
+		})
+
+		b.internalMux = mux
+	}
+
+	return b.internalMux
+}
+
+// StartInternalServer starts the internal server on given address in background.
+//
+// NOTE: Endpoints of the internal server expose sensitive information (eg. command line, memory profiles),
+// so `addr` should not be reachable from outside. (eg. "127.0.0.1:6060")
+func (b *Bot) StartInternalServer(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           b.InternalServeMux(),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		// NOTE: no WriteTimeout for long-running profiles and traces
+	}
+
+	b.internalLock.Lock()
+	b.internalServer = server
+	b.internalLock.Unlock()
+
+	b.verbose("starting internal server on: %s ...", listener.Addr())
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			b.error("internal server stopped with error: %s", err)
+		}
+	}()
+
+	return nil
+}
+
+// StopInternalServer stops the internal server.
+func (b *Bot) StopInternalServer() error {
+	b.internalLock.Lock()
+	server := b.internalServer
+	b.internalServer = nil
+	b.internalLock.Unlock()
+
+	if server != nil {
+		return server.Close()
+	}
+	return nil
+}
+
+// start the internal server if its address is given with the environment variable
+func (b *Bot) startInternalServerFromEnv() {
+	b.internalLock.Lock()
+	running := b.internalServer != nil
+	b.internalLock.Unlock()
+
+	if addr := os.Getenv(envInternalServerAddr); addr != "" && !running {
+		if err := b.StartInternalServer(addr); err != nil {
+			b.error("failed to start internal server on %s: %s", addr, err)
+		}
+	}
+}
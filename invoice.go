@@ -0,0 +1,268 @@
+package telegrambot
+
+// Building invoices for SendInvoice() and CreateInvoiceLink() from one definition, with validation.
+//
+// https://core.telegram.org/bots/payments
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// CurrencyTelegramStars is the currency code of Telegram Stars
+	//
+	// https://core.telegram.org/bots/payments-stars
+	CurrencyTelegramStars = "XTR"
+
+	// StarsSubscriptionPeriod is the only allowed `subscription_period` (in seconds) of Telegram Stars subscriptions
+	StarsSubscriptionPeriod = 30 * 24 * 60 * 60
+
+	maxSuggestedTipAmounts = 4
+)
+
+// InvoiceBuilder is a builder of invoices, which can be sent with SendInvoiceWithBuilder()
+// or turned into a link with CreateInvoiceLinkWithBuilder().
+//
+//	invoice := NewInvoiceBuilder("Premium", "1 month of premium features", "premium-1m", CurrencyTelegramStars).
+//		AddPrice("Premium", 100).
+//		SetSubscriptionPeriod(StarsSubscriptionPeriod)
+//	link := bot.CreateInvoiceLinkWithBuilder(invoice, nil)
+type InvoiceBuilder struct {
+	title         string
+	description   string
+	payload       string
+	providerToken string
+	currency      string
+	prices        []LabeledPrice
+
+	subscriptionPeriod  int
+	maxTipAmount        int
+	suggestedTipAmounts []int
+
+	options map[string]any // other options common to sendInvoice and createInvoiceLink
+}
+
+// NewInvoiceBuilder returns a new InvoiceBuilder.
+//
+// `currency` is a three-letter ISO 4217 currency code, or CurrencyTelegramStars.
+func NewInvoiceBuilder(title, description, payload, currency string) *InvoiceBuilder {
+	return &InvoiceBuilder{
+		title:       title,
+		description: description,
+		payload:     payload,
+		currency:    currency,
+		options:     map[string]any{},
+	}
+}
+
+// SetProviderToken sets the payment provider token. (should be empty for Telegram Stars)
+func (i *InvoiceBuilder) SetProviderToken(providerToken string) *InvoiceBuilder {
+	i.providerToken = providerToken
+	return i
+}
+
+// AddPrice adds a price portion. (`amount` is in the smallest units of the currency, eg. cents)
+func (i *InvoiceBuilder) AddPrice(label string, amount int) *InvoiceBuilder {
+	i.prices = append(i.prices, LabeledPrice{Label: label, Amount: amount})
+	return i
+}
+
+// SetSubscriptionPeriod sets the subscription period in seconds. (Telegram Stars and CreateInvoiceLink only)
+func (i *InvoiceBuilder) SetSubscriptionPeriod(seconds int) *InvoiceBuilder {
+	i.subscriptionPeriod = seconds
+	return i
+}
+
+// SetTips sets the maximum tip amount and up to 4 suggested tip amounts. (not for Telegram Stars)
+func (i *InvoiceBuilder) SetTips(maxTipAmount int, suggestedTipAmounts ...int) *InvoiceBuilder {
+	i.maxTipAmount = maxTipAmount
+	i.suggestedTipAmounts = suggestedTipAmounts
+	return i
+}
+
+// SetProviderData sets the JSON-serialized data for the payment provider.
+func (i *InvoiceBuilder) SetProviderData(providerData string) *InvoiceBuilder {
+	i.options["provider_data"] = providerData
+	return i
+}
+
+// SetPhoto sets the photo of the invoice. (`size`, `width`, and `height` are optional with 0)
+func (i *InvoiceBuilder) SetPhoto(photoURL string, size, width, height int) *InvoiceBuilder {
+	i.options["photo_url"] = photoURL
+	for key, value := range map[string]int{"photo_size": size, "photo_width": width, "photo_height": height} {
+		if value > 0 {
+			i.options[key] = value
+		}
+	}
+	return i
+}
+
+// SetNeeds sets which information of the user is needed for completing the order.
+func (i *InvoiceBuilder) SetNeeds(name, phoneNumber, email, shippingAddress bool) *InvoiceBuilder {
+	i.options["need_name"] = name
+	i.options["need_phone_number"] = phoneNumber
+	i.options["need_email"] = email
+	i.options["need_shipping_address"] = shippingAddress
+	return i
+}
+
+// SetSendToProvider sets whether the user's phone number and email should be sent to the provider.
+func (i *InvoiceBuilder) SetSendToProvider(phoneNumber, email bool) *InvoiceBuilder {
+	i.options["send_phone_number_to_provider"] = phoneNumber
+	i.options["send_email_to_provider"] = email
+	return i
+}
+
+// SetIsFlexible sets whether the final price depends on the shipping method.
+func (i *InvoiceBuilder) SetIsFlexible(isFlexible bool) *InvoiceBuilder {
+	i.options["is_flexible"] = isFlexible
+	return i
+}
+
+// Validate checks the invoice against the constraints of the Bot API.
+func (i *InvoiceBuilder) Validate() error {
+	if length := utf8.RuneCountInString(i.title); length < 1 || length > 32 {
+		return fmt.Errorf("title should be 1-32 characters long: %d", length)
+	}
+	if length := utf8.RuneCountInString(i.description); length < 1 || length > 255 {
+		return fmt.Errorf("description should be 1-255 characters long: %d", length)
+	}
+	if length := len(i.payload); length < 1 || length > 128 {
+		return fmt.Errorf("payload should be 1-128 bytes long: %d", length)
+	}
+	if !isCurrencyCode(i.currency) {
+		return fmt.Errorf("invalid currency code: '%s'", i.currency)
+	}
+
+	// prices
+	if len(i.prices) == 0 {
+		return fmt.Errorf("no price was given")
+	}
+	total := 0
+	for _, price := range i.prices {
+		total += price.Amount
+	}
+	if total <= 0 {
+		return fmt.Errorf("total price should be positive: %d", total)
+	}
+
+	if i.currency == CurrencyTelegramStars {
+		if i.providerToken != "" {
+			return fmt.Errorf("provider token should be empty for Telegram Stars")
+		}
+		if len(i.prices) != 1 {
+			return fmt.Errorf("exactly one price should be given for Telegram Stars: %d", len(i.prices))
+		}
+		if i.maxTipAmount > 0 || len(i.suggestedTipAmounts) > 0 {
+			return fmt.Errorf("tips are not supported for Telegram Stars")
+		}
+	} else {
+		if i.providerToken == "" {
+			return fmt.Errorf("provider token is needed for currency: %s", i.currency)
+		}
+		if i.subscriptionPeriod != 0 {
+			return fmt.Errorf("subscriptions are supported for Telegram Stars only")
+		}
+	}
+
+	// subscription
+	if i.subscriptionPeriod != 0 && i.subscriptionPeriod != StarsSubscriptionPeriod {
+		return fmt.Errorf("subscription period should be %d seconds: %d", StarsSubscriptionPeriod, i.subscriptionPeriod)
+	}
+
+	// tips
+	if len(i.suggestedTipAmounts) > 0 {
+		if i.maxTipAmount <= 0 {
+			return fmt.Errorf("max tip amount is needed for suggested tip amounts")
+		}
+		if len(i.suggestedTipAmounts) > maxSuggestedTipAmounts {
+			return fmt.Errorf("at most %d suggested tip amounts can be given: %d", maxSuggestedTipAmounts, len(i.suggestedTipAmounts))
+		}
+		for idx, amount := range i.suggestedTipAmounts {
+			if amount <= 0 || amount > i.maxTipAmount {
+				return fmt.Errorf("suggested tip amount should be positive and not exceed max tip amount (%d): %d", i.maxTipAmount, amount)
+			}
+			if idx > 0 && amount <= i.suggestedTipAmounts[idx-1] {
+				return fmt.Errorf("suggested tip amounts should be in strictly increasing order")
+			}
+		}
+	}
+
+	return nil
+}
+
+// check if given string looks like a three-letter currency code
+func isCurrencyCode(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// fill given options with the values of the invoice
+func (i *InvoiceBuilder) fillOptions(options map[string]any) {
+	for key, value := range i.options {
+		if _, exists := options[key]; !exists {
+			options[key] = value
+		}
+	}
+	if i.maxTipAmount > 0 {
+		options["max_tip_amount"] = i.maxTipAmount
+	}
+	if len(i.suggestedTipAmounts) > 0 {
+		options["suggested_tip_amounts"] = i.suggestedTipAmounts
+	}
+}
+
+// SendInvoiceWithBuilder sends an invoice built with InvoiceBuilder, after validating it.
+//
+// `options` are for message options (eg. `reply_markup`), which are not a part of the invoice.
+func (b *Bot) SendInvoiceWithBuilder(chatID ChatID, invoice *InvoiceBuilder, options OptionsSendInvoice) (result APIResponse[Message]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	if err := invoice.Validate(); err != nil {
+		return invalidInvoiceResponse[Message](b, err)
+	}
+	if invoice.subscriptionPeriod != 0 {
+		return invalidInvoiceResponse[Message](b, fmt.Errorf("subscriptions are supported with invoice links only"))
+	}
+
+	invoice.fillOptions(options)
+
+	return b.SendInvoice(chatID, invoice.title, invoice.description, invoice.payload, invoice.providerToken, invoice.currency, invoice.prices, options)
+}
+
+// CreateInvoiceLinkWithBuilder creates a link for an invoice built with InvoiceBuilder, after validating it.
+func (b *Bot) CreateInvoiceLinkWithBuilder(invoice *InvoiceBuilder, options OptionsCreateInvoiceLink) (result APIResponse[string]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	if err := invoice.Validate(); err != nil {
+		return invalidInvoiceResponse[string](b, err)
+	}
+
+	invoice.fillOptions(options)
+	if invoice.subscriptionPeriod != 0 {
+		options["subscription_period"] = invoice.subscriptionPeriod
+	}
+
+	return b.CreateInvoiceLink(invoice.title, invoice.description, invoice.payload, invoice.providerToken, invoice.currency, invoice.prices, options)
+}
+
+// failed response for an invalid invoice
+func invalidInvoiceResponse[T any](b *Bot, err error) APIResponse[T] {
+	errStr := fmt.Sprintf("invalid invoice: %s", err)
+
+	b.error(errStr)
+
+	return APIResponse[T]{Ok: false, Description: &errStr}
+}
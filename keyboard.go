@@ -0,0 +1,162 @@
+package telegrambot
+
+// Building reply keyboards with rows of buttons, including buttons which request users or chats.
+
+// ReplyKeyboardBuilder is a builder of ReplyKeyboardMarkup.
+//
+// Buttons are added to the current row, and Row() starts a new one.
+// For removing the keyboard, send NewReplyKeyboardRemove() instead.
+//
+//	keyboard := NewReplyKeyboardBuilder().
+//		AddButtons("Yes", "No").
+//		Row().
+//		AddRequestUsers("Share friends", KeyboardButtonRequestUsers{MaxQuantity: 3, RequestName: true}).
+//		AddRequestChat("Share a group", KeyboardButtonRequestChat{ChatIsChannel: false}).
+//		SetResizeKeyboard(true).
+//		SetOneTimeKeyboard(true).
+//		Build()
+//	bot.SendMessage(chatID, "Choose:", OptionsSendMessage{}.SetReplyMarkup(keyboard))
+type ReplyKeyboardBuilder struct {
+	rows [][]KeyboardButton
+
+	isPersistent          bool
+	resizeKeyboard        bool
+	oneTimeKeyboard       bool
+	inputFieldPlaceholder *string
+	selective             bool
+
+	lastRequestID int64 // last `request_id` of request_users / request_chat buttons
+}
+
+// NewReplyKeyboardBuilder returns a new ReplyKeyboardBuilder.
+func NewReplyKeyboardBuilder() *ReplyKeyboardBuilder {
+	return &ReplyKeyboardBuilder{
+		rows: [][]KeyboardButton{{}},
+	}
+}
+
+// AddButton adds a button to the current row.
+func (k *ReplyKeyboardBuilder) AddButton(button KeyboardButton) *ReplyKeyboardBuilder {
+	last := len(k.rows) - 1
+	k.rows[last] = append(k.rows[last], button)
+	return k
+}
+
+// AddButtons adds text buttons to the current row.
+func (k *ReplyKeyboardBuilder) AddButtons(texts ...string) *ReplyKeyboardBuilder {
+	for _, button := range NewKeyboardButtons(texts...) {
+		k.AddButton(button)
+	}
+	return k
+}
+
+// AddRequestUsers adds a button which requests users to the current row.
+//
+// When `request.RequestID` is 0, a unique one in this keyboard is assigned.
+func (k *ReplyKeyboardBuilder) AddRequestUsers(text string, request KeyboardButtonRequestUsers) *ReplyKeyboardBuilder {
+	request.RequestID = k.requestID(request.RequestID)
+	return k.AddButton(KeyboardButton{Text: text, RequestUsers: &request})
+}
+
+// AddRequestChat adds a button which requests a chat to the current row.
+//
+// When `request.RequestID` is 0, a unique one in this keyboard is assigned.
+func (k *ReplyKeyboardBuilder) AddRequestChat(text string, request KeyboardButtonRequestChat) *ReplyKeyboardBuilder {
+	request.RequestID = k.requestID(request.RequestID)
+	return k.AddButton(KeyboardButton{Text: text, RequestChat: &request})
+}
+
+// AddRequestContact adds a button which requests the user's phone number to the current row.
+func (k *ReplyKeyboardBuilder) AddRequestContact(text string) *ReplyKeyboardBuilder {
+	return k.AddButton(KeyboardButton{Text: text, RequestContact: true})
+}
+
+// AddRequestLocation adds a button which requests the user's location to the current row.
+func (k *ReplyKeyboardBuilder) AddRequestLocation(text string) *ReplyKeyboardBuilder {
+	return k.AddButton(KeyboardButton{Text: text, RequestLocation: true})
+}
+
+// AddRequestPoll adds a button which requests a poll to the current row.
+//
+// `pollType` is "quiz", "regular", or "" for any type.
+func (k *ReplyKeyboardBuilder) AddRequestPoll(text, pollType string) *ReplyKeyboardBuilder {
+	poll := KeyboardButtonPollType{}
+	if pollType != "" {
+		poll.Type = &pollType
+	}
+	return k.AddButton(KeyboardButton{Text: text, RequestPoll: &poll})
+}
+
+// AddWebApp adds a button which launches a web app to the current row.
+func (k *ReplyKeyboardBuilder) AddWebApp(text, url string) *ReplyKeyboardBuilder {
+	return k.AddButton(KeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}})
+}
+
+// Row starts a new row of buttons. (does nothing when the current row is empty)
+func (k *ReplyKeyboardBuilder) Row() *ReplyKeyboardBuilder {
+	if len(k.rows[len(k.rows)-1]) > 0 {
+		k.rows = append(k.rows, []KeyboardButton{})
+	}
+	return k
+}
+
+// SetIsPersistent sets the `is_persistent` value of the keyboard.
+func (k *ReplyKeyboardBuilder) SetIsPersistent(isPersistent bool) *ReplyKeyboardBuilder {
+	k.isPersistent = isPersistent
+	return k
+}
+
+// SetResizeKeyboard sets the `resize_keyboard` value of the keyboard.
+func (k *ReplyKeyboardBuilder) SetResizeKeyboard(resizeKeyboard bool) *ReplyKeyboardBuilder {
+	k.resizeKeyboard = resizeKeyboard
+	return k
+}
+
+// SetOneTimeKeyboard sets the `one_time_keyboard` value of the keyboard.
+func (k *ReplyKeyboardBuilder) SetOneTimeKeyboard(oneTimeKeyboard bool) *ReplyKeyboardBuilder {
+	k.oneTimeKeyboard = oneTimeKeyboard
+	return k
+}
+
+// SetInputFieldPlaceholder sets the `input_field_placeholder` value of the keyboard. (1-64 characters)
+func (k *ReplyKeyboardBuilder) SetInputFieldPlaceholder(placeholder string) *ReplyKeyboardBuilder {
+	k.inputFieldPlaceholder = &placeholder
+	return k
+}
+
+// SetSelective sets the `selective` value of the keyboard.
+func (k *ReplyKeyboardBuilder) SetSelective(selective bool) *ReplyKeyboardBuilder {
+	k.selective = selective
+	return k
+}
+
+// Build returns the built ReplyKeyboardMarkup. (empty rows are dropped)
+func (k *ReplyKeyboardBuilder) Build() ReplyKeyboardMarkup {
+	rows := [][]KeyboardButton{}
+	for _, row := range k.rows {
+		if len(row) > 0 {
+			rows = append(rows, append([]KeyboardButton{}, row...))
+		}
+	}
+
+	return ReplyKeyboardMarkup{
+		Keyboard:              rows,
+		IsPersistent:          k.isPersistent,
+		ResizeKeyboard:        k.resizeKeyboard,
+		OneTimeKeyboard:       k.oneTimeKeyboard,
+		InputFieldPlaceholder: k.inputFieldPlaceholder,
+		Selective:             k.selective,
+	}
+}
+
+// given `request_id`, or a new one when it is 0
+func (k *ReplyKeyboardBuilder) requestID(requestID int64) int64 {
+	if requestID == 0 {
+		k.lastRequestID++
+		return k.lastRequestID
+	}
+	if requestID > k.lastRequestID {
+		k.lastRequestID = requestID
+	}
+	return requestID
+}
@@ -0,0 +1,232 @@
+package telegrambot
+
+// Lazy decoding of Updates, for bots which filter out most of updates.
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// LazyUpdate is an Update which decodes only its `update_id` and type upfront,
+// and its full payload on demand (with Update()).
+type LazyUpdate struct {
+	UpdateID int64
+	Type     UpdateType // empty if unknown
+
+	raw   []byte    // copy of the original payload
+	codec JSONCodec // codec for decoding the full payload
+
+	once   sync.Once
+	update Update
+	err    error
+}
+
+// a json value which is only checked for its existence (without being decoded)
+type presentField bool
+
+// UnmarshalJSON marks the field as present.
+func (p *presentField) UnmarshalJSON(data []byte) error {
+	*p = string(data) != "null"
+	return nil
+}
+
+// keys of Update for determining its type
+type lazyUpdateKeys struct {
+	UpdateID                int64        `json:"update_id"`
+	Message                 presentField `json:"message"`
+	EditedMessage           presentField `json:"edited_message"`
+	ChannelPost             presentField `json:"channel_post"`
+	EditedChannelPost       presentField `json:"edited_channel_post"`
+	InlineQuery             presentField `json:"inline_query"`
+	ChosenInlineResult      presentField `json:"chosen_inline_result"`
+	CallbackQuery           presentField `json:"callback_query"`
+	ShippingQuery           presentField `json:"shipping_query"`
+	PreCheckoutQuery        presentField `json:"pre_checkout_query"`
+	Poll                    presentField `json:"poll"`
+	PollAnswer              presentField `json:"poll_answer"`
+	MyChatMember            presentField `json:"my_chat_member"`
+	ChatMember              presentField `json:"chat_member"`
+	ChatJoinRequest         presentField `json:"chat_join_request"`
+	MessageReaction         presentField `json:"message_reaction"`
+	MessageReactionCount    presentField `json:"message_reaction_count"`
+	BusinessConnection      presentField `json:"business_connection"`
+	BusinessMessage         presentField `json:"business_message"`
+	EditedBusinessMessage   presentField `json:"edited_business_message"`
+	DeletedBusinessMessages presentField `json:"deleted_business_messages"`
+	ChatBoost               presentField `json:"chat_boost"`
+	RemovedChatBoost        presentField `json:"removed_chat_boost"`
+}
+
+// type of the update
+func (k lazyUpdateKeys) updateType() UpdateType {
+	for _, t := range []struct {
+		present presentField
+		typ     UpdateType
+	}{
+		{k.Message, UpdateTypeMessage},
+		{k.EditedMessage, UpdateTypeEditedMessage},
+		{k.ChannelPost, UpdateTypeChannelPost},
+		{k.EditedChannelPost, UpdateTypeEditedChannelPost},
+		{k.InlineQuery, UpdateTypeInlineQuery},
+		{k.ChosenInlineResult, UpdateTypeChosenInlineResult},
+		{k.CallbackQuery, UpdateTypeCallbackQuery},
+		{k.ShippingQuery, UpdateTypeShippingQuery},
+		{k.PreCheckoutQuery, UpdateTypePreCheckoutQuery},
+		{k.Poll, UpdateTypePoll},
+		{k.PollAnswer, UpdateTypePollAnswer},
+		{k.MyChatMember, UpdateTypeMyChatMember},
+		{k.ChatMember, UpdateTypeChatMember},
+		{k.ChatJoinRequest, UpdateTypeChatJoinRequest},
+		{k.MessageReaction, UpdateTypeMessageReaction},
+		{k.MessageReactionCount, UpdateTypeMessageReactionCount},
+		{k.BusinessConnection, UpdateTypeBusinessConnection},
+		{k.BusinessMessage, UpdateTypeBusinessMessage},
+		{k.EditedBusinessMessage, UpdateTypeEditedBusinessMessage},
+		{k.DeletedBusinessMessages, UpdateTypeDeletedBusinessMessages},
+		{k.ChatBoost, UpdateTypeChatBoost},
+		{k.RemovedChatBoost, UpdateTypeRemovedChatBoost},
+	} {
+		if t.present {
+			return t.typ
+		}
+	}
+	return ""
+}
+
+// NewLazyUpdate decodes `update_id` and the type of an update from given bytes.
+//
+// Given bytes are copied, so they can be reused after this function returns.
+func NewLazyUpdate(data []byte) (*LazyUpdate, error) {
+	update := &LazyUpdate{}
+	if err := update.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+// UnmarshalJSON decodes `update_id` and the type of an update.
+func (u *LazyUpdate) UnmarshalJSON(data []byte) error {
+	return u.decode(StdJSONCodec{}, data)
+}
+
+// decode `update_id` and the type of an update with given codec, which is also used for decoding the full payload
+func (u *LazyUpdate) decode(codec JSONCodec, data []byte) error {
+	var keys lazyUpdateKeys
+	if err := codec.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	u.UpdateID = keys.UpdateID
+	u.Type = keys.updateType()
+	u.raw = append([]byte(nil), data...)
+	u.codec = codec
+
+	return nil
+}
+
+// Update decodes (only once) and returns the full update.
+func (u *LazyUpdate) Update() (Update, error) {
+	u.once.Do(func() {
+		codec := u.codec
+		if codec == nil {
+			codec = StdJSONCodec{}
+		}
+		u.err = codec.Unmarshal(u.raw, &u.update)
+	})
+
+	return u.update, u.err
+}
+
+// Raw returns the original payload of the update.
+func (u *LazyUpdate) Raw() []byte {
+	return u.raw
+}
+
+// SetUpdateFilter sets a filter for incoming updates (from both webhook and polling).
+//
+// Only `update_id` and the type of each update are decoded before the filter is applied,
+// and updates which are not accepted by the filter are dropped without being decoded fully.
+// (LazyUpdate.Update() can be called in the filter when more details are needed.)
+//
+//	client.SetUpdateFilter(func(update *LazyUpdate) bool {
+//		return update.Type == UpdateTypeMessage || update.Type == UpdateTypeCallbackQuery
+//	})
+func (b *Bot) SetUpdateFilter(filter func(update *LazyUpdate) bool) {
+	b.updateFilter = filter
+}
+
+// GetUpdatesLazy retrieves updates from Telegram bot API, without decoding their full payloads.
+//
+// https://core.telegram.org/bots/api#getupdates
+func (b *Bot) GetUpdatesLazy(options OptionsGetUpdates) (result APIResponse[[]*LazyUpdate]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	return b.requestLazyUpdates("getUpdates", options)
+}
+
+// Send request for APIResponse[[]*LazyUpdate] and fetch its result.
+func (b *Bot) requestLazyUpdates(method string, params map[string]any) (result APIResponse[[]*LazyUpdate]) {
+	var errStr string
+
+	if bytes, err := b.request(method, params); err == nil {
+		codec := b.jsonCodec()
+
+		var jsonResponse APIResponse[[]json.RawMessage]
+		err = codec.Unmarshal(bytes, &jsonResponse)
+		if err == nil {
+			result = APIResponse[[]*LazyUpdate]{
+				Ok:          jsonResponse.Ok,
+				ErrorCode:   jsonResponse.ErrorCode,
+				Description: jsonResponse.Description,
+				Parameters:  jsonResponse.Parameters,
+				raw:         bytes,
+			}
+			if jsonResponse.Result != nil {
+				updates := make([]*LazyUpdate, 0, len(*jsonResponse.Result))
+				for _, data := range *jsonResponse.Result {
+					update := &LazyUpdate{}
+					if err = update.decode(codec, data); err != nil {
+						break
+					}
+					updates = append(updates, update)
+				}
+				result.Result = &updates
+			}
+		}
+		if err == nil {
+			return result
+		}
+
+		errStr = fmt.Sprintf("json parse error: %s (%s)", err, string(bytes))
+	} else {
+		errStr = fmt.Sprintf("%s failed with error: %s", method, err)
+	}
+
+	b.error(errStr)
+
+	return APIResponse[[]*LazyUpdate]{Ok: false, Description: &errStr}
+}
+
+// decode given bytes into an update, applying the update filter if it exists
+//
+// returns false if the update was filtered out.
+func (b *Bot) decodeUpdate(data []byte, update *Update) (accepted bool, err error) {
+	if b.updateFilter == nil {
+		return true, b.jsonCodec().Unmarshal(data, update)
+	}
+
+	lazy := &LazyUpdate{}
+	if err = lazy.decode(b.jsonCodec(), data); err != nil {
+		return false, err
+	}
+	if !b.updateFilter(lazy) {
+		b.verbose("update filtered out: %d (%s)", lazy.UpdateID, lazy.Type)
+		return false, nil
+	}
+
+	*update, err = lazy.Update()
+	return true, err
+}
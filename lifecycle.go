@@ -0,0 +1,71 @@
+package telegrambot
+
+// Callbacks for the bot's own membership changes in chats, derived from `my_chat_member` updates.
+
+// ChatLifecycleEvent is a change of the bot's membership in a chat
+type ChatLifecycleEvent struct {
+	Chat Chat // the chat where the bot's membership changed
+	From User // the user who made the change (eg. the one who added or removed the bot)
+
+	Update ChatMemberUpdated // the original `my_chat_member` update
+}
+
+// ChatLifecycleHandler is a function which handles a ChatLifecycleEvent.
+type ChatLifecycleHandler func(ctx *Ctx, event ChatLifecycleEvent) error
+
+// handler names of lifecycle callbacks
+const (
+	addedToChatHandlerName     = "on_added_to_chat"
+	removedFromChatHandlerName = "on_removed_from_chat"
+	promotedInChatHandlerName  = "on_promoted_in_chat"
+)
+
+// OnAddedToChat adds a callback which is called when the bot is added to (or joins) a chat.
+//
+// It is added with AddUpdateHandler(), so it is called in order with other handlers.
+// When the bot is added as an administrator directly, OnPromotedInChat callbacks are also called.
+//
+// NOTE: `my_chat_member` updates are not delivered when they are excluded from `allowed_updates`.
+//
+//	client.OnAddedToChat(func(ctx *Ctx, event ChatLifecycleEvent) error {
+//		return store.InitChat(event.Chat.ID)
+//	})
+func (b *Bot) OnAddedToChat(handler ChatLifecycleHandler) {
+	b.addChatLifecycleHandler(addedToChatHandlerName, ChatMemberUpdated.Joined, handler)
+}
+
+// OnRemovedFromChat adds a callback which is called when the bot leaves (or is removed or banned from) a chat.
+//
+// It is added with AddUpdateHandler(), so it is called in order with other handlers.
+//
+//	client.OnRemovedFromChat(func(ctx *Ctx, event ChatLifecycleEvent) error {
+//		return store.DeleteChat(event.Chat.ID)
+//	})
+func (b *Bot) OnRemovedFromChat(handler ChatLifecycleHandler) {
+	b.addChatLifecycleHandler(removedFromChatHandlerName, ChatMemberUpdated.Left, handler)
+}
+
+// OnPromotedInChat adds a callback which is called when the bot becomes an administrator of a chat.
+//
+// It is added with AddUpdateHandler(), so it is called in order with other handlers.
+func (b *Bot) OnPromotedInChat(handler ChatLifecycleHandler) {
+	b.addChatLifecycleHandler(promotedInChatHandlerName, ChatMemberUpdated.WasPromoted, handler)
+}
+
+// add a handler which calls given callback for `my_chat_member` updates matching given predicate
+func (b *Bot) addChatLifecycleHandler(name string, matches func(ChatMemberUpdated) bool, handler ChatLifecycleHandler) {
+	b.AddUpdateHandler(name, func(ctx *Ctx) error {
+		updated := ctx.Update.MyChatMember
+		if updated == nil || !matches(*updated) {
+			return nil
+		}
+
+		b.verbose("bot's membership changed in chat %d: %s => %s", updated.Chat.ID, updated.OldChatMember.Status, updated.NewChatMember.Status)
+
+		return handler(ctx, ChatLifecycleEvent{
+			Chat:   updated.Chat,
+			From:   updated.From,
+			Update: *updated,
+		})
+	})
+}
@@ -0,0 +1,134 @@
+package telegrambot
+
+// Builders of t.me links for messages, users, chats, and bots.
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	tmeBaseURL = "https://t.me/"
+
+	// prefix of supergroup/channel ids (eg. -1001234567890)
+	supergroupChatIDPrefix = int64(-1000000000000)
+)
+
+// MessageLink generates a link to a message in given chat.
+//
+// Public chats (with username) get "https://t.me/<username>/<message_id>",
+// and private supergroups/channels get "https://t.me/c/<internal_id>/<message_id>".
+//
+// Returns an empty string for private chats and basic groups, which have no message links.
+func MessageLink(chat Chat, messageID int64) string {
+	if chat.Username != nil && *chat.Username != "" {
+		return fmt.Sprintf("%s%s/%d", tmeBaseURL, *chat.Username, messageID)
+	}
+	if internalID, ok := internalChatID(chat); ok {
+		return fmt.Sprintf("%sc/%d/%d", tmeBaseURL, internalID, messageID)
+	}
+	return ""
+}
+
+// internal id of a supergroup/channel, used in private links (eg. -1001234567890 => 1234567890)
+func internalChatID(chat Chat) (internalID int64, ok bool) {
+	if (chat.Type == ChatTypeSupergroup || chat.Type == ChatTypeChannel) && chat.ID < supergroupChatIDPrefix {
+		return supergroupChatIDPrefix - chat.ID, true
+	}
+	return 0, false
+}
+
+// Link generates a link to this message. (see MessageLink)
+//
+// Messages in forum topics get links with their thread ids.
+func (m Message) Link() string {
+	if m.IsTopicMessage && m.MessageThreadID != 0 {
+		if m.Chat.Username != nil && *m.Chat.Username != "" {
+			return fmt.Sprintf("%s%s/%d/%d", tmeBaseURL, *m.Chat.Username, m.MessageThreadID, m.MessageID)
+		}
+		if internalID, ok := internalChatID(m.Chat); ok {
+			return fmt.Sprintf("%sc/%d/%d/%d", tmeBaseURL, internalID, m.MessageThreadID, m.MessageID)
+		}
+	}
+	return MessageLink(m.Chat, m.MessageID)
+}
+
+// UserMentionLink generates a link for mentioning a user with id. (eg. "tg://user?id=123456")
+//
+// It only works in messages when the user can be mentioned. (eg. privacy settings)
+func UserMentionLink(userID int64) string {
+	return fmt.Sprintf("tg://user?id=%d", userID)
+}
+
+// Link generates a link to this user's profile.
+//
+// Returns "https://t.me/<username>" if the user has a username, or a mention link otherwise.
+func (u User) Link() string {
+	if u.Username != nil && *u.Username != "" {
+		return tmeBaseURL + *u.Username
+	}
+	return UserMentionLink(u.ID)
+}
+
+// Link generates a link to this chat.
+//
+// Returns "https://t.me/<username>" for public chats, or the invite link of the chat if it was fetched with GetChat().
+//
+// Returns an empty string if there is no available link.
+func (c Chat) Link() string {
+	if c.Username != nil && *c.Username != "" {
+		return tmeBaseURL + *c.Username
+	}
+	if c.InviteLink != nil {
+		return *c.InviteLink
+	}
+	return ""
+}
+
+// ShareLink generates a link for sharing given url and text. (https://t.me/share/url?url=...&text=...)
+func ShareLink(sharedURL, text string) string {
+	params := url.Values{}
+	params.Set("url", sharedURL)
+	if text != "" {
+		params.Set("text", text)
+	}
+	return tmeBaseURL + "share/url?" + params.Encode()
+}
+
+// BotLink generates a link to a bot with its username. (eg. "https://t.me/mybot")
+func BotLink(botUsername string) string {
+	return tmeBaseURL + strings.TrimPrefix(botUsername, "@")
+}
+
+// BotStartLink generates a link which starts a private chat with a bot, with `payload` as its start parameter.
+//
+// `payload` can contain up to 64 characters of A-Z, a-z, 0-9, _ and -.
+//
+// https://core.telegram.org/bots/features#deep-linking
+func BotStartLink(botUsername, payload string) string {
+	return botLinkWithParam(botUsername, "start", payload)
+}
+
+// BotStartGroupLink generates a link which adds a bot to a group, with `payload` as its start parameter.
+//
+// https://core.telegram.org/bots/features#deep-linking
+func BotStartGroupLink(botUsername, payload string) string {
+	return botLinkWithParam(botUsername, "startgroup", payload)
+}
+
+// BotStartChannelLink generates a link which adds a bot to a channel as an administrator.
+//
+// https://core.telegram.org/api/links#bot-links
+func BotStartChannelLink(botUsername string) string {
+	return botLinkWithParam(botUsername, "startchannel", "")
+}
+
+// generate a bot link with given parameter
+func botLinkWithParam(botUsername, param, value string) string {
+	link := BotLink(botUsername) + "?" + param
+	if value != "" {
+		link += "=" + url.QueryEscape(value)
+	}
+	return link
+}
@@ -0,0 +1,228 @@
+package telegrambot
+
+// Live streaming of incoming updates and outgoing API calls with server-sent events, for watching bot traffic.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// default path of the live stream on the internal server
+	defaultLiveStreamPath = "/debug/live"
+
+	// number of buffered events for each client (events are dropped for slow clients)
+	liveStreamClientBufferSize = 64
+
+	// interval of keep-alive comments
+	liveStreamKeepAliveInterval = 15 * time.Second
+
+	// maximum length of text previews in update summaries
+	liveStreamPreviewLength = 64
+)
+
+// LiveEventKind is a kind of LiveEvent
+type LiveEventKind string
+
+// LiveEventKind strings
+const (
+	LiveEventKindUpdate LiveEventKind = "update" // an incoming update
+	LiveEventKindCall   LiveEventKind = "call"   // an outgoing API call
+)
+
+// LiveEvent is an event streamed by LiveStream
+type LiveEvent struct {
+	Kind   LiveEventKind      `json:"kind"`
+	Time   time.Time          `json:"time"`
+	Update *LiveUpdateSummary `json:"update,omitempty"`
+	Call   *AuditEntry        `json:"call,omitempty"` // without params
+}
+
+// LiveUpdateSummary is a sanitized summary of an incoming update
+type LiveUpdateSummary struct {
+	UpdateID int64      `json:"update_id"`
+	Type     UpdateType `json:"type,omitempty"`
+	ChatID   int64      `json:"chat_id,omitempty"`
+	UserID   int64      `json:"user_id,omitempty"`
+	Preview  string     `json:"preview,omitempty"` // truncated text (or caption, or callback data), only with SetPreviews(true)
+}
+
+// LiveStream streams incoming updates and outgoing API calls to connected clients as server-sent events.
+type LiveStream struct {
+	clients  map[chan LiveEvent]struct{}
+	previews bool // include previews of user contents in update summaries or not
+	lock     sync.RWMutex
+}
+
+// EnableLiveStream mounts a live stream of incoming updates and outgoing API calls on the internal server
+// at given path (default: "/debug/live"), and returns it.
+//
+// Each event is sent as a server-sent event whose name is its kind ("update" or "call") and data is LiveEvent in JSON.
+// Updates are summarized without personal information except ids (unless previews are enabled with SetPreviews()),
+// and calls are recorded like AuditEntry
+// without their params (which may contain user content).
+//
+//	client.EnableLiveStream("")
+//	client.StartInternalServer("127.0.0.1:6060")
+//
+//	$ curl -N http://127.0.0.1:6060/debug/live
+func (b *Bot) EnableLiveStream(path string) *LiveStream {
+	if path == "" {
+		path = defaultLiveStreamPath
+	}
+
+	stream := &LiveStream{
+		clients: map[chan LiveEvent]struct{}{},
+	}
+	b.InternalServeMux().Handle(path, stream)
+	b.liveStream = stream
+
+	return stream
+}
+
+// ServeHTTP streams events to the client until it disconnects.
+func (s *LiveStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events := s.subscribe()
+	defer s.unsubscribe(events)
+
+	keepAlive := time.NewTicker(liveStreamKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Kind, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// SetPreviews enables or disables previews of texts, captions, and callback data in update summaries. (disabled by default)
+//
+// NOTE: Previews are contents of users, so enable them only when the stream is not exposed to others.
+func (s *LiveStream) SetPreviews(enabled bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.previews = enabled
+}
+
+// Clients returns the number of connected clients.
+func (s *LiveStream) Clients() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.clients)
+}
+
+// add a client
+func (s *LiveStream) subscribe() chan LiveEvent {
+	events := make(chan LiveEvent, liveStreamClientBufferSize)
+
+	s.lock.Lock()
+	s.clients[events] = struct{}{}
+	s.lock.Unlock()
+
+	return events
+}
+
+// remove a client
+func (s *LiveStream) unsubscribe(events chan LiveEvent) {
+	s.lock.Lock()
+	delete(s.clients, events)
+	s.lock.Unlock()
+}
+
+// send given event to all clients, without blocking
+func (s *LiveStream) publish(event LiveEvent) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for events := range s.clients {
+		select {
+		case events <- event:
+		default: // drop the event for a slow client
+		}
+	}
+}
+
+// publish a summary of an incoming update
+func (s *LiveStream) publishUpdate(update Update) {
+	s.lock.RLock()
+	clients, previews := len(s.clients), s.previews
+	s.lock.RUnlock()
+
+	if clients == 0 {
+		return
+	}
+
+	summary := LiveUpdateSummary{
+		UpdateID: update.UpdateID,
+		Type:     update.Type(),
+	}
+	if chat := update.EffectiveChat(); chat != nil {
+		summary.ChatID = chat.ID
+	}
+	if user := update.EffectiveUser(); user != nil {
+		summary.UserID = user.ID
+	}
+	if previews {
+		if message := update.EffectiveMessage(); message != nil {
+			if message.Text != nil {
+				summary.Preview = *message.Text
+			} else if message.Caption != nil {
+				summary.Preview = *message.Caption
+			}
+		}
+		if update.CallbackQuery != nil && update.CallbackQuery.Data != nil {
+			summary.Preview = *update.CallbackQuery.Data
+		}
+		if runes := []rune(summary.Preview); len(runes) > liveStreamPreviewLength {
+			summary.Preview = string(runes[:liveStreamPreviewLength]) + "…"
+		}
+	}
+
+	s.publish(LiveEvent{
+		Kind:   LiveEventKindUpdate,
+		Time:   time.Now(),
+		Update: &summary,
+	})
+}
+
+// publish an outgoing API call, without its params
+func (s *LiveStream) publishCall(entry AuditEntry) {
+	entry.Params = nil
+
+	s.publish(LiveEvent{
+		Kind: LiveEventKindCall,
+		Time: entry.Time,
+		Call: &entry,
+	})
+}
@@ -0,0 +1,102 @@
+package telegrambot
+
+// Using a self-hosted (local) Bot API server, which allows larger uploads and returns local paths of files.
+//
+// https://github.com/tdlib/telegram-bot-api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NewClientWithLocalServer gets a new bot API client with given token string,
+// for a local Bot API server at `serverURL` (eg. "http://localhost:8081"). (see SetLocalServer())
+func NewClientWithLocalServer(token, serverURL string) *Bot {
+	b := NewClient(token)
+	b.SetLocalServer(serverURL)
+	return b
+}
+
+// SetLocalServer sets the base urls of API requests and file downloads to a local Bot API server
+// at `serverURL` (eg. "http://localhost:8081"), which runs with `--local` option.
+//
+// The upload limit of payload validation is raised to LocalServerUploadFileMaxSize.
+//
+// A local server returns absolute paths of files on its disk in `file_path` of GetFile(),
+// so read them with OpenFile() when the bot runs on the same machine (or with the same volume).
+//
+// Call LogOut() on the cloud Bot API server before switching to a local server.
+func (b *Bot) SetLocalServer(serverURL string) {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+
+	b.apiBaseURL = serverURL + "/bot"
+	b.fileBaseURL = serverURL + "/file/bot"
+	b.localServer = true
+
+	if b.payloadLimits != nil {
+		b.payloadLimits.UploadFileSize = LocalServerUploadFileMaxSize
+	}
+}
+
+// SetFileBaseURL sets the base url of file downloads, followed by the bot token and file path.
+// (default: "https://api.telegram.org/file/bot")
+func (b *Bot) SetFileBaseURL(baseURL string) {
+	b.fileBaseURL = baseURL
+}
+
+// IsLocalServer returns whether the bot is set to use a local Bot API server (see SetLocalServer()) or not.
+func (b *Bot) IsLocalServer() bool {
+	return b.localServer
+}
+
+// OpenFile opens the content of given File (from GetFile()) for reading, and the returned reader should be closed.
+//
+// With a local Bot API server, files with absolute paths are read from the disk directly.
+// Otherwise, they are downloaded from GetFileURL().
+func (b *Bot) OpenFile(file File) (io.ReadCloser, error) {
+	if file.FilePath == nil {
+		return nil, fmt.Errorf("no file path in file '%s'", file.FileID)
+	}
+
+	if b.localServer && filepath.IsAbs(*file.FilePath) {
+		f, err := os.Open(*file.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open local file of '%s': %w", file.FileID, err)
+		}
+		return f, nil
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", b.GetFileURL(file), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file '%s': %s", file.FileID, b.redact(err.Error()))
+	}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file '%s': %s", file.FileID, b.redact(err.Error()))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download file '%s': http status %d", file.FileID, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+// path of a file relative to the bot's directory, for downloading it from the file base url
+//
+// (local servers return absolute paths like "/var/lib/telegram-bot-api/<token>/photos/file_0.jpg")
+func (b *Bot) relativeFilePath(path string) string {
+	if !b.localServer || !filepath.IsAbs(path) {
+		return path
+	}
+
+	if _, relative, found := strings.Cut(filepath.ToSlash(path), "/"+b.token+"/"); found {
+		return relative
+	}
+	return strings.TrimPrefix(filepath.ToSlash(path), "/")
+}
@@ -0,0 +1,96 @@
+package telegrambot
+
+// Pluggable structured logger for log messages of the bot.
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Logger is an interface of leveled loggers with key-value fields.
+//
+// *slog.Logger satisfies it, and other loggers (eg. zap's SugaredLogger with `w` methods) can be adapted easily.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// SetLogger sets the logger for log messages of the bot. (nil for printing them to stdout/stderr, by default)
+//
+// With a logger, verbose messages (when Bot.Verbose == true) are logged at Info level,
+// errors at Error level, and each API request is logged at Debug level (Warn level when it fails)
+// with fields: `method`, `chat_id`, `duration`, `ok`, `error_code`, and `description`.
+// When Bot.Debug == true, `params` and `response` are also added to the entries of API requests.
+//
+// Bot tokens and webhook secret tokens are redacted from messages and all fields, except numbers and booleans.
+// (other values are formatted as strings with fmt.Sprint before redaction)
+//
+//	client.SetLogger(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+func (b *Bot) SetLogger(logger Logger) {
+	b.logger = logger
+}
+
+// log an API request with fields
+func (b *Bot) logRequest(method string, params map[string]any, debugParams string, duration time.Duration, resp []byte, err error) {
+	ok, errorCode, description := b.responseSummary(resp, err)
+
+	args := []any{"method", method}
+	if chatID, exists := params["chat_id"]; exists {
+		args = append(args, "chat_id", fmt.Sprint(chatID))
+	}
+	args = append(args, "duration", duration, "ok", ok)
+	if !ok {
+		args = append(args, "error_code", errorCode, "description", description)
+	}
+	if b.Debug {
+		args = append(args, "params", debugParams)
+		if err == nil {
+			args = append(args, "response", string(resp))
+		}
+	}
+
+	if ok {
+		b.logger.Debug("api request", b.redactLogArgs(args)...)
+	} else {
+		b.logger.Warn("api request failed", b.redactLogArgs(args)...)
+	}
+}
+
+// redact confidential info from values of key-value fields, formatting non-primitive values as strings
+func (b *Bot) redactLogArgs(args []any) []any {
+	redacted := make([]any, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case nil:
+			redacted[i] = nil
+		case string:
+			redacted[i] = b.redact(v)
+		case []byte:
+			redacted[i] = b.redact(string(v))
+		case error:
+			redacted[i] = b.redact(v.Error())
+		default:
+			if isPrimitiveLogArg(arg) {
+				redacted[i] = arg
+			} else {
+				redacted[i] = b.redact(fmt.Sprint(arg))
+			}
+		}
+	}
+	return redacted
+}
+
+// check if given value is a number or a boolean (eg. time.Duration), which cannot contain confidential info
+func isPrimitiveLogArg(arg any) bool {
+	switch reflect.TypeOf(arg).Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package telegrambot
+
+// Verifying authorization data of users who logged in to websites with Telegram Login Widget.
+//
+// https://core.telegram.org/widgets/login#checking-authorization
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errors of VerifyLoginWidget()
+var (
+	ErrLoginWidgetInvalidHash = errors.New("invalid hash of login widget data") // the data were not signed with the bot's token (eg. forged)
+	ErrLoginWidgetExpired     = errors.New("login widget data expired")         // `auth_date` is older than the max age
+)
+
+// AuthUser is a user authenticated with Telegram Login Widget
+//
+// https://core.telegram.org/widgets/login#receiving-authorization-data
+type AuthUser struct {
+	ID        int64   `json:"id"`
+	FirstName string  `json:"first_name"`
+	LastName  *string `json:"last_name,omitempty"`
+	Username  *string `json:"username,omitempty"`
+	PhotoURL  *string `json:"photo_url,omitempty"`
+	AuthDate  int     `json:"auth_date"` // unix timestamp of the authentication
+}
+
+// AuthTime returns `auth_date` of AuthUser as time.Time.
+func (u AuthUser) AuthTime() time.Time {
+	return time.Unix(int64(u.AuthDate), 0)
+}
+
+// VerifyLoginWidget verifies authorization data from Telegram Login Widget (eg. query parameters of `data-auth-url`),
+// and returns the authenticated user.
+//
+// It fails with ErrLoginWidgetInvalidHash if the data were not signed with the bot's token,
+// or with ErrLoginWidgetExpired if `auth_date` is older than `maxAge`. (0 for no expiry)
+func (b *Bot) VerifyLoginWidget(values url.Values, maxAge time.Duration) (user AuthUser, err error) {
+	data := map[string]string{}
+	for key := range values {
+		data[key] = values.Get(key)
+	}
+
+	hash, exists := data["hash"]
+	if !exists {
+		return user, fmt.Errorf("no hash in login widget data: %w", ErrLoginWidgetInvalidHash)
+	}
+	delete(data, "hash")
+
+	if !hmac.Equal([]byte(strings.ToLower(hash)), []byte(b.loginWidgetHash(data))) {
+		return user, ErrLoginWidgetInvalidHash
+	}
+
+	if user.ID, err = strconv.ParseInt(data["id"], 10, 64); err != nil {
+		return user, fmt.Errorf("invalid `id` in login widget data: %w", err)
+	}
+	if user.AuthDate, err = strconv.Atoi(data["auth_date"]); err != nil {
+		return user, fmt.Errorf("invalid `auth_date` in login widget data: %w", err)
+	}
+	user.FirstName = data["first_name"]
+	if lastName, exists := data["last_name"]; exists {
+		user.LastName = &lastName
+	}
+	if username, exists := data["username"]; exists {
+		user.Username = &username
+	}
+	if photoURL, exists := data["photo_url"]; exists {
+		user.PhotoURL = &photoURL
+	}
+
+	if maxAge > 0 && time.Since(user.AuthTime()) > maxAge {
+		return user, ErrLoginWidgetExpired
+	}
+
+	return user, nil
+}
+
+// VerifyLoginWidgetRequest verifies authorization data in the query parameters of given http request
+// (redirected to `data-auth-url` by Telegram Login Widget) with VerifyLoginWidget().
+//
+//	client.InternalServeMux().HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+//		user, err := client.VerifyLoginWidgetRequest(r, 24*time.Hour)
+//		if err != nil {
+//			http.Error(w, "login failed", http.StatusUnauthorized)
+//			return
+//		}
+//		startSession(w, user)
+//	})
+func (b *Bot) VerifyLoginWidgetRequest(r *http.Request, maxAge time.Duration) (user AuthUser, err error) {
+	return b.VerifyLoginWidget(r.URL.Query(), maxAge)
+}
+
+// hex-encoded HMAC-SHA256 of the data-check-string of given data, with SHA256 of the bot's token as the key
+func (b *Bot) loginWidgetHash(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = key + "=" + data[key]
+	}
+
+	secret := sha256.Sum256([]byte(b.token))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,344 @@
+package telegrambot
+
+// Escaping texts for MarkdownV2, and formatting them with automatically escaped values.
+//
+// https://core.telegram.org/bots/api#markdownv2-style
+
+import (
+	"fmt"
+	"strings"
+)
+
+// characters which should be escaped in MarkdownV2 texts
+const markdownV2SpecialChars = "_*[]()~`>#+-=|{}.!\\"
+
+// EscapeMarkdownV2 escapes all special characters in given text for MarkdownV2.
+func EscapeMarkdownV2(text string) string {
+	return escapeChars(text, markdownV2SpecialChars)
+}
+
+// EscapeMarkdownV2Code escapes given text for MarkdownV2 inline code or pre blocks. ('`' and '\')
+func EscapeMarkdownV2Code(text string) string {
+	return escapeChars(text, "`\\")
+}
+
+// EscapeMarkdownV2URL escapes given url for MarkdownV2 inline links. (')' and '\')
+func EscapeMarkdownV2URL(url string) string {
+	return escapeChars(url, ")\\")
+}
+
+// escape given characters in text with '\'
+func escapeChars(text, chars string) string {
+	if !strings.ContainsAny(text, chars) {
+		return text
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(text) + 8)
+	for _, r := range text {
+		if strings.ContainsRune(chars, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+////////////////////////////////
+// formatter
+//
+
+// contexts in MarkdownV2 texts, which need different escaping
+type markdownV2Context int
+
+const (
+	markdownV2ContextText markdownV2Context = iota
+	markdownV2ContextCode                   // in inline code or pre block
+	markdownV2ContextURL                    // in url of inline link
+	markdownV2ContextRaw                    // width or precision of verbs (eg. `%*d`), not escaped
+)
+
+// a value which is escaped when formatted
+type markdownV2Value struct {
+	value   any
+	context markdownV2Context
+}
+
+// Format formats the value with given verb, and escapes it for its context.
+func (v markdownV2Value) Format(f fmt.State, verb rune) {
+	formatted := fmt.Sprintf(fmt.FormatString(f, verb), v.value)
+
+	switch v.context {
+	case markdownV2ContextCode:
+		formatted = EscapeMarkdownV2Code(formatted)
+	case markdownV2ContextURL:
+		formatted = EscapeMarkdownV2URL(formatted)
+	default:
+		formatted = EscapeMarkdownV2(formatted)
+	}
+
+	_, _ = f.Write([]byte(formatted))
+}
+
+// FormatMarkdownV2 formats like fmt.Sprintf, with `format` as a MarkdownV2 template,
+// escaping formatted `args` for where they are placed (text, inline code/pre block, or url of inline link).
+//
+//	text := FormatMarkdownV2("*Hello, %s!* Your order `%s` is [here](%s).", name, orderID, orderURL)
+//
+// NOTE: `format` itself is not escaped, so its literal special characters should be escaped manually. (eg. "\\.")
+func FormatMarkdownV2(format string, args ...any) string {
+	contexts, ok := markdownV2ArgContexts(format, len(args))
+
+	wrapped := make([]any, len(args))
+	for i, arg := range args {
+		context := markdownV2ContextText
+		if ok {
+			context = contexts[i]
+		}
+		if context == markdownV2ContextRaw {
+			wrapped[i] = arg
+		} else {
+			wrapped[i] = markdownV2Value{value: arg, context: context}
+		}
+	}
+
+	return fmt.Sprintf(format, wrapped...)
+}
+
+// contexts of arguments in given format string
+//
+// returns false if they cannot be determined. (eg. explicit argument indexes)
+func markdownV2ArgContexts(format string, numArgs int) (contexts []markdownV2Context, ok bool) {
+	contexts = make([]markdownV2Context, numArgs)
+	context := markdownV2ContextText
+	inLinkText := false
+	arg := 0
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+
+		switch {
+		case c == '%':
+			if i+1 < len(format) && format[i+1] == '%' {
+				i++
+				continue
+			}
+
+			// flags, width, and precision
+			for i++; i < len(format) && strings.IndexByte("+-# 0123456789.*[]", format[i]) >= 0; i++ {
+				switch format[i] {
+				case '[':
+					return nil, false // explicit argument indexes are not supported
+				case '*':
+					if arg < numArgs {
+						contexts[arg] = markdownV2ContextRaw // width or precision from args
+					}
+					arg++
+				}
+			}
+
+			if arg < numArgs {
+				contexts[arg] = context
+			}
+			arg++
+		case c == '\\':
+			i++ // skip escaped character
+		case context == markdownV2ContextCode:
+			if c == '`' {
+				if strings.HasPrefix(format[i:], "```") {
+					i += 2
+				}
+				context = markdownV2ContextText
+			}
+		case context == markdownV2ContextURL:
+			if c == ')' {
+				context = markdownV2ContextText
+			}
+		case c == '`':
+			if strings.HasPrefix(format[i:], "```") {
+				i += 2
+			}
+			context = markdownV2ContextCode
+		case c == '[':
+			inLinkText = true
+		case c == ']' && inLinkText && i+1 < len(format) && format[i+1] == '(':
+			inLinkText = false
+			context = markdownV2ContextURL
+			i++
+		}
+	}
+
+	return contexts, true
+}
+
+////////////////////////////////
+// auto-escape
+//
+
+// FixMarkdownV2 escapes special characters in given MarkdownV2 text which cannot be a part of markups,
+// (eg. '.', '!', '-', or unpaired brackets) leaving markups and already-escaped characters as they are.
+//
+// It is for texts which are not (fully) escaped, like ones with dynamic values interpolated without escaping.
+func FixMarkdownV2(text string) string {
+	var sb strings.Builder
+	sb.Grow(len(text) + 8)
+
+	linkCloses := map[int]int{} // index of ']' => index of ')' of inline links
+	atLineStart := true
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		lineStart := atLineStart
+		atLineStart = c == '\n'
+
+		switch c {
+		case '\\':
+			if i+1 < len(text) {
+				sb.WriteString(text[i : i+2]) // already escaped
+				i++
+			} else {
+				sb.WriteString("\\\\")
+			}
+		case '`':
+			// copy code or pre blocks as they are
+			delimiter := "`"
+			if strings.HasPrefix(text[i:], "```") {
+				delimiter = "```"
+			}
+			if end := indexUnescaped(text, delimiter, i+len(delimiter)); end >= 0 {
+				sb.WriteString(text[i : end+len(delimiter)])
+				i = end + len(delimiter) - 1
+			} else {
+				sb.WriteString("\\`")
+			}
+		case '[':
+			if closeBracket, closeParen, found := markdownV2Link(text, i); found {
+				linkCloses[closeBracket] = closeParen
+				sb.WriteByte(c)
+			} else {
+				sb.WriteString("\\[")
+			}
+		case ']':
+			if closeParen, exists := linkCloses[i]; exists {
+				sb.WriteString(text[i : closeParen+1]) // "](url)"
+				i = closeParen
+			} else {
+				sb.WriteString("\\]")
+			}
+		case '!':
+			// custom emoji: ![👍](tg://emoji?id=...)
+			if i+1 < len(text) && text[i+1] == '[' {
+				if _, _, found := markdownV2Link(text, i+1); found {
+					sb.WriteByte(c)
+					continue
+				}
+			}
+			sb.WriteString("\\!")
+		case '>':
+			if lineStart {
+				sb.WriteByte(c) // block quotation
+			} else {
+				sb.WriteString("\\>")
+			}
+		case '|':
+			if i+1 < len(text) && text[i+1] == '|' {
+				sb.WriteString("||") // spoiler
+				i++
+			} else {
+				sb.WriteString("\\|")
+			}
+		case '(', ')', '#', '+', '-', '=', '{', '}', '.':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c) // NOTE: '*', '_', and '~' are left as markups
+		}
+	}
+
+	return sb.String()
+}
+
+// index of given delimiter which is not escaped, from `start`
+func indexUnescaped(text, delimiter string, start int) int {
+	for i := start; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+		} else if strings.HasPrefix(text[i:], delimiter) {
+			return i
+		}
+	}
+	return -1
+}
+
+// find an inline link starting with '[' at `start`, and return indices of its ']' and ')'
+func markdownV2Link(text string, start int) (closeBracket, closeParen int, found bool) {
+	closeBracket = indexUnescaped(text, "]", start+1)
+	if closeBracket < 0 || closeBracket+1 >= len(text) || text[closeBracket+1] != '(' {
+		return 0, 0, false
+	}
+	if nested := indexUnescaped(text[:closeBracket], "[", start+1); nested >= 0 {
+		return 0, 0, false
+	}
+
+	closeParen = indexUnescaped(text, ")", closeBracket+2)
+	if closeParen < 0 {
+		return 0, 0, false
+	}
+	return closeBracket, closeParen, true
+}
+
+// SetMarkdownV2AutoEscape enables or disables fixing texts and captions with FixMarkdownV2()
+// when they are sent with `parse_mode` of MarkdownV2.
+//
+// (for bots which interpolate dynamic values into MarkdownV2 texts without escaping them)
+//
+// NOTE: FixMarkdownV2() leaves '*', '_', and '~' as markups, so dynamic values which contain them (eg. "john_doe")
+// should be given with SetMarkdownV2Args() of options instead, which escapes them fully:
+//
+//	client.SendMessage(chatID, "*Hello*, %s\\!", OptionsSendMessage{}.SetMarkdownV2Args(username))
+func (b *Bot) SetMarkdownV2AutoEscape(enabled bool) {
+	b.markdownV2AutoEscape = enabled
+}
+
+// library-local option key for values formatted into MarkdownV2 texts and captions (not sent to the API)
+const markdownV2ArgsKey = "__markdown_v2_args"
+
+// format the text (or caption) in given params with FormatMarkdownV2(), if values are given with SetMarkdownV2Args()
+//
+// It should be called before texts are split or measured, as the text is a template until then.
+func formatMarkdownV2Params(params map[string]any) {
+	value, exists := params[markdownV2ArgsKey]
+	if !exists {
+		return
+	}
+	delete(params, markdownV2ArgsKey)
+
+	args, _ := value.([]any)
+	for _, key := range []string{"text", "caption"} {
+		if template, ok := params[key].(string); ok {
+			params[key] = FormatMarkdownV2(template, args...)
+		}
+	}
+}
+
+// fix texts and captions in given params with FixMarkdownV2(), if they are in MarkdownV2
+func fixMarkdownV2Params(params map[string]any) {
+	switch mode := params["parse_mode"].(type) {
+	case ParseMode:
+		if mode != ParseModeMarkdownV2 {
+			return
+		}
+	case string:
+		if mode != string(ParseModeMarkdownV2) {
+			return
+		}
+	default:
+		return
+	}
+
+	for _, key := range []string{"text", "caption"} {
+		if text, ok := params[key].(string); ok {
+			params[key] = FixMarkdownV2(text)
+		}
+	}
+}
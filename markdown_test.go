@@ -0,0 +1,52 @@
+package telegrambot
+
+import (
+	"testing"
+)
+
+// TestFormatMarkdownV2 tests escaping formatted values for their contexts in MarkdownV2 templates.
+func TestFormatMarkdownV2(t *testing.T) {
+	for _, test := range []struct {
+		format   string
+		args     []any
+		expected string
+	}{
+		{"*Hello*, %s\\!", []any{"john_doe"}, "*Hello*, john\\_doe\\!"},
+		{"%s", []any{"a*b"}, "a\\*b"},
+		{"%s", []any{"~strike~"}, "\\~strike\\~"},
+		{"%d items", []any{-3}, "\\-3 items"},
+		{"`%s`", []any{"a`b\\c*d"}, "`a\\`b\\\\c*d`"},
+		{"[%s](%s)", []any{"a.b", "https://example.com/(x)"}, "[a\\.b](https://example.com/(x\\))"},
+		{"\\[%*d\\]", []any{5, 42}, "\\[   42\\]"},
+		{"%.*f|%s", []any{2, 3.14159, "x.y"}, "3\\.14|x\\.y"},
+		{"%-*s|", []any{4, "ab"}, "ab  |"},
+		{"100%% %s", []any{"done."}, "100% done\\."},
+	} {
+		if formatted := FormatMarkdownV2(test.format, test.args...); formatted != test.expected {
+			t.Errorf("FormatMarkdownV2(%q, %v) = %q, expected: %q", test.format, test.args, formatted, test.expected)
+		}
+	}
+}
+
+// TestFormatMarkdownV2Params tests formatting texts and captions with values given with SetMarkdownV2Args().
+func TestFormatMarkdownV2Params(t *testing.T) {
+	options := OptionsSendMessage{}.SetMarkdownV2Args("john_doe", "a*b")
+	options["text"] = "_%s_ wrote: %s"
+
+	formatMarkdownV2Params(options)
+
+	if text := options["text"]; text != "_john\\_doe_ wrote: a\\*b" {
+		t.Errorf("unexpected text: %q", text)
+	}
+	if mode := options["parse_mode"]; mode != ParseModeMarkdownV2 {
+		t.Errorf("unexpected parse mode: %v", mode)
+	}
+	if _, exists := options[markdownV2ArgsKey]; exists {
+		t.Errorf("values of the template should not be sent to the API")
+	}
+
+	// fully escaped values are kept by the auto-escape
+	if fixed := FixMarkdownV2(options["text"].(string)); fixed != options["text"] {
+		t.Errorf("FixMarkdownV2() changed a formatted text: %q", fixed)
+	}
+}
@@ -0,0 +1,206 @@
+package telegrambot
+
+// Sending more media than a media group can have, in multiple media groups,
+// and receiving messages of a media group as one album.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// MediaGroupMaxItems is the maximum number of items in a media group
+	MediaGroupMaxItems = 10
+
+	// MediaGroupMinItems is the minimum number of items in a media group
+	MediaGroupMinItems = 2
+
+	// delay between media groups sent with SendLargeMediaGroup()
+	mediaGroupChunkInterval = 1 * time.Second
+
+	// default time to wait for more messages of a media group after the last one
+	defaultMediaGroupWait = 1 * time.Second
+
+	// name of the handler of media groups, for HandlerError
+	mediaGroupHandlerName = "media_group"
+)
+
+// SplitMediaGroup splits given media into chunks which can be sent as media groups.
+//
+// Items are distributed evenly, so that no chunk has fewer than MediaGroupMinItems items. (eg. 11 => 6 + 5, not 10 + 1)
+func SplitMediaGroup(media []InputMedia) (chunks [][]InputMedia) {
+	if len(media) <= MediaGroupMaxItems {
+		return [][]InputMedia{media}
+	}
+
+	numChunks := (len(media) + MediaGroupMaxItems - 1) / MediaGroupMaxItems
+	size, extra := len(media)/numChunks, len(media)%numChunks
+
+	for i, start := 0, 0; i < numChunks; i++ {
+		end := start + size
+		if i < extra {
+			end++
+		}
+		chunks = append(chunks, media[start:end])
+		start = end
+	}
+	return chunks
+}
+
+// SendLargeMediaGroup sends media as multiple media groups, if there are more than MediaGroupMaxItems items.
+//
+// Media groups are sent sequentially with a short delay between them, and rate-limited (429) ones are retried
+// by the MethodPolicy of MethodClassSend (or MethodClassUpload with files to upload). (see SetMethodPolicy)
+// `reply_to_message_id` and `reply_parameters` are applied to the first media group only.
+//
+// If sending one of the media groups fails, the result will contain messages sent so far.
+func (b *Bot) SendLargeMediaGroup(chatID ChatID, media []InputMedia, options OptionsSendMediaGroup) (result APIResponse[[]Message]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	sent := []Message{}
+	for i, chunk := range SplitMediaGroup(media) {
+		chunkOptions := OptionsSendMediaGroup{}
+		for k, v := range options {
+			chunkOptions[k] = v
+		}
+		if i > 0 {
+			delete(chunkOptions, "reply_to_message_id")
+			delete(chunkOptions, "reply_parameters")
+
+			time.Sleep(mediaGroupChunkInterval)
+		}
+
+		res := b.SendMediaGroup(chatID, chunk, chunkOptions)
+		if !res.Ok {
+			return APIResponse[[]Message]{
+				Ok:          false,
+				Description: res.Description,
+				ErrorCode:   res.ErrorCode,
+				Parameters:  res.Parameters,
+				Result:      &sent,
+			}
+		}
+		sent = append(sent, *res.Result...)
+	}
+
+	return APIResponse[[]Message]{Ok: true, Result: &sent}
+}
+
+// a media group whose messages are being received
+type pendingMediaGroup struct {
+	bot      *Bot
+	update   Update // update of the first message
+	messages []Message
+	timer    *time.Timer
+}
+
+// buffer of messages of media groups, which are delivered together after a while
+type mediaGroupAggregator struct {
+	wait    time.Duration
+	handler func(ctx *Ctx, messages []Message) error
+
+	groups map[string]*pendingMediaGroup // by chat id and media group id
+	lock   sync.Mutex
+}
+
+// OnMediaGroup registers a handler for media groups (albums) in `message` updates.
+//
+// Messages of a media group arrive as separate updates, so they are buffered until no more message of the group
+// arrives for `wait` (default: 1 second), and passed to the handler together in the order of their message ids.
+// The handler is called in its own goroutine after the wait, with the update of the first message in Ctx,
+// and its errors (or panics) are reported to the handler error hook.
+//
+// Register it before other message handlers, as messages of media groups are consumed by it.
+//
+//	router.OnMediaGroup(0, func(ctx *Ctx, messages []Message) error {
+//		for _, message := range messages {
+//			if message.HasPhoto() {
+//				save(message.LargestPhoto())
+//			}
+//		}
+//		return messages[0].Reply(ctx.Bot, fmt.Sprintf("saved %d items", len(messages)), nil).Err()
+//	})
+func (r *Router) OnMediaGroup(wait time.Duration, handler func(ctx *Ctx, messages []Message) error) {
+	if wait <= 0 {
+		wait = defaultMediaGroupWait
+	}
+
+	aggregator := &mediaGroupAggregator{
+		wait:    wait,
+		handler: handler,
+		groups:  map[string]*pendingMediaGroup{},
+	}
+
+	r.add(func(update Update) (Handler, bool) {
+		if update.Message == nil || update.Message.MediaGroupID == nil {
+			return nil, false
+		}
+
+		return func(ctx *Ctx) error {
+			aggregator.add(ctx, *ctx.Update.Message)
+			return nil
+		}, true
+	})
+}
+
+// buffer a message of a media group, and (re)start the timer of the group
+func (a *mediaGroupAggregator) add(ctx *Ctx, message Message) {
+	key := fmt.Sprintf("%d:%s", message.Chat.ID, *message.MediaGroupID)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if group, exists := a.groups[key]; exists {
+		group.messages = append(group.messages, message)
+		group.timer.Reset(a.wait)
+		return
+	}
+
+	group := &pendingMediaGroup{
+		bot:      ctx.Bot,
+		update:   ctx.Update,
+		messages: []Message{message},
+	}
+	group.timer = time.AfterFunc(a.wait, func() { a.deliver(key) })
+	a.groups[key] = group
+}
+
+// deliver buffered messages of a media group to the handler
+func (a *mediaGroupAggregator) deliver(key string) {
+	a.lock.Lock()
+	group, exists := a.groups[key]
+	delete(a.groups, key)
+	a.lock.Unlock()
+
+	if !exists {
+		return // already delivered
+	}
+
+	messages := group.messages
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].MessageID < messages[j].MessageID })
+
+	b := group.bot
+	ctx := context.Background()
+	if b.updateTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.updateTimeout)
+		defer cancel()
+	}
+
+	c := &Ctx{
+		Context: ctx,
+		Bot:     b,
+		Update:  group.update,
+	}
+	if err := b.callHandler(c, namedHandler{
+		name:    mediaGroupHandlerName,
+		handler: func(ctx *Ctx) error { return a.handler(ctx, messages) },
+	}); err != nil {
+		b.reportHandlerError(err)
+	}
+}
@@ -3,16 +3,23 @@ package telegrambot
 // https://core.telegram.org/bots/api#available-methods
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // GetUpdates retrieves updates from Telegram bot API.
@@ -30,14 +37,21 @@ func (b *Bot) GetUpdates(options OptionsGetUpdates) (result APIResponse[[]Update
 //
 // `port` should be one of: 443, 80, 88, or 8443.
 //
+// If `secret_token` is not given, one derived from the bot token is used (the same one for all replicas of the bot). Once the webhook is set successfully, webhook requests are verified with it,
+// and ones with a mismatched `X-Telegram-Bot-Api-Secret-Token` header are rejected with 403. (see WebhookSecretToken())
+//
 // https://core.telegram.org/bots/api#setwebhook
 func (b *Bot) SetWebhook(host string, port int, options OptionsSetWebhook) (result APIResponse[bool]) {
+	url := b.getWebhookURL(host, port)
+
+	b.webhookLock.Lock()
 	b.webhookHost = host
 	b.webhookPort = port
-	b.webhookURL = b.getWebhookURL()
+	b.webhookURL = url
+	b.webhookLock.Unlock()
 
 	params := map[string]any{
-		"url": b.webhookURL,
+		"url": url,
 	}
 
 	if cert, exists := options["certificate"]; exists {
@@ -77,9 +91,29 @@ func (b *Bot) SetWebhook(host string, port int, options OptionsSetWebhook) (resu
 		params["drop_pending_updates"] = dropPendingUpdates
 	}
 
-	b.verbose("setting webhook url to: %s", b.webhookURL)
+	secretToken, _ := options["secret_token"].(string)
+	if secretToken == "" {
+		secretToken = b.defaultWebhookSecretToken()
+	}
+	params["secret_token"] = secretToken
+
+	b.webhookLock.Lock()
+	b.webhookPendingSecretToken = secretToken
+	b.webhookLock.Unlock()
+
+	b.verbose("setting webhook url to: %s", url)
+
+	result = b.requestBool("setWebhook", params)
 
-	return b.requestBool("setWebhook", params)
+	// NOTE: keep the old secret token until the new one is accepted, so updates in flight are not rejected
+	b.webhookLock.Lock()
+	b.webhookPendingSecretToken = ""
+	if result.Ok {
+		b.webhookSecretToken = secretToken
+	}
+	b.webhookLock.Unlock()
+
+	return result
 }
 
 // DeleteWebhook deletes webhook for this bot.
@@ -87,9 +121,12 @@ func (b *Bot) SetWebhook(host string, port int, options OptionsSetWebhook) (resu
 //
 // https://core.telegram.org/bots/api#deletewebhook
 func (b *Bot) DeleteWebhook(dropPendingUpdates bool) (result APIResponse[bool]) {
+	b.webhookLock.Lock()
 	b.webhookHost = ""
 	b.webhookPort = 0
 	b.webhookURL = ""
+	b.webhookSecretToken = ""
+	b.webhookLock.Unlock()
 
 	b.verbose("deleting webhook url")
 
@@ -138,7 +175,7 @@ func (b *Bot) SendMessage(chatID ChatID, text string, options OptionsSendMessage
 	options["chat_id"] = chatID
 	options["text"] = text
 
-	return b.requestMessage("sendMessage", options)
+	return b.requestMessageWithDocumentFallback(options)
 }
 
 // ForwardMessage forwards a message.
@@ -173,6 +210,42 @@ func (b *Bot) CopyMessage(chatID, fromChatID ChatID, messageID int64, options Op
 	return b.requestMessageID("copyMessage", options)
 }
 
+// ForwardMessages forwards multiple messages (1-100) at once, skipping ones which cannot be forwarded.
+//
+// Album grouping is kept for forwarded messages.
+//
+// https://core.telegram.org/bots/api#forwardmessages
+func (b *Bot) ForwardMessages(chatID, fromChatID ChatID, messageIDs []int64, options OptionsForwardMessages) (result APIResponse[[]MessageID]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	// essential params
+	options["chat_id"] = chatID
+	options["from_chat_id"] = fromChatID
+	options["message_ids"] = messageIDs
+
+	return b.requestMessageIDs("forwardMessages", options)
+}
+
+// CopyMessages copies multiple messages (1-100) at once, skipping ones which cannot be copied.
+//
+// Album grouping is kept for copied messages.
+//
+// https://core.telegram.org/bots/api#copymessages
+func (b *Bot) CopyMessages(chatID, fromChatID ChatID, messageIDs []int64, options OptionsCopyMessages) (result APIResponse[[]MessageID]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	// essential params
+	options["chat_id"] = chatID
+	options["from_chat_id"] = fromChatID
+	options["message_ids"] = messageIDs
+
+	return b.requestMessageIDs("copyMessages", options)
+}
+
 // SendPhoto sends a photo.
 //
 // https://core.telegram.org/bots/api#sendphoto
@@ -185,7 +258,7 @@ func (b *Bot) SendPhoto(chatID ChatID, photo InputFile, options OptionsSendPhoto
 	options["chat_id"] = chatID
 	options["photo"] = photo
 
-	return b.requestMessage("sendPhoto", options)
+	return b.requestMessageWithCaption("sendPhoto", options)
 }
 
 // SendAudio sends an audio file. (.mp3 format only, will be played with external players)
@@ -215,7 +288,7 @@ func (b *Bot) SendDocument(chatID ChatID, document InputFile, options OptionsSen
 	options["chat_id"] = chatID
 	options["document"] = document
 
-	return b.requestMessage("sendDocument", options)
+	return b.requestMessageWithCaption("sendDocument", options)
 }
 
 // SendSticker sends a sticker.
@@ -424,7 +497,7 @@ func (b *Bot) SendVideo(chatID ChatID, video InputFile, options OptionsSendVideo
 	options["chat_id"] = chatID
 	options["video"] = video
 
-	return b.requestMessage("sendVideo", options)
+	return b.requestMessageWithCaption("sendVideo", options)
 }
 
 // SendAnimation sends an animation.
@@ -459,7 +532,8 @@ func (b *Bot) SendVoice(chatID ChatID, voice InputFile, options OptionsSendVoice
 
 // SendVideoNote sends a video note.
 //
-// videoNote cannot be a remote http url (not supported yet)
+// As the API does not accept remote http urls for video notes, a videoNote with `URL` is downloaded
+// (up to 50MB) and uploaded again.
 //
 // https://core.telegram.org/bots/api#sendvideonote
 func (b *Bot) SendVideoNote(chatID ChatID, videoNote InputFile, options OptionsSendVideoNote) (result APIResponse[Message]) {
@@ -467,6 +541,20 @@ func (b *Bot) SendVideoNote(chatID ChatID, videoNote InputFile, options OptionsS
 		options = map[string]any{}
 	}
 
+	if videoNote.URL != nil {
+		filepath, err := b.downloadToTempFile(*videoNote.URL, maxReuploadFileSize)
+		if err != nil {
+			errStr := fmt.Sprintf("sendVideoNote failed with error: %s", err)
+
+			b.error(errStr)
+
+			return APIResponse[Message]{Ok: false, Description: &errStr}
+		}
+		defer os.Remove(filepath)
+
+		videoNote = InputFileFromFilepath(filepath)
+	}
+
 	// essential params
 	options["chat_id"] = chatID
 	options["video_note"] = videoNote
@@ -484,7 +572,7 @@ func (b *Bot) SendMediaGroup(chatID ChatID, media []InputMedia, options OptionsS
 
 	// essential params
 	options["chat_id"] = chatID
-	options["media"] = media
+	options["media"] = attachInputMedia(options, media)
 
 	return b.requestMessages("sendMediaGroup", options)
 }
@@ -492,7 +580,7 @@ func (b *Bot) SendMediaGroup(chatID ChatID, media []InputMedia, options OptionsS
 // SendLocation sends locations.
 //
 // https://core.telegram.org/bots/api#sendlocation
-func (b *Bot) SendLocation(chatID ChatID, latitude, longitude float32, options OptionsSendLocation) (result APIResponse[Message]) {
+func (b *Bot) SendLocation(chatID ChatID, latitude, longitude float64, options OptionsSendLocation) (result APIResponse[Message]) {
 	if options == nil {
 		options = map[string]any{}
 	}
@@ -508,7 +596,7 @@ func (b *Bot) SendLocation(chatID ChatID, latitude, longitude float32, options O
 // SendVenue sends venues.
 //
 // https://core.telegram.org/bots/api#sendvenue
-func (b *Bot) SendVenue(chatID ChatID, latitude, longitude float32, title, address string, options OptionsSendVenue) (result APIResponse[Message]) {
+func (b *Bot) SendVenue(chatID ChatID, latitude, longitude float64, title, address string, options OptionsSendVenue) (result APIResponse[Message]) {
 	if options == nil {
 		options = map[string]any{}
 	}
@@ -599,6 +687,40 @@ func (b *Bot) SendChatAction(chatID ChatID, action ChatAction, options OptionsSe
 	return b.requestBool("sendChatAction", options)
 }
 
+// SetMessageReaction changes the chosen reactions on a message.
+//
+// https://core.telegram.org/bots/api#setmessagereaction
+func (b *Bot) SetMessageReaction(chatID ChatID, messageID int64, options OptionsSetMessageReaction) (result APIResponse[bool]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	// essential params
+	options["chat_id"] = chatID
+	options["message_id"] = messageID
+
+	return b.requestBool("setMessageReaction", options)
+}
+
+// GetUserChatBoosts gets the boosts added to a chat by a user. (requires administrator rights in the chat)
+//
+// https://core.telegram.org/bots/api#getuserchatboosts
+func (b *Bot) GetUserChatBoosts(chatID ChatID, userID int64) (result APIResponse[UserChatBoosts]) {
+	return b.requestUserChatBoosts("getUserChatBoosts", map[string]any{
+		"chat_id": chatID,
+		"user_id": userID,
+	})
+}
+
+// GetBusinessConnection gets the connection of the bot with a business account.
+//
+// https://core.telegram.org/bots/api#getbusinessconnection
+func (b *Bot) GetBusinessConnection(businessConnectionID string) (result APIResponse[BusinessConnection]) {
+	return b.requestBusinessConnection("getBusinessConnection", map[string]any{
+		"business_connection_id": businessConnectionID,
+	})
+}
+
 // GetUserProfilePhotos gets user profile photos.
 //
 // https://core.telegram.org/bots/api#getuserprofilephotos
@@ -626,8 +748,11 @@ func (b *Bot) GetFile(fileID string) (result APIResponse[File]) {
 }
 
 // GetFileURL gets download link from a given File.
+//
+// With a local Bot API server, absolute paths of files are converted to paths relative to the bot's directory
+// (see SetLocalServer), or use OpenFile() for reading them from the disk.
 func (b *Bot) GetFileURL(file File) string {
-	return fmt.Sprintf("%s%s/%s", fileBaseURL, b.token, *file.FilePath)
+	return fmt.Sprintf("%s%s/%s", b.fileBaseURL, b.token, b.relativeFilePath(*file.FilePath))
 }
 
 // BanChatMember bans a chat member.
@@ -926,7 +1051,9 @@ func (b *Bot) GetChat(chatID ChatID) (result APIResponse[Chat]) {
 		"chat_id": chatID,
 	}
 
-	return b.requestChat("getChat", params)
+	return cachedRequest(b, chatCacheKey(chatID), func() APIResponse[Chat] {
+		return b.requestChat("getChat", params)
+	})
 }
 
 // GetChatAdministrators gets chat administrators.
@@ -938,7 +1065,9 @@ func (b *Bot) GetChatAdministrators(chatID ChatID) (result APIResponse[[]ChatMem
 		"chat_id": chatID,
 	}
 
-	return b.requestChatMembers("getChatAdministrators", params)
+	return cachedRequest(b, chatAdminsCacheKey(chatID), func() APIResponse[[]ChatMember] {
+		return b.requestChatMembers("getChatAdministrators", params)
+	})
 }
 
 // GetChatMemberCount gets chat members' count.
@@ -963,7 +1092,9 @@ func (b *Bot) GetChatMember(chatID ChatID, userID int64) (result APIResponse[Cha
 		"user_id": userID,
 	}
 
-	return b.requestChatMember("getChatMember", params)
+	return cachedRequest(b, chatMemberCacheKey(chatID, userID), func() APIResponse[ChatMember] {
+		return b.requestChatMember("getChatMember", params)
+	})
 }
 
 // SetChatStickerSet sets a chat sticker set.
@@ -1148,7 +1279,7 @@ func (b *Bot) EditMessageMedia(media InputMedia, options OptionsEditMessageMedia
 	}
 
 	// essential params
-	options["media"] = media
+	options["media"] = attachInputMedia(options, []InputMedia{media})[0]
 
 	return b.requestMessageOrBool("editMessageMedia", options)
 }
@@ -1163,7 +1294,7 @@ func (b *Bot) EditMessageReplyMarkup(options OptionsEditMessageReplyMarkup) (res
 // EditMessageLiveLocation edits live location of a message.
 //
 // https://core.telegram.org/bots/api#editmessagelivelocation
-func (b *Bot) EditMessageLiveLocation(latitude, longitude float32, options OptionsEditMessageLiveLocation) (result APIResponseMessageOrBool) {
+func (b *Bot) EditMessageLiveLocation(latitude, longitude float64, options OptionsEditMessageLiveLocation) (result APIResponseMessageOrBool) {
 	if options == nil {
 		options = map[string]any{}
 	}
@@ -1192,6 +1323,16 @@ func (b *Bot) DeleteMessage(chatID ChatID, messageID int64) (result APIResponse[
 	})
 }
 
+// DeleteMessages deletes multiple messages (1-100) at once, skipping ones which cannot be deleted.
+//
+// https://core.telegram.org/bots/api#deletemessages
+func (b *Bot) DeleteMessages(chatID ChatID, messageIDs []int64) (result APIResponse[bool]) {
+	return b.requestBool("deleteMessages", map[string]any{
+		"chat_id":     chatID,
+		"message_ids": messageIDs,
+	})
+}
+
 // AnswerInlineQuery sends answers to an inline query.
 //
 // results = array of InlineQueryResultArticle, InlineQueryResultPhoto, InlineQueryResultGif, InlineQueryResultMpeg4Gif, or InlineQueryResultVideo.
@@ -1211,8 +1352,12 @@ func (b *Bot) AnswerInlineQuery(inlineQueryID string, results []any, options Opt
 
 // SendInvoice sends an invoice.
 //
+// `chatID` can be a numeric chat id (int64) or a channel username. (eg. "@channelusername")
+//
 // https://core.telegram.org/bots/api#sendinvoice
-func (b *Bot) SendInvoice(chatID int64, title, description, payload, providerToken, currency string, prices []LabeledPrice, options OptionsSendInvoice) (result APIResponse[Message]) {
+//
+// Deprecated: use SendInvoiceWithBuilder() instead, which validates the invoice before sending it.
+func (b *Bot) SendInvoice(chatID ChatID, title, description, payload, providerToken, currency string, prices []LabeledPrice, options OptionsSendInvoice) (result APIResponse[Message]) {
 	if options == nil {
 		options = map[string]any{}
 	}
@@ -1497,7 +1642,49 @@ func checkIfFileParamExists(params map[string]any) bool {
 		case *os.File, []byte:
 			return true
 		case InputFile:
-			if len(val.Bytes) > 0 || val.Filepath != nil {
+			if val.uploadable() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// copy given media with their files to upload attached to params as multipart parts, referenced with "attach://<name>"
+func attachInputMedia(params map[string]any, media []InputMedia) []InputMedia {
+	attached := make([]InputMedia, len(media))
+	for i, m := range media {
+		if m.MediaFile != nil {
+			if m.MediaFile.uploadable() {
+				name := fmt.Sprintf("media%d", i)
+				params[name] = *m.MediaFile
+				m.Media = "attach://" + name
+			} else if m.MediaFile.FileID != nil {
+				m.Media = *m.MediaFile.FileID
+			} else if m.MediaFile.URL != nil {
+				m.Media = *m.MediaFile.URL
+			}
+		}
+		if m.Thumbnail != nil && m.Thumbnail.uploadable() {
+			name := fmt.Sprintf("thumbnail%d", i)
+			params[name] = *m.Thumbnail
+			reference := "attach://" + name
+			m.Thumbnail = &InputFile{URL: &reference}
+		}
+		attached[i] = m
+	}
+	return attached
+}
+
+// Check if given http params contain *os.File or InputFile with Reader, which cannot be read again.
+func checkIfOneShotFileParamExists(params map[string]any) bool {
+	for _, value := range params {
+		switch val := value.(type) {
+		case *os.File:
+			return true
+		case InputFile:
+			if val.Reader != nil {
 				return true
 			}
 		}
@@ -1506,6 +1693,30 @@ func checkIfFileParamExists(params map[string]any) bool {
 	return false
 }
 
+// maximum capacity of buffers to be returned to the pool
+const maxPooledBufferSize = 1 << 20
+
+// pool of Updates for decoding webhook requests
+var _updatePool = sync.Pool{
+	New: func() any {
+		return new(Update)
+	},
+}
+
+// pool of buffers for encoding params as JSON and reading webhook requests
+var _bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// return given buffer to the pool (unless it has grown too large)
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBufferSize {
+		_bufferPool.Put(buf)
+	}
+}
+
 // Convert given interface to string. (for HTTP params)
 func (b *Bot) paramToString(param any) (result string, success bool) {
 	switch val := param.(type) {
@@ -1513,8 +1724,10 @@ func (b *Bot) paramToString(param any) (result string, success bool) {
 		return strconv.Itoa(val), true
 	case int64:
 		return strconv.FormatInt(val, 10), true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true // shortest representation with full precision
 	case float32:
-		return fmt.Sprintf("%.8f", val), true
+		return strconv.FormatFloat(float64(val), 'f', -1, 32), true
 	case bool:
 		return strconv.FormatBool(val), true
 	case string:
@@ -1532,9 +1745,22 @@ func (b *Bot) paramToString(param any) (result string, success bool) {
 		}
 		b.error("parameter '%+v' could not be cast to string value", param)
 	default: // fallback: encode to JSON string
-		json, err := json.Marshal(param)
+		if b.codec != nil {
+			json, err := b.codec.Marshal(param)
+			if err == nil {
+				return string(json), true
+			}
+			b.error("parameter '%+v' could not be encoded as json: %s", param, err)
+			break
+		}
+
+		buf := _bufferPool.Get().(*bytes.Buffer)
+		defer putBuffer(buf)
+		buf.Reset()
+
+		err := json.NewEncoder(buf).Encode(param)
 		if err == nil {
-			return string(json), true
+			return string(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})), true
 		}
 		b.error("parameter '%+v' could not be encoded as json: %s", param, err)
 	}
@@ -1546,16 +1772,91 @@ func (b *Bot) paramToString(param any) (result string, success bool) {
 //
 // NOTE: If *os.File is included in the params, it will be closed automatically by this function.
 func (b *Bot) request(method string, params map[string]any) (resp []byte, err error) {
-	apiURL := fmt.Sprintf("%s%s/%s", apiBaseURL, b.token, method)
+	apiURL := b.currentAPIBaseURL() + b.token + "/" + method
+
+	b.substituteResolvedChatIDs(params)
+	formatMarkdownV2Params(params)
+	if b.markdownV2AutoEscape {
+		fixMarkdownV2Params(params)
+	}
+	if b.payloadLimits != nil {
+		if err := b.payloadLimits.validate(method, params); err != nil {
+			b.verbose("%s", err)
+			return []byte{}, err
+		}
+	}
 
 	b.verbose("sending request to api url: %s, params: %#v", apiURL, params)
 
-	if checkIfFileParamExists(params) {
-		// multipart form data
-		resp, err = b.requestMultipartFormData(apiURL, params)
-	} else {
-		// www-form urlencoded
-		resp, err = b.requestURLEncodedFormData(apiURL, params)
+	var debugParams string
+	if b.Debug {
+		debugParams = b.paramsForDebug(params) // NOTE: dump params before files are consumed
+	}
+	var auditParams map[string]string
+	hooked := b.hasRequestHooks()
+	if b.auditSink != nil || b.liveStream != nil || hooked {
+		auditParams = b.dumpParams(params) // NOTE: dump params before files are consumed
+	}
+	if hooked {
+		b.callBeforeRequestHooks(method, auditParams)
+	}
+	started := time.Now()
+
+	class := b.methodClass(method, params)
+	policy := b.MethodPolicy(class)
+	multipart := checkIfFileParamExists(params)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 { // NOTE: api server may have been changed by failover
+			apiURL = b.currentAPIBaseURL() + b.token + "/" + method
+		}
+		b.waitChatRateLimit(class, params)
+		b.waitRateLimit(policy.RateLimitBucket)
+
+		ctx, cancel := policy.context(class, params)
+		if multipart {
+			// multipart form data
+			resp, err = b.requestMultipartFormDataContext(ctx, apiURL, params)
+		} else {
+			// www-form urlencoded
+			resp, err = b.requestURLEncodedFormDataContext(ctx, apiURL, params)
+		}
+		cancel()
+
+		if b.failover != nil {
+			b.reportAPIResult(resp, err)
+		}
+
+		if attempt >= policy.MaxRetries || checkIfOneShotFileParamExists(params) { // NOTE: *os.File and io.Reader params cannot be read again
+			break
+		}
+		delay, retry := policy.retryDelay(b.jsonCodec(), resp, err, attempt)
+		if !retry {
+			break
+		}
+
+		b.verbose("retrying %s after %s (%d/%d)", method, delay, attempt+1, policy.MaxRetries)
+
+		time.Sleep(delay)
+	}
+
+	if b.logger != nil {
+		b.logRequest(method, params, debugParams, time.Since(started), resp, err)
+	} else if b.Debug {
+		id := atomic.AddUint64(&_debugRequestID, 1)
+		elapsed := time.Since(started)
+
+		if err == nil {
+			b.debug("[debug #%d] %s (%s) params: %s, response: %s", id, method, elapsed, debugParams, string(resp))
+		} else {
+			b.debug("[debug #%d] %s (%s) params: %s, error: %s", id, method, elapsed, debugParams, err)
+		}
+	}
+
+	if b.auditSink != nil || b.liveStream != nil {
+		b.audit(method, auditParams, started, resp, err)
+	}
+	if hooked {
+		b.callAfterResponseHooks(method, auditParams, started, resp, err)
 	}
 
 	if err == nil {
@@ -1565,114 +1866,112 @@ func (b *Bot) request(method string, params map[string]any) (resp []byte, err er
 	return []byte{}, fmt.Errorf(b.redact(err.Error()))
 }
 
-// request multipart form data
-func (b *Bot) requestMultipartFormData(apiURL string, params map[string]any) (resp []byte, err error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+// dump given params as a JSON object for debugging, with files elided
+func (b *Bot) paramsForDebug(params map[string]any) string {
+	var sb strings.Builder
+	encoder := json.NewEncoder(&sb)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(b.dumpParams(params))
+
+	return strings.TrimSpace(sb.String())
+}
 
+// dump given params as strings, with files elided
+func (b *Bot) dumpParams(params map[string]any) map[string]string {
+	dumped := map[string]string{}
 	for key, value := range params {
 		switch val := value.(type) {
 		case *os.File:
-			defer val.Close() // XXX - close the file
-
-			var part io.Writer
-			part, err = writer.CreateFormFile(key, val.Name())
-			if err == nil {
-				if _, err = io.Copy(part, val); err != nil {
-					b.error("could not write to multipart: %s", key)
-				}
-			} else {
-				b.error("could not create form file for parameter '%s' (%v)", key, value)
-			}
+			dumped[key] = fmt.Sprintf("<file: %s>", val.Name())
 		case []byte:
-			if fbytes, ok := value.([]byte); ok {
-				filename := fmt.Sprintf("%s.%s", key, getExtension(fbytes))
-				var part io.Writer
-				part, err = writer.CreateFormFile(key, filename)
-				if err == nil {
-					if _, err = io.Copy(part, bytes.NewReader(fbytes)); err != nil {
-						b.error("could not write to multipart: %s", key)
-					}
-				} else {
-					b.error("could not create form file for parameter '%s' ([]byte)", key)
-				}
-			} else {
-				b.error("parameter '%s' could not be cast to []byte", key)
-			}
+			dumped[key] = fmt.Sprintf("<%d bytes>", len(val))
 		case InputFile:
-			if inputFile, ok := value.(InputFile); ok {
-				if inputFile.Filepath != nil {
-					var file *os.File
-					if file, err = os.Open(*inputFile.Filepath); err == nil {
-						defer file.Close()
-
-						var part io.Writer
-						part, err = writer.CreateFormFile(key, file.Name())
-						if err == nil {
-							if _, err = io.Copy(part, file); err != nil {
-								b.error("could not write to multipart: %s", key)
-							}
-						} else {
-							b.error("could not create form file for parameter '%s' (%v)", key, value)
-						}
-					} else {
-						b.error("parameter '%s' (%v) could not be read from file: %s", key, value, err.Error())
-					}
-				} else if len(inputFile.Bytes) > 0 {
-					filename := fmt.Sprintf("%s.%s", key, getExtension(inputFile.Bytes))
-					var part io.Writer
-					part, err = writer.CreateFormFile(key, filename)
-					if err == nil {
-						if _, err = io.Copy(part, bytes.NewReader(inputFile.Bytes)); err != nil {
-							b.error("could not write InputFile to multipart: %s", key)
-						}
-					} else {
-						b.error("could not create form file for parameter '%s' (InputFile)", key)
-					}
-				} else {
-					if strValue, ok := b.paramToString(value); ok {
-						if err := writer.WriteField(key, strValue); err != nil {
-							b.error("failed to write field with key: %s, value: %s (%s)", key, strValue, err)
-						}
-					} else {
-						b.error("invalid InputFile parameter '%s'", key)
-					}
-				}
-			} else {
-				b.error("parameter '%s' could not be cast to InputFile", key)
+			if val.Filepath != nil {
+				dumped[key] = fmt.Sprintf("<file: %s>", *val.Filepath)
+			} else if len(val.Bytes) > 0 {
+				dumped[key] = fmt.Sprintf("<%d bytes>", len(val.Bytes))
+			} else if val.Reader != nil {
+				dumped[key] = "<reader>"
+			} else if str, ok := b.paramToString(val); ok {
+				dumped[key] = str
 			}
 		default:
-			if strValue, ok := b.paramToString(value); ok {
-				if err := writer.WriteField(key, strValue); err != nil {
-					b.error("failed to write filed with key: %s, value: %s (%s)", key, strValue, err)
-				}
+			if str, ok := b.paramToString(value); ok {
+				dumped[key] = str
 			}
 		}
 	}
 
-	if err = writer.Close(); err != nil {
-		b.error("error while closing writer (%s)", err)
+	return dumped
+}
+
+// request multipart form data
+func (b *Bot) requestMultipartFormData(apiURL string, params map[string]any) (resp []byte, err error) {
+	return b.requestMultipartFormDataContext(context.Background(), apiURL, params)
+}
+
+// request multipart form data with given context
+//
+// The body is streamed to the server while it is written, so uploaded files are not held in memory.
+func (b *Bot) requestMultipartFormDataContext(ctx context.Context, apiURL string, params map[string]any) (resp []byte, err error) {
+	parts, err := b.multipartParts(params)
+	defer func() {
+		for _, value := range params {
+			switch val := value.(type) {
+			case *os.File:
+				_ = val.Close() // XXX - close the file
+			case InputFile:
+				if closer, ok := val.Reader.(io.Closer); ok {
+					_ = closer.Close()
+				}
+			}
+		}
+		for _, part := range parts {
+			if part.file != nil {
+				_ = part.file.Close()
+			}
+		}
+	}()
+	if err != nil {
+		err = fmt.Errorf("building request error: %w", err)
+
+		b.error(err.Error())
+
+		return []byte{}, err
 	}
 
+	bodyReader, bodyWriter := io.Pipe()
+	writer := multipart.NewWriter(bodyWriter)
+
 	var req *http.Request
-	req, err = http.NewRequest("POST", apiURL, body)
+	req, err = http.NewRequestWithContext(ctx, "POST", apiURL, bodyReader)
 	if err == nil {
 		req.Header.Add("Content-Type", writer.FormDataContentType()) // due to file parameter
-		req.Close = true
+		req.ContentLength = multipartContentLength(parts, writer.Boundary())
+
+		written := make(chan struct{})
+		go func() {
+			defer close(written)
+
+			_ = bodyWriter.CloseWithError(b.writeMultipartParts(writer, parts))
+		}()
 
 		var resp *http.Response
 		resp, err = b.httpClient.Do(req)
 
+		// NOTE: stop writing the body if the request ended before it was fully sent
+		_ = bodyReader.CloseWithError(io.ErrUnexpectedEOF)
+		<-written
+
 		if resp != nil { // XXX - in case of http redirect
 			defer resp.Body.Close()
 		}
 
 		if err == nil {
-			// FIXXX: check http status code here
 			var bytes []byte
 			bytes, err = io.ReadAll(resp.Body)
 			if err == nil {
-				return bytes, nil
+				return b.checkHTTPStatus(resp, bytes), nil
 			}
 
 			err = fmt.Errorf("response read error: %w", err)
@@ -1684,6 +1983,8 @@ func (b *Bot) requestMultipartFormData(apiURL string, params map[string]any) (re
 			b.error(err.Error())
 		}
 	} else {
+		_ = bodyReader.Close()
+
 		err = fmt.Errorf("building request error: %w", err)
 
 		b.error(err.Error())
@@ -1692,22 +1993,188 @@ func (b *Bot) requestMultipartFormData(apiURL string, params map[string]any) (re
 	return []byte{}, err
 }
 
-// request urlencoded form data
-func (b *Bot) requestURLEncodedFormData(apiURL string, params map[string]any) (resp []byte, err error) {
-	paramValues := url.Values{}
+// number of bytes for detecting content types of readers (see http.DetectContentType())
+const multipartSniffLength = 512
+
+// a part of multipart form data
+type multipartPart struct {
+	key   string
+	value string // value of a field (when `content` is nil)
+
+	filename string
+	content  io.Reader // content of a file
+	size     int64     // size of `content` (-1 if unknown)
+	file     *os.File  // file opened from a path, to be closed after the request (if any)
+}
+
+// parts of multipart form data for given params, with files opened (but not read yet)
+func (b *Bot) multipartParts(params map[string]any) (parts []multipartPart, err error) {
+	for key, value := range params {
+		switch val := value.(type) {
+		case *os.File:
+			parts = append(parts, multipartPart{key: key, filename: val.Name(), content: val, size: fileSize(val)})
+		case []byte:
+			filename := fmt.Sprintf("%s.%s", key, getExtension(val))
+			parts = append(parts, multipartPart{key: key, filename: filename, content: bytes.NewReader(val), size: int64(len(val))})
+		case InputFile:
+			if val.Filepath != nil {
+				var file *os.File
+				if file, err = os.Open(*val.Filepath); err != nil {
+					return parts, fmt.Errorf("parameter '%s' could not be read from file: %w", key, err)
+				}
+				parts = append(parts, multipartPart{key: key, filename: file.Name(), content: file, size: fileSize(file), file: file})
+			} else if val.Reader != nil {
+				content, size := val.Reader, val.Size
+				if size <= 0 {
+					size = -1
+				}
+
+				var filename string
+				if val.Filename != nil {
+					filename = *val.Filename
+				} else { // NOTE: peek the beginning of the content for detecting its type
+					buffered := bufio.NewReaderSize(val.Reader, multipartSniffLength)
+					head, _ := buffered.Peek(multipartSniffLength)
+					filename = fmt.Sprintf("%s.%s", key, getExtension(head))
+					content = buffered
+				}
+				parts = append(parts, multipartPart{key: key, filename: filename, content: content, size: size})
+			} else if len(val.Bytes) > 0 {
+				filename := fmt.Sprintf("%s.%s", key, getExtension(val.Bytes))
+				if val.Filename != nil {
+					filename = *val.Filename
+				}
+				parts = append(parts, multipartPart{key: key, filename: filename, content: bytes.NewReader(val.Bytes), size: int64(len(val.Bytes))})
+			} else if strValue, ok := b.paramToString(value); ok {
+				parts = append(parts, multipartPart{key: key, value: strValue})
+			} else {
+				b.error("invalid InputFile parameter '%s'", key)
+			}
+		default:
+			if strValue, ok := b.paramToString(value); ok {
+				parts = append(parts, multipartPart{key: key, value: strValue})
+			}
+		}
+	}
+
+	return parts, nil
+}
+
+// write given parts with the multipart writer, and close it
+func (b *Bot) writeMultipartParts(writer *multipart.Writer, parts []multipartPart) (err error) {
+	for _, part := range parts {
+		if part.content == nil {
+			if err = writer.WriteField(part.key, part.value); err != nil {
+				return fmt.Errorf("failed to write field with key: %s, value: %s (%w)", part.key, part.value, err)
+			}
+			continue
+		}
+
+		var w io.Writer
+		if w, err = writer.CreateFormFile(part.key, part.filename); err != nil {
+			return fmt.Errorf("could not create form file for parameter '%s' (%w)", part.key, err)
+		}
+		if _, err = io.Copy(w, part.content); err != nil {
+			return fmt.Errorf("could not write to multipart: %s (%w)", part.key, err)
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("error while closing writer (%w)", err)
+	}
+
+	return nil
+}
+
+// length of multipart form data of given parts with given boundary (-1 if sizes of some files are unknown)
+func multipartContentLength(parts []multipartPart, boundary string) int64 {
+	counter := &countingWriter{}
+	writer := multipart.NewWriter(counter)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return -1
+	}
+
+	var contents int64
+	for _, part := range parts {
+		if part.content == nil {
+			_ = writer.WriteField(part.key, part.value)
+			continue
+		}
+
+		if part.size < 0 {
+			return -1
+		}
+		_, _ = writer.CreateFormFile(part.key, part.filename)
+		contents += part.size
+	}
+	_ = writer.Close()
+
+	return counter.n + contents
+}
+
+// size of given file (-1 if unknown, eg. pipes)
+func fileSize(file *os.File) int64 {
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+		if offset, err := file.Seek(0, io.SeekCurrent); err == nil {
+			return info.Size() - offset
+		}
+	}
+	return -1
+}
+
+// writer which only counts written bytes
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// encode given params as urlencoded form data
+//
+// (same as url.Values.Encode(), but without intermediate url.Values)
+func (b *Bot) urlEncodedParams(params map[string]any) string {
+	keys := make([]string, 0, len(params))
+	values := make(map[string]string, len(params))
+	size := 0
 	for key, value := range params {
 		if strValue, ok := b.paramToString(value); ok {
-			paramValues[key] = []string{strValue}
+			keys = append(keys, key)
+			values[key] = strValue
+			size += len(key) + len(strValue) + 2
+		}
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.Grow(size + size/4) // with some room for escaped characters
+	for i, key := range keys {
+		if i > 0 {
+			sb.WriteByte('&')
 		}
+		sb.WriteString(url.QueryEscape(key))
+		sb.WriteByte('=')
+		sb.WriteString(url.QueryEscape(values[key]))
 	}
-	encoded := paramValues.Encode()
+	return sb.String()
+}
+
+// request urlencoded form data
+func (b *Bot) requestURLEncodedFormData(apiURL string, params map[string]any) (resp []byte, err error) {
+	return b.requestURLEncodedFormDataContext(context.Background(), apiURL, params)
+}
+
+// request urlencoded form data with given context
+func (b *Bot) requestURLEncodedFormDataContext(ctx context.Context, apiURL string, params map[string]any) (resp []byte, err error) {
+	encoded := b.urlEncodedParams(params)
 
 	var req *http.Request
-	req, err = http.NewRequest("POST", apiURL, bytes.NewBufferString(encoded))
+	req, err = http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(encoded))
 	if err == nil {
 		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 		req.Header.Add("Content-Length", strconv.Itoa(len(encoded)))
-		req.Close = true
 
 		var resp *http.Response
 		resp, err = b.httpClient.Do(req)
@@ -1717,11 +2184,10 @@ func (b *Bot) requestURLEncodedFormData(apiURL string, params map[string]any) (r
 		}
 
 		if err == nil {
-			// FIXXX: check http status code here
 			var bytes []byte
 			bytes, err = io.ReadAll(resp.Body)
 			if err == nil {
-				return bytes, nil
+				return b.checkHTTPStatus(resp, bytes), nil
 			}
 
 			err = fmt.Errorf("response read error: %w", err)
@@ -1741,14 +2207,74 @@ func (b *Bot) requestURLEncodedFormData(apiURL string, params map[string]any) (r
 	return []byte{}, err
 }
 
+// maximum length of non-JSON bodies of failed responses, included in their descriptions
+const maxErrorBodySnippetLength = 200
+
+// check the http status code of a response, and return its body as a JSON API response
+//
+// Telegram returns JSON bodies with `error_code` for failed requests, but proxies or servers in trouble may not,
+// so bodies of non-2xx responses which are not API responses are replaced with ones of their status codes.
+// `retry_after` is filled from the `Retry-After` header, if the body does not have it.
+func (b *Bot) checkHTTPStatus(resp *http.Response, body []byte) []byte {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return body
+	}
+
+	retryAfter := retryAfterHeader(resp.Header)
+
+	var res APIResponse[json.RawMessage]
+	if err := b.jsonCodec().Unmarshal(body, &res); err != nil || res.Ok || res.ErrorCode == 0 {
+		description := fmt.Sprintf("%s (http status %d)", http.StatusText(resp.StatusCode), resp.StatusCode)
+		if snippet := strings.TrimSpace(string(body)); snippet != "" {
+			if runes := []rune(snippet); len(runes) > maxErrorBodySnippetLength {
+				snippet = string(runes[:maxErrorBodySnippetLength]) + "..."
+			}
+			description += ": " + snippet
+		}
+		res = APIResponse[json.RawMessage]{Ok: false, ErrorCode: resp.StatusCode, Description: &description}
+	} else if retryAfter == 0 || (res.Parameters != nil && res.Parameters.RetryAfter > 0) {
+		return body // nothing to fill
+	}
+
+	if retryAfter > 0 && (res.Parameters == nil || res.Parameters.RetryAfter == 0) {
+		if res.Parameters == nil {
+			res.Parameters = &APIResponseParameters{}
+		}
+		res.Parameters.RetryAfter = retryAfter
+	}
+
+	if normalized, err := b.jsonCodec().Marshal(res); err == nil {
+		return normalized
+	}
+	return body
+}
+
+// seconds in the `Retry-After` header (in seconds or an http date), 0 if none
+func retryAfterHeader(header http.Header) int {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return seconds
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if seconds := int(math.Ceil(time.Until(date).Seconds())); seconds > 0 {
+			return seconds
+		}
+	}
+	return 0
+}
+
 // Send request for APIResponse[WebhookInfo] and fetch its result.
 func (b *Bot) requestWebhookInfo(method string, params map[string]any) (result APIResponse[WebhookInfo]) {
 	var errStr string
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[WebhookInfo]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1768,8 +2294,9 @@ func (b *Bot) requestUser(method string, params map[string]any) (result APIRespo
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[User]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1785,12 +2312,31 @@ func (b *Bot) requestUser(method string, params map[string]any) (result APIRespo
 
 // Send request for APIResponse[Message] and fetch its result.
 func (b *Bot) requestMessage(method string, params map[string]any) (result APIResponse[Message]) {
+	if result, ok := b.requestMessageIdempotently(method, params); ok {
+		return result
+	}
+	if result, ok := b.requestMessageWithFileID(method, params); ok {
+		return result
+	}
+
+	return b.requestMessageOnce(method, params)
+}
+
+// Send request for APIResponse[Message] once and fetch its result.
+func (b *Bot) requestMessageOnce(method string, params map[string]any) (result APIResponse[Message]) {
 	var errStr string
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[Message]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
+			if b.archiver != nil && jsonResponse.Ok && jsonResponse.Result != nil {
+				if err := b.archiver.archiveOutgoing(method, *jsonResponse.Result); err != nil {
+					b.error("failed to archive sent message: %s", err)
+				}
+			}
+
 			return jsonResponse
 		}
 
@@ -1810,8 +2356,9 @@ func (b *Bot) requestMessages(method string, params map[string]any) (result APIR
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[[]Message]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1831,8 +2378,9 @@ func (b *Bot) requestMessageID(method string, params map[string]any) (result API
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[MessageID]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1846,14 +2394,81 @@ func (b *Bot) requestMessageID(method string, params map[string]any) (result API
 	return APIResponse[MessageID]{Ok: false, Description: &errStr}
 }
 
+// Send request for APIResponse[UserChatBoosts] and fetch its result.
+func (b *Bot) requestUserChatBoosts(method string, params map[string]any) (result APIResponse[UserChatBoosts]) {
+	var errStr string
+
+	if bytes, err := b.request(method, params); err == nil {
+		var jsonResponse APIResponse[UserChatBoosts]
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
+		if err == nil {
+			jsonResponse.raw = bytes
+			return jsonResponse
+		}
+
+		errStr = fmt.Sprintf("json parse error: %s (%s)", err, string(bytes))
+	} else {
+		errStr = fmt.Sprintf("%s failed with error: %s", method, err)
+	}
+
+	b.error(errStr)
+
+	return APIResponse[UserChatBoosts]{Ok: false, Description: &errStr}
+}
+
+// Send request for APIResponse[BusinessConnection] and fetch its result.
+func (b *Bot) requestBusinessConnection(method string, params map[string]any) (result APIResponse[BusinessConnection]) {
+	var errStr string
+
+	if bytes, err := b.request(method, params); err == nil {
+		var jsonResponse APIResponse[BusinessConnection]
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
+		if err == nil {
+			jsonResponse.raw = bytes
+			return jsonResponse
+		}
+
+		errStr = fmt.Sprintf("json parse error: %s (%s)", err, string(bytes))
+	} else {
+		errStr = fmt.Sprintf("%s failed with error: %s", method, err)
+	}
+
+	b.error(errStr)
+
+	return APIResponse[BusinessConnection]{Ok: false, Description: &errStr}
+}
+
+// Send request for APIResponse[[]MessageID] and fetch its result.
+func (b *Bot) requestMessageIDs(method string, params map[string]any) (result APIResponse[[]MessageID]) {
+	var errStr string
+
+	if bytes, err := b.request(method, params); err == nil {
+		var jsonResponse APIResponse[[]MessageID]
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
+		if err == nil {
+			jsonResponse.raw = bytes
+			return jsonResponse
+		}
+
+		errStr = fmt.Sprintf("json parse error: %s (%s)", err, string(bytes))
+	} else {
+		errStr = fmt.Sprintf("%s failed with error: %s", method, err)
+	}
+
+	b.error(errStr)
+
+	return APIResponse[[]MessageID]{Ok: false, Description: &errStr}
+}
+
 // Send request for APIResponse[UserProfilePhotos] and fetch its result.
 func (b *Bot) requestUserProfilePhotos(method string, params map[string]any) (result APIResponse[UserProfilePhotos]) {
 	var errStr string
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[UserProfilePhotos]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1873,8 +2488,9 @@ func (b *Bot) requestUpdates(method string, params map[string]any) (result APIRe
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[[]Update]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1894,8 +2510,9 @@ func (b *Bot) requestFile(method string, params map[string]any) (result APIRespo
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[File]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1915,8 +2532,9 @@ func (b *Bot) requestChat(method string, params map[string]any) (result APIRespo
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[Chat]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1936,8 +2554,9 @@ func (b *Bot) requestChatMembers(method string, params map[string]any) (result A
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[[]ChatMember]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1957,8 +2576,9 @@ func (b *Bot) requestChatMember(method string, params map[string]any) (result AP
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[ChatMember]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1978,8 +2598,9 @@ func (b *Bot) requestInt(method string, params map[string]any) (result APIRespon
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[int]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -1999,8 +2620,9 @@ func (b *Bot) requestBool(method string, params map[string]any) (result APIRespo
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[bool]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2020,8 +2642,9 @@ func (b *Bot) requestString(method string, params map[string]any) (result APIRes
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[string]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2041,8 +2664,9 @@ func (b *Bot) requestGameHighScores(method string, params map[string]any) (resul
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[[]GameHighScore]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2062,8 +2686,9 @@ func (b *Bot) requestSentWebAppMessage(method string, params map[string]any) (re
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[SentWebAppMessage]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2083,8 +2708,9 @@ func (b *Bot) requestStickerSet(method string, params map[string]any) (result AP
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[StickerSet]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2104,8 +2730,9 @@ func (b *Bot) requestStickers(method string, params map[string]any) (result APIR
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[[]Sticker]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2126,23 +2753,27 @@ func (b *Bot) requestMessageOrBool(method string, params map[string]any) (result
 	if bytes, err := b.request(method, params); err == nil {
 		// try APIResponseMessage type,
 		var jsonResponseMessage APIResponse[Message]
-		err = json.Unmarshal(bytes, &jsonResponseMessage)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponseMessage)
 		if err == nil {
 			return APIResponseMessageOrBool{
-				Ok:            true,
+				Ok:            jsonResponseMessage.Ok,
+				ErrorCode:     jsonResponseMessage.ErrorCode,
 				Description:   jsonResponseMessage.Description,
+				Parameters:    jsonResponseMessage.Parameters,
 				ResultMessage: jsonResponseMessage.Result,
+				raw:           bytes,
 			}
 		}
 
 		// then try APIResponseBool type,
 		var jsonResponseBool APIResponse[bool]
-		err = json.Unmarshal(bytes, &jsonResponseBool)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponseBool)
 		if err == nil {
 			return APIResponseMessageOrBool{
 				Ok:          true,
 				Description: jsonResponseBool.Description,
 				ResultBool:  jsonResponseBool.Result,
+				raw:         bytes,
 			}
 		}
 
@@ -2162,8 +2793,9 @@ func (b *Bot) requestPoll(method string, params map[string]any) (result APIRespo
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[Poll]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2183,8 +2815,9 @@ func (b *Bot) requestBotCommands(method string, params map[string]any) (result A
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[[]BotCommand]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2204,8 +2837,9 @@ func (b *Bot) requestBotName(method string, params map[string]any) (result APIRe
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[BotName]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2225,8 +2859,9 @@ func (b *Bot) requestBotDescription(method string, params map[string]any) (resul
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[BotDescription]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2246,8 +2881,9 @@ func (b *Bot) requestBotShortDescription(method string, params map[string]any) (
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[BotShortDescription]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2267,8 +2903,9 @@ func (b *Bot) requestChatInviteLink(method string, params map[string]any) (resul
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[ChatInviteLink]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2288,8 +2925,9 @@ func (b *Bot) requestMenuButton(method string, params map[string]any) (result AP
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[MenuButton]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2309,8 +2947,9 @@ func (b *Bot) requestForumTopic(method string, params map[string]any) (result AP
 
 	if bytes, err := b.request(method, params); err == nil {
 		var jsonResponse APIResponse[ForumTopic]
-		err = json.Unmarshal(bytes, &jsonResponse)
+		err = b.jsonCodec().Unmarshal(bytes, &jsonResponse)
 		if err == nil {
+			jsonResponse.raw = bytes
 			return jsonResponse
 		}
 
@@ -2328,21 +2967,62 @@ func (b *Bot) requestForumTopic(method string, params map[string]any) (result AP
 func (b *Bot) handleWebhook(writer http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 
+	if !b.verifyWebhookRequest(writer, req) {
+		return
+	}
+
 	b.verbose("received webhook request: %+v", req)
 
-	if body, err := io.ReadAll(req.Body); err == nil {
-		var webhook Update
-		if err = json.Unmarshal(body, &webhook); err != nil {
+	// NOTE: only the Update struct itself is recycled (not the values referenced by its fields),
+	// so handlers can keep using them after returning
+	update := _updatePool.Get().(*Update)
+	recycleUpdate := func() {
+		*update = Update{}
+		_updatePool.Put(update)
+	}
+
+	// decode the request body directly, without buffering it
+	if codec, ok := b.jsonCodec().(JSONStreamCodec); ok && b.updateFilter == nil && !b.Verbose {
+		if err := codec.Decode(req.Body, update); err != nil {
+			recycleUpdate()
+
+			b.error("error while decoding webhook request (%s)", err)
+			return
+		}
+
+		b.handleUpdateThen(*update, recycleUpdate)
+		return
+	}
+
+	// or read the request body into a pooled buffer (instead of allocating a new one with io.ReadAll)
+	buf := _bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	recycle := func() {
+		putBuffer(buf)
+		recycleUpdate()
+	}
+
+	if _, err := buf.ReadFrom(req.Body); err == nil {
+		var accepted bool
+		if accepted, err = b.decodeUpdate(buf.Bytes(), update); err != nil {
+			recycle()
+
 			b.error("error while parsing json (%s)", err)
-		} else {
-			b.verbose("received webhook body: %s", string(body))
+		} else if accepted {
+			if b.Verbose {
+				b.verbose("received webhook body: %s", buf.String())
+			}
 
-			b.updateHandler(b, webhook, nil)
+			b.handleUpdateThen(*update, recycle) // recycled after the update is handled
+		} else {
+			recycle()
 		}
 	} else {
+		recycle()
+
 		b.error("error while reading webhook request (%s)", err)
 
-		b.updateHandler(b, Update{}, err)
+		b.handleUpdate(Update{}, err)
 	}
 }
 
@@ -83,11 +83,17 @@ func (o OptionsGetUpdates) SetAllowedUpdates(allowedUpdates []AllowedUpdate) Opt
 
 // OptionsSendMessage struct for SendMessage().
 //
-// options include: `message_thread_id`, `parse_mode`, `entities`, `disable_web_page_preview`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `parse_mode`, `entities`, `disable_web_page_preview`, `link_preview_options`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendmessage
 type OptionsSendMessage MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendMessage.
+func (o OptionsSendMessage) SetBusinessConnectionID(businessConnectionID string) OptionsSendMessage {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendMessage.
 func (o OptionsSendMessage) SetMessageThreadID(messageThreadID int64) OptionsSendMessage {
 	o["message_thread_id"] = messageThreadID
@@ -107,11 +113,19 @@ func (o OptionsSendMessage) SetEntities(entities []MessageEntity) OptionsSendMes
 }
 
 // SetDisableWebPagePreview sets the `disable_web_page_preview` value of OptionsSendMessage.
+//
+// Deprecated: use SetLinkPreviewOptions() instead.
 func (o OptionsSendMessage) SetDisableWebPagePreview(disable bool) OptionsSendMessage {
 	o["disable_web_page_preview"] = disable
 	return o
 }
 
+// SetLinkPreviewOptions sets the `link_preview_options` value of OptionsSendMessage.
+func (o OptionsSendMessage) SetLinkPreviewOptions(linkPreviewOptions LinkPreviewOptions) OptionsSendMessage {
+	o["link_preview_options"] = linkPreviewOptions
+	return o
+}
+
 // SetDisableNotification sets the `disable_notification` value of OptionsSendMessage.
 func (o OptionsSendMessage) SetDisableNotification(disable bool) OptionsSendMessage {
 	o["disable_notification"] = disable
@@ -130,6 +144,12 @@ func (o OptionsSendMessage) SetReplyToMessageID(replyToMessageID int64) OptionsS
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendMessage.
+func (o OptionsSendMessage) SetReplyParameters(replyParameters ReplyParameters) OptionsSendMessage {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendMessage.
 func (o OptionsSendMessage) SetAllowSendingWithoutReply(allow bool) OptionsSendMessage {
 	o["allow_sending_without_reply"] = allow
@@ -144,6 +164,33 @@ func (o OptionsSendMessage) SetReplyMarkup(replyMarkup any) OptionsSendMessage {
 	return o
 }
 
+// SetDocumentFallback sets the maximum number of messages for a text, over which the text is sent as a .txt document instead.
+//
+// SendMessage() sends a text as a document when it does not fit in a message, regardless of `maxMessages`.
+// (not sent to the API)
+func (o OptionsSendMessage) SetDocumentFallback(maxMessages int) OptionsSendMessage {
+	o[documentFallbackKey] = maxMessages
+	return o
+}
+
+// SetIdempotencyKey sets the idempotency key of OptionsSendMessage.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendMessage) SetIdempotencyKey(key string) OptionsSendMessage {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
+// SetMarkdownV2Args sets values which are formatted into the text with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsSendMessage to MarkdownV2.
+//
+// The text is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsSendMessage) SetMarkdownV2Args(args ...any) OptionsSendMessage {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsForwardMessage struct for ForwardMessage().
 //
 // options include: `message_thread_id`, `disable_notification` and `protect_content`.
@@ -169,9 +216,17 @@ func (o OptionsForwardMessage) SetProtectContent(protect bool) OptionsForwardMes
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsForwardMessage.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsForwardMessage) SetIdempotencyKey(key string) OptionsForwardMessage {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsCopyMessage struct for CopyMessage().
 //
-// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, `reply_markup`
+// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`
 //
 // https://core.telegram.org/bots/api#copymessage
 type OptionsCopyMessage MethodOptions
@@ -218,6 +273,12 @@ func (o OptionsCopyMessage) SetReplyToMessageID(replyToMessageID int64) OptionsC
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsCopyMessage.
+func (o OptionsCopyMessage) SetReplyParameters(replyParameters ReplyParameters) OptionsCopyMessage {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsCopyMessage.
 func (o OptionsCopyMessage) SetAllowSendingWithoutReply(allow bool) OptionsCopyMessage {
 	o["allow_sending_without_reply"] = allow
@@ -232,13 +293,75 @@ func (o OptionsCopyMessage) SetReplyMarkup(replyMarkup any) OptionsCopyMessage {
 	return o
 }
 
+// OptionsForwardMessages struct for ForwardMessages().
+//
+// options include: `message_thread_id`, `disable_notification` and `protect_content`.
+//
+// https://core.telegram.org/bots/api#forwardmessages
+type OptionsForwardMessages MethodOptions
+
+// SetMessageThreadID sets the `message_thread_id` value of OptionsForwardMessages.
+func (o OptionsForwardMessages) SetMessageThreadID(messageThreadID int64) OptionsForwardMessages {
+	o["message_thread_id"] = messageThreadID
+	return o
+}
+
+// SetDisableNotification sets the `disable_notification` value of OptionsForwardMessages.
+func (o OptionsForwardMessages) SetDisableNotification(disable bool) OptionsForwardMessages {
+	o["disable_notification"] = disable
+	return o
+}
+
+// SetProtectContent sets the `protect_content` value of OptionsForwardMessages.
+func (o OptionsForwardMessages) SetProtectContent(protect bool) OptionsForwardMessages {
+	o["protect_content"] = protect
+	return o
+}
+
+// OptionsCopyMessages struct for CopyMessages().
+//
+// options include: `message_thread_id`, `disable_notification`, `protect_content` and `remove_caption`.
+//
+// https://core.telegram.org/bots/api#copymessages
+type OptionsCopyMessages MethodOptions
+
+// SetMessageThreadID sets the `message_thread_id` value of OptionsCopyMessages.
+func (o OptionsCopyMessages) SetMessageThreadID(messageThreadID int64) OptionsCopyMessages {
+	o["message_thread_id"] = messageThreadID
+	return o
+}
+
+// SetDisableNotification sets the `disable_notification` value of OptionsCopyMessages.
+func (o OptionsCopyMessages) SetDisableNotification(disable bool) OptionsCopyMessages {
+	o["disable_notification"] = disable
+	return o
+}
+
+// SetProtectContent sets the `protect_content` value of OptionsCopyMessages.
+func (o OptionsCopyMessages) SetProtectContent(protect bool) OptionsCopyMessages {
+	o["protect_content"] = protect
+	return o
+}
+
+// SetRemoveCaption sets the `remove_caption` value of OptionsCopyMessages.
+func (o OptionsCopyMessages) SetRemoveCaption(remove bool) OptionsCopyMessages {
+	o["remove_caption"] = remove
+	return o
+}
+
 // OptionsSendPhoto struct for SendPhoto().
 //
-// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `has_spoiler`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `has_spoiler`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendphoto
 type OptionsSendPhoto MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendPhoto.
+func (o OptionsSendPhoto) SetBusinessConnectionID(businessConnectionID string) OptionsSendPhoto {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id`value of OptionsSendPhoto.
 func (o OptionsSendPhoto) SetMessageThreadID(messageThreadID int64) OptionsSendPhoto {
 	o["message_thread_id"] = messageThreadID
@@ -251,6 +374,14 @@ func (o OptionsSendPhoto) SetCaption(caption string) OptionsSendPhoto {
 	return o
 }
 
+// SetCaptionOverflow sets how to handle the caption of OptionsSendPhoto when it is longer than CaptionMaxLength.
+//
+// (not sent to the API; without it, the API request will fail with a long caption)
+func (o OptionsSendPhoto) SetCaptionOverflow(overflow CaptionOverflow) OptionsSendPhoto {
+	o[captionOverflowKey] = overflow
+	return o
+}
+
 // SetParseMode sets the `parse_mode` value of OptionsSendPhoto.
 func (o OptionsSendPhoto) SetParseMode(parseMode ParseMode) OptionsSendPhoto {
 	o["parse_mode"] = parseMode
@@ -287,6 +418,12 @@ func (o OptionsSendPhoto) SetReplyToMessageID(replyToMessageID int64) OptionsSen
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendPhoto.
+func (o OptionsSendPhoto) SetReplyParameters(replyParameters ReplyParameters) OptionsSendPhoto {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendPhoto.
 func (o OptionsSendPhoto) SetAllowSendingWithoutReply(allow bool) OptionsSendPhoto {
 	o["allow_sending_without_reply"] = allow
@@ -301,13 +438,37 @@ func (o OptionsSendPhoto) SetReplyMarkup(replyMarkup any) OptionsSendPhoto {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendPhoto.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendPhoto) SetIdempotencyKey(key string) OptionsSendPhoto {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
+// SetMarkdownV2Args sets values which are formatted into the caption with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsSendPhoto to MarkdownV2.
+//
+// The caption is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsSendPhoto) SetMarkdownV2Args(args ...any) OptionsSendPhoto {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsSendAudio struct for SendAudio().
 //
-// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `duration`, `performer`, `title`, `thumbnail`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `duration`, `performer`, `title`, `thumbnail`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendaudio
 type OptionsSendAudio MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendAudio.
+func (o OptionsSendAudio) SetBusinessConnectionID(businessConnectionID string) OptionsSendAudio {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendAudio.
 func (o OptionsSendAudio) SetMessageThreadID(messageThreadID int64) OptionsSendAudio {
 	o["message_thread_id"] = messageThreadID
@@ -376,6 +537,12 @@ func (o OptionsSendAudio) SetReplyToMessageID(replyToMessageID int64) OptionsSen
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendAudio.
+func (o OptionsSendAudio) SetReplyParameters(replyParameters ReplyParameters) OptionsSendAudio {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendAudio.
 func (o OptionsSendAudio) SetAllowSendingWithoutReply(allow bool) OptionsSendAudio {
 	o["allow_sending_without_reply"] = allow
@@ -390,13 +557,37 @@ func (o OptionsSendAudio) SetReplyMarkup(replyMarkup any) OptionsSendAudio {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendAudio.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendAudio) SetIdempotencyKey(key string) OptionsSendAudio {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
+// SetMarkdownV2Args sets values which are formatted into the caption with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsSendAudio to MarkdownV2.
+//
+// The caption is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsSendAudio) SetMarkdownV2Args(args ...any) OptionsSendAudio {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsSendDocument struct for SendDocument().
 //
-// options include: `message_thread_id`, `thumbnail`, `caption`, `parse_mode`, `caption_entities`, `disable_content_type_detection`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `thumbnail`, `caption`, `parse_mode`, `caption_entities`, `disable_content_type_detection`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#senddocument
 type OptionsSendDocument MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendDocument.
+func (o OptionsSendDocument) SetBusinessConnectionID(businessConnectionID string) OptionsSendDocument {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendDocument.
 func (o OptionsSendDocument) SetMessageThreadID(messageThreadID int64) OptionsSendDocument {
 	o["message_thread_id"] = messageThreadID
@@ -417,6 +608,14 @@ func (o OptionsSendDocument) SetCaption(caption string) OptionsSendDocument {
 	return o
 }
 
+// SetCaptionOverflow sets how to handle the caption of OptionsSendDocument when it is longer than CaptionMaxLength.
+//
+// (not sent to the API; without it, the API request will fail with a long caption)
+func (o OptionsSendDocument) SetCaptionOverflow(overflow CaptionOverflow) OptionsSendDocument {
+	o[captionOverflowKey] = overflow
+	return o
+}
+
 // SetParseMode sets the `parse_mode` value of OptionsSendDocument.
 func (o OptionsSendDocument) SetParseMode(parseMode ParseMode) OptionsSendDocument {
 	o["parse_mode"] = parseMode
@@ -453,6 +652,12 @@ func (o OptionsSendDocument) SetReplyToMessageID(replyToMessageID int64) Options
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendDocument.
+func (o OptionsSendDocument) SetReplyParameters(replyParameters ReplyParameters) OptionsSendDocument {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendDocument.
 func (o OptionsSendDocument) SetAllowSendingWithoutReply(allow bool) OptionsSendDocument {
 	o["allow_sending_without_reply"] = allow
@@ -467,13 +672,37 @@ func (o OptionsSendDocument) SetReplyMarkup(replyMarkup any) OptionsSendDocument
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendDocument.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendDocument) SetIdempotencyKey(key string) OptionsSendDocument {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
+// SetMarkdownV2Args sets values which are formatted into the caption with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsSendDocument to MarkdownV2.
+//
+// The caption is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsSendDocument) SetMarkdownV2Args(args ...any) OptionsSendDocument {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsSendSticker struct for SendSticker().
 //
-// options include: `message_thread_id`, `emoji`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `emoji`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendsticker
 type OptionsSendSticker MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendSticker.
+func (o OptionsSendSticker) SetBusinessConnectionID(businessConnectionID string) OptionsSendSticker {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendSticker.
 func (o OptionsSendSticker) SetMessageThreadID(messageThreadID int64) OptionsSendSticker {
 	o["message_thread_id"] = messageThreadID
@@ -504,6 +733,12 @@ func (o OptionsSendSticker) SetReplyToMessageID(replyToMessageID int64) OptionsS
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendSticker.
+func (o OptionsSendSticker) SetReplyParameters(replyParameters ReplyParameters) OptionsSendSticker {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendSticker.
 func (o OptionsSendSticker) SetAllowSendingWithoutReply(allow bool) OptionsSendSticker {
 	o["allow_sending_without_reply"] = allow
@@ -518,6 +753,14 @@ func (o OptionsSendSticker) SetReplyMarkup(replyMarkup any) OptionsSendSticker {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendSticker.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendSticker) SetIdempotencyKey(key string) OptionsSendSticker {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsCreateNewStickerSet struct for CreateNewStickerSet().
 //
 // options include: `sticker_type`, and `needs_repainting`
@@ -593,11 +836,17 @@ func (o OptionsSetStickerMaskPosition) SetMaskPosition(maskPosition MaskPosition
 
 // OptionsSendVideo struct for SendVideo().
 //
-// options include: `message_thread_id`, `duration`, `width`, `height`, `thumbnail`, `caption`, `parse_mode`, `caption_entities`, `has_spoiler`, `supports_streaming`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `duration`, `width`, `height`, `thumbnail`, `caption`, `parse_mode`, `caption_entities`, `has_spoiler`, `supports_streaming`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendvideo
 type OptionsSendVideo MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendVideo.
+func (o OptionsSendVideo) SetBusinessConnectionID(businessConnectionID string) OptionsSendVideo {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendVideo.
 func (o OptionsSendVideo) SetMessageThreadID(messageThreadID int64) OptionsSendVideo {
 	o["message_thread_id"] = messageThreadID
@@ -636,6 +885,14 @@ func (o OptionsSendVideo) SetCaption(caption string) OptionsSendVideo {
 	return o
 }
 
+// SetCaptionOverflow sets how to handle the caption of OptionsSendVideo when it is longer than CaptionMaxLength.
+//
+// (not sent to the API; without it, the API request will fail with a long caption)
+func (o OptionsSendVideo) SetCaptionOverflow(overflow CaptionOverflow) OptionsSendVideo {
+	o[captionOverflowKey] = overflow
+	return o
+}
+
 // SetParseMode sets the `parse_mode` value of OptionsSendVideo.
 func (o OptionsSendVideo) SetParseMode(parseMode ParseMode) OptionsSendVideo {
 	o["parse_mode"] = parseMode
@@ -678,6 +935,12 @@ func (o OptionsSendVideo) SetReplyToMessageID(replyToMessageID int64) OptionsSen
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendVideo.
+func (o OptionsSendVideo) SetReplyParameters(replyParameters ReplyParameters) OptionsSendVideo {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendVideo.
 func (o OptionsSendVideo) SetAllowSendingWithoutReply(allow bool) OptionsSendVideo {
 	o["allow_sending_without_reply"] = allow
@@ -692,13 +955,37 @@ func (o OptionsSendVideo) SetReplyMarkup(replyMarkup any) OptionsSendVideo {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendVideo.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendVideo) SetIdempotencyKey(key string) OptionsSendVideo {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
+// SetMarkdownV2Args sets values which are formatted into the caption with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsSendVideo to MarkdownV2.
+//
+// The caption is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsSendVideo) SetMarkdownV2Args(args ...any) OptionsSendVideo {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsSendAnimation struct for SendAnimation().
 //
-// options include: `message_thread_id`, `duration`, `width`, `height`, `thumbnail`, `caption`, `parse_mode`, `caption_entities`, `has_spoiler`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `duration`, `width`, `height`, `thumbnail`, `caption`, `parse_mode`, `caption_entities`, `has_spoiler`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendanimation
 type OptionsSendAnimation MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendAnimation.
+func (o OptionsSendAnimation) SetBusinessConnectionID(businessConnectionID string) OptionsSendAnimation {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendAnimation.
 func (o OptionsSendAnimation) SetMessageThreadID(messageThreadID int64) OptionsSendAnimation {
 	o["message_thread_id"] = messageThreadID
@@ -773,6 +1060,12 @@ func (o OptionsSendAnimation) SetReplyToMessageID(replyToMessageID int64) Option
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendAnimation.
+func (o OptionsSendAnimation) SetReplyParameters(replyParameters ReplyParameters) OptionsSendAnimation {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendAnimation.
 func (o OptionsSendAnimation) SetAllowSendingWithoutReply(allow bool) OptionsSendAnimation {
 	o["allow_sending_without_reply"] = allow
@@ -787,13 +1080,37 @@ func (o OptionsSendAnimation) SetReplyMarkup(replyMarkup any) OptionsSendAnimati
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendAnimation.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendAnimation) SetIdempotencyKey(key string) OptionsSendAnimation {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
+// SetMarkdownV2Args sets values which are formatted into the caption with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsSendAnimation to MarkdownV2.
+//
+// The caption is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsSendAnimation) SetMarkdownV2Args(args ...any) OptionsSendAnimation {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsSendVoice struct for SendVoice().
 //
-// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `duration`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `caption`, `parse_mode`, `caption_entities`, `duration`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendvoice
 type OptionsSendVoice MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendVoice.
+func (o OptionsSendVoice) SetBusinessConnectionID(businessConnectionID string) OptionsSendVoice {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendVoice.
 func (o OptionsSendVoice) SetMessageThreadID(messageThreadID int64) OptionsSendVoice {
 	o["message_thread_id"] = messageThreadID
@@ -842,6 +1159,12 @@ func (o OptionsSendVoice) SetReplyToMessageID(replyToMessageID int64) OptionsSen
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendVoice.
+func (o OptionsSendVoice) SetReplyParameters(replyParameters ReplyParameters) OptionsSendVoice {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendVoice.
 func (o OptionsSendVoice) SetAllowSendingWithoutReply(allow bool) OptionsSendVoice {
 	o["allow_sending_without_reply"] = allow
@@ -856,14 +1179,38 @@ func (o OptionsSendVoice) SetReplyMarkup(replyMarkup any) OptionsSendVoice {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendVoice.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendVoice) SetIdempotencyKey(key string) OptionsSendVoice {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
+// SetMarkdownV2Args sets values which are formatted into the caption with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsSendVoice to MarkdownV2.
+//
+// The caption is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsSendVoice) SetMarkdownV2Args(args ...any) OptionsSendVoice {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsSendVideoNote struct for SendVideoNote().
 //
-// options include: `message_thread_id,` `duration`, `length`, `thumbnail`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id,` `duration`, `length`, `thumbnail`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 // (XXX: API returns 'Bad Request: wrong video note length' when length is not given / 2017.05.19.)
 //
 // https://core.telegram.org/bots/api#sendvideonote
 type OptionsSendVideoNote MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendVideoNote.
+func (o OptionsSendVideoNote) SetBusinessConnectionID(businessConnectionID string) OptionsSendVideoNote {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendVideoNote.
 func (o OptionsSendVideoNote) SetMessageThreadID(messageThreadID int64) OptionsSendVideoNote {
 	o["message_thread_id"] = messageThreadID
@@ -908,6 +1255,12 @@ func (o OptionsSendVideoNote) SetReplyToMessageID(replyToMessageID int64) Option
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendVideoNote.
+func (o OptionsSendVideoNote) SetReplyParameters(replyParameters ReplyParameters) OptionsSendVideoNote {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendVideoNote.
 func (o OptionsSendVideoNote) SetAllowSendingWithoutReply(allow bool) OptionsSendVideoNote {
 	o["allow_sending_without_reply"] = allow
@@ -922,13 +1275,27 @@ func (o OptionsSendVideoNote) SetReplyMarkup(replyMarkup any) OptionsSendVideoNo
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendVideoNote.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendVideoNote) SetIdempotencyKey(key string) OptionsSendVideoNote {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsSendMediaGroup struct for SendMediaGroup().
 //
-// options include: `message_thread_id`, `disable_notification`, `protect_content`, `reply_to_message_id`, and `allow_sending_without_reply`
+// options include: `message_thread_id`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendmediagroup
 type OptionsSendMediaGroup MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendMediaGroup.
+func (o OptionsSendMediaGroup) SetBusinessConnectionID(businessConnectionID string) OptionsSendMediaGroup {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendMediaGroup.
 func (o OptionsSendMediaGroup) SetMessageThreadID(messageThreadID int64) OptionsSendMediaGroup {
 	o["message_thread_id"] = messageThreadID
@@ -953,6 +1320,12 @@ func (o OptionsSendMediaGroup) SetReplyToMessageID(replyToMessageID int64) Optio
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendMediaGroup.
+func (o OptionsSendMediaGroup) SetReplyParameters(replyParameters ReplyParameters) OptionsSendMediaGroup {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendMediaGroup.
 func (o OptionsSendMediaGroup) SetAllowSendingWithoutReply(allow bool) OptionsSendMediaGroup {
 	o["allow_sending_without_reply"] = allow
@@ -961,11 +1334,17 @@ func (o OptionsSendMediaGroup) SetAllowSendingWithoutReply(allow bool) OptionsSe
 
 // OptionsSendLocation struct for SendLocation()
 //
-// options include: `message_thread_id,` `horizontal_accuracy`, `live_period`, `heading`, `proximity_alert_radius`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id,` `horizontal_accuracy`, `live_period`, `heading`, `proximity_alert_radius`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendlocation
 type OptionsSendLocation MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendLocation.
+func (o OptionsSendLocation) SetBusinessConnectionID(businessConnectionID string) OptionsSendLocation {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendLocation.
 func (o OptionsSendLocation) SetMessageThreadID(messageThreadID int64) OptionsSendLocation {
 	o["message_thread_id"] = messageThreadID
@@ -973,7 +1352,7 @@ func (o OptionsSendLocation) SetMessageThreadID(messageThreadID int64) OptionsSe
 }
 
 // SetHorizontalAccuracy sets the `horizontal_accuracy` value of OptionsSendLocation.
-func (o OptionsSendLocation) SetHorizontalAccuracy(horizontalAccuracy float32) OptionsSendLocation {
+func (o OptionsSendLocation) SetHorizontalAccuracy(horizontalAccuracy float64) OptionsSendLocation {
 	o["horizontal_accuracy"] = horizontalAccuracy
 	return o
 }
@@ -1014,6 +1393,12 @@ func (o OptionsSendLocation) SetReplyToMessageID(replyToMessageID int64) Options
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendLocation.
+func (o OptionsSendLocation) SetReplyParameters(replyParameters ReplyParameters) OptionsSendLocation {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendLocation.
 func (o OptionsSendLocation) SetAllowSendingWithoutReply(allow bool) OptionsSendLocation {
 	o["allow_sending_without_reply"] = allow
@@ -1028,13 +1413,27 @@ func (o OptionsSendLocation) SetReplyMarkup(replyMarkup any) OptionsSendLocation
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendLocation.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendLocation) SetIdempotencyKey(key string) OptionsSendLocation {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsSendVenue struct for SendVenue().
 //
-// options include: `message_thread_id`, `foursquare_id`, `foursquare_type`, `google_place_id`, `google_place_type`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `foursquare_id`, `foursquare_type`, `google_place_id`, `google_place_type`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendvenue
 type OptionsSendVenue MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendVenue.
+func (o OptionsSendVenue) SetBusinessConnectionID(businessConnectionID string) OptionsSendVenue {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendVenue.
 func (o OptionsSendVenue) SetMessageThreadID(messageThreadID int64) OptionsSendVenue {
 	o["message_thread_id"] = messageThreadID
@@ -1083,6 +1482,12 @@ func (o OptionsSendVenue) SetReplyToMessageID(replyToMessageID int64) OptionsSen
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendVenue.
+func (o OptionsSendVenue) SetReplyParameters(replyParameters ReplyParameters) OptionsSendVenue {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendVenue.
 func (o OptionsSendVenue) SetAllowSendingWithoutReply(allow bool) OptionsSendVenue {
 	o["allow_sending_without_reply"] = allow
@@ -1097,13 +1502,27 @@ func (o OptionsSendVenue) SetReplyMarkup(replyMarkup any) OptionsSendVenue {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendVenue.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendVenue) SetIdempotencyKey(key string) OptionsSendVenue {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsSendPoll struct for SendPoll().
 //
-// options include: `message_thread_id`, `is_anonymous`, `type`, `allows_multiple_answers`, `correct_option_id`, `explanation`, `explanation_parse_mode`, `explanation_entities`, `open_period`, `close_date`, `is_closed`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `is_anonymous`, `type`, `allows_multiple_answers`, `correct_option_id`, `explanation`, `explanation_parse_mode`, `explanation_entities`, `open_period`, `close_date`, `is_closed`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendpoll
 type OptionsSendPoll MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendPoll.
+func (o OptionsSendPoll) SetBusinessConnectionID(businessConnectionID string) OptionsSendPoll {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendPoll.
 func (o OptionsSendPoll) SetMessageThreadID(messageThreadID int64) OptionsSendPoll {
 	o["message_thread_id"] = messageThreadID
@@ -1188,6 +1607,12 @@ func (o OptionsSendPoll) SetReplyToMessageID(replyToMessageID int64) OptionsSend
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendPoll.
+func (o OptionsSendPoll) SetReplyParameters(replyParameters ReplyParameters) OptionsSendPoll {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendPoll.
 func (o OptionsSendPoll) SetAllowSendingWithoutReply(allow bool) OptionsSendPoll {
 	o["allow_sending_without_reply"] = allow
@@ -1202,6 +1627,14 @@ func (o OptionsSendPoll) SetReplyMarkup(replyMarkup any) OptionsSendPoll {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendPoll.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendPoll) SetIdempotencyKey(key string) OptionsSendPoll {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsStopPoll struct for StopPoll().
 //
 // options include: `reply_markup`.
@@ -1217,11 +1650,17 @@ func (o OptionsStopPoll) SetReplyMarkup(replyMarkup InlineKeyboardMarkup) Option
 
 // OptionsSendDice struct for SendDice().
 //
-// options include: `message_thread_id`, `emoji`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `emoji`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#senddice
 type OptionsSendDice MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendDice.
+func (o OptionsSendDice) SetBusinessConnectionID(businessConnectionID string) OptionsSendDice {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendDice.
 func (o OptionsSendDice) SetMessageThreadID(messageThreadID int64) OptionsSendDice {
 	o["message_thread_id"] = messageThreadID
@@ -1236,6 +1675,11 @@ func (o OptionsSendDice) SetEmoji(emoji string) OptionsSendDice {
 	return o
 }
 
+// SetDiceEmoji sets the `emoji` value of OptionsSendDice with a DiceEmoji constant.
+func (o OptionsSendDice) SetDiceEmoji(emoji DiceEmoji) OptionsSendDice {
+	return o.SetEmoji(string(emoji))
+}
+
 // SetDisableNotification sets the `disable_notification` value of OptionsSendDice.
 func (o OptionsSendDice) SetDisableNotification(disable bool) OptionsSendDice {
 	o["disable_notification"] = disable
@@ -1254,6 +1698,12 @@ func (o OptionsSendDice) SetReplyToMessageID(replyToMessageID int64) OptionsSend
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendDice.
+func (o OptionsSendDice) SetReplyParameters(replyParameters ReplyParameters) OptionsSendDice {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendDice.
 func (o OptionsSendDice) SetAllowSendingWithoutReply(allow bool) OptionsSendDice {
 	o["allow_sending_without_reply"] = allow
@@ -1268,26 +1718,67 @@ func (o OptionsSendDice) SetReplyMarkup(replyMarkup any) OptionsSendDice {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendDice.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendDice) SetIdempotencyKey(key string) OptionsSendDice {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsSendChatAction struct for SendChatAction().
 //
-// options include: `message_thread_id`.
+// options include: `message_thread_id` and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendchataction
 type OptionsSendChatAction MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendChatAction.
+func (o OptionsSendChatAction) SetBusinessConnectionID(businessConnectionID string) OptionsSendChatAction {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendChatAction.
 func (o OptionsSendChatAction) SetMessageThreadID(messageThreadID int64) OptionsSendChatAction {
 	o["message_thread_id"] = messageThreadID
 	return o
 }
 
+// OptionsSetMessageReaction struct for SetMessageReaction().
+//
+// options include: `reaction`, and `is_big`.
+//
+// https://core.telegram.org/bots/api#setmessagereaction
+type OptionsSetMessageReaction MethodOptions
+
+// SetReaction sets the `reaction` value of OptionsSetMessageReaction.
+//
+// An empty slice clears all reactions of the bot on the message.
+func (o OptionsSetMessageReaction) SetReaction(reaction []ReactionType) OptionsSetMessageReaction {
+	o["reaction"] = reaction
+	return o
+}
+
+// SetIsBig sets the `is_big` value of OptionsSetMessageReaction.
+func (o OptionsSetMessageReaction) SetIsBig(isBig bool) OptionsSetMessageReaction {
+	o["is_big"] = isBig
+	return o
+}
+
 // OptionsSendContact struct for SendContact().
 //
-// options include: `message_thread_id`, `last_name`, `vcard`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `last_name`, `vcard`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendcontact
 type OptionsSendContact MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendContact.
+func (o OptionsSendContact) SetBusinessConnectionID(businessConnectionID string) OptionsSendContact {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendContact.
 func (o OptionsSendContact) SetMessageThreadID(messageThreadID int64) OptionsSendContact {
 	o["message_thread_id"] = messageThreadID
@@ -1324,6 +1815,12 @@ func (o OptionsSendContact) SetReplyToMessageID(replyToMessageID int64) OptionsS
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendContact.
+func (o OptionsSendContact) SetReplyParameters(replyParameters ReplyParameters) OptionsSendContact {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendContact.
 func (o OptionsSendContact) SetAllowSendingWithoutReply(allow bool) OptionsSendContact {
 	o["allow_sending_without_reply"] = allow
@@ -1338,6 +1835,14 @@ func (o OptionsSendContact) SetReplyMarkup(replyMarkup any) OptionsSendContact {
 	return o
 }
 
+// SetIdempotencyKey sets the idempotency key of OptionsSendContact.
+//
+// Sends with the same key are suppressed in the window of SetIdempotencyStore(). (not sent to the API)
+func (o OptionsSendContact) SetIdempotencyKey(key string) OptionsSendContact {
+	o[idempotencyKeyKey] = key
+	return o
+}
+
 // OptionsGetUserProfilePhotos struct for GetUserProfilePhotos().
 //
 // options include: `offset` and `limit`.
@@ -1551,6 +2056,18 @@ func (o OptionsUnpinChatMessage) SetMessageID(messageID int64) OptionsUnpinChatM
 // https://core.telegram.org/bots/api#answercallbackquery
 type OptionsAnswerCallbackQuery MethodOptions
 
+// SetText sets the `text` value of OptionsAnswerCallbackQuery.
+func (o OptionsAnswerCallbackQuery) SetText(text string) OptionsAnswerCallbackQuery {
+	o["text"] = text
+	return o
+}
+
+// SetShowAlert sets the `show_alert` value of OptionsAnswerCallbackQuery.
+func (o OptionsAnswerCallbackQuery) SetShowAlert(showAlert bool) OptionsAnswerCallbackQuery {
+	o["show_alert"] = showAlert
+	return o
+}
+
 // SetURL sets the `url` value of OptionsAnswerCallbackQuery.
 func (o OptionsAnswerCallbackQuery) SetURL(url string) OptionsAnswerCallbackQuery {
 	o["url"] = url
@@ -1804,7 +2321,7 @@ func (o OptionsGetMyDefaultAdministratorRights) SetForChannels(forChannels bool)
 //
 //	or `inline_message_id` (when `chat_id` & `message_id` is not given)
 //
-// other options: `parse_mode`, `entities`, `disable_web_page_preview`, and `reply_markup`
+// other options: `parse_mode`, `entities`, `disable_web_page_preview`, `link_preview_options`, and `reply_markup`
 //
 // https://core.telegram.org/bots/api#editmessagetext
 type OptionsEditMessageText MethodOptions
@@ -1835,17 +2352,35 @@ func (o OptionsEditMessageText) SetEntities(entities []MessageEntity) OptionsEdi
 }
 
 // SetDisableWebPagePreview sets the `disable_web_page_preview` value of OptionsEditMessageText.
+//
+// Deprecated: use SetLinkPreviewOptions() instead.
 func (o OptionsEditMessageText) SetDisableWebPagePreview(disable bool) OptionsEditMessageText {
 	o["disable_web_page_preview"] = disable
 	return o
 }
 
+// SetLinkPreviewOptions sets the `link_preview_options` value of OptionsEditMessageText.
+func (o OptionsEditMessageText) SetLinkPreviewOptions(linkPreviewOptions LinkPreviewOptions) OptionsEditMessageText {
+	o["link_preview_options"] = linkPreviewOptions
+	return o
+}
+
 // SetReplyMarkup sets the `reply_markup` value of OptionsEditMessageText.
 func (o OptionsEditMessageText) SetReplyMarkup(replyMarkup InlineKeyboardMarkup) OptionsEditMessageText {
 	o["reply_markup"] = replyMarkup
 	return o
 }
 
+// SetMarkdownV2Args sets values which are formatted into the text with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsEditMessageText to MarkdownV2.
+//
+// The text is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsEditMessageText) SetMarkdownV2Args(args ...any) OptionsEditMessageText {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsEditMessageCaption struct for EditMessageCaption().
 //
 // required options: `chat_id` + `message_id` (when `inline_message_id` is not given)
@@ -1894,6 +2429,16 @@ func (o OptionsEditMessageCaption) SetReplyMarkup(replyMarkup InlineKeyboardMark
 	return o
 }
 
+// SetMarkdownV2Args sets values which are formatted into the caption with FormatMarkdownV2() (fully escaped),
+// and sets `parse_mode` of OptionsEditMessageCaption to MarkdownV2.
+//
+// The caption is used as the MarkdownV2 template. (values are not sent to the API)
+func (o OptionsEditMessageCaption) SetMarkdownV2Args(args ...any) OptionsEditMessageCaption {
+	o[markdownV2ArgsKey] = args
+	o["parse_mode"] = ParseModeMarkdownV2
+	return o
+}
+
 // OptionsEditMessageMedia struct for EditMessageMedia()
 //
 // required options: `chat_id` + `message_id` (when `inline_message_id` is not given)
@@ -1979,7 +2524,7 @@ func (o OptionsEditMessageLiveLocation) SetInlineMessageID(inlineMessageID strin
 }
 
 // SetHorizontalAccuracy sets the `horizontal_accuracy` value of OptionsEditMessageLiveLocation.
-func (o OptionsEditMessageLiveLocation) SetHorizontalAccuracy(horizontalAccuracy float32) OptionsEditMessageLiveLocation {
+func (o OptionsEditMessageLiveLocation) SetHorizontalAccuracy(horizontalAccuracy float64) OptionsEditMessageLiveLocation {
 	o["horizontal_accuracy"] = horizontalAccuracy
 	return o
 }
@@ -2034,7 +2579,7 @@ func (o OptionsStopMessageLiveLocation) SetReplyMarkup(replyMarkup InlineKeyboar
 
 // OptionsAnswerInlineQuery struct for AnswerInlineQuery().
 //
-// options include: `cache_time`, `is_personal`, `next_offset`, `switch_pm_text`, and `switch_pm_parameter`.
+// options include: `cache_time`, `is_personal`, `next_offset`, and `button`.
 //
 // https://core.telegram.org/bots/api#answerinlinequery
 type OptionsAnswerInlineQuery MethodOptions
@@ -2058,6 +2603,8 @@ func (o OptionsAnswerInlineQuery) SetNextOffset(nextOffset string) OptionsAnswer
 }
 
 // SetButton sets the `button` value of OptionsAnswerInlineQuery.
+//
+// `button` can be generated with NewInlineQueryResultsButtonWithStartParameter() or NewInlineQueryResultsButtonWithWebApp().
 func (o OptionsAnswerInlineQuery) SetButton(button InlineQueryResultsButton) OptionsAnswerInlineQuery {
 	o["button"] = button
 	return o
@@ -2065,7 +2612,7 @@ func (o OptionsAnswerInlineQuery) SetButton(button InlineQueryResultsButton) Opt
 
 // OptionsSendInvoice struct for SendInvoice().
 //
-// options include: `message_thread_id`, `max_tip_amount`, `suggested_tip_amounts`, `start_parameter`, `provider_data`, `photo_url`, `photo_size`, `photo_width`, `photo_height`, `need_name`, `need_phone_number`, `need_email`, `need_shipping_address`, `send_phone_number_to_provider`, `send_email_to_provider`, `is_flexible`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`
+// options include: `message_thread_id`, `max_tip_amount`, `suggested_tip_amounts`, `start_parameter`, `provider_data`, `photo_url`, `photo_size`, `photo_width`, `photo_height`, `need_name`, `need_phone_number`, `need_email`, `need_shipping_address`, `send_phone_number_to_provider`, `send_email_to_provider`, `is_flexible`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, and `reply_markup`
 //
 // https://core.telegram.org/bots/api#sendinvoice
 type OptionsSendInvoice MethodOptions
@@ -2184,6 +2731,12 @@ func (o OptionsSendInvoice) SetReplyToMessageID(replyToMessageID int64) OptionsS
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendInvoice.
+func (o OptionsSendInvoice) SetReplyParameters(replyParameters ReplyParameters) OptionsSendInvoice {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendInvoice.
 func (o OptionsSendInvoice) SetAllowSendingWithoutReply(allow bool) OptionsSendInvoice {
 	o["allow_sending_without_reply"] = allow
@@ -2198,11 +2751,19 @@ func (o OptionsSendInvoice) SetReplyMarkup(replyMarkup InlineKeyboardMarkup) Opt
 
 // OptionsCreateInvoiceLink struct for CreateInvoiceLink().
 //
-// options include: `max_tip_amount`, `suggested_tip_amounts`, `provider_data`, `photo_url`, `photo_size`, `photo_width`, `photo_height`, `need_name`, `need_phone_number`, `need_email`, `need_shipping_address`, `send_phone_number_to_provider`, `send_email_to_provider`, and `is_flexible`.
+// options include: `subscription_period`, `max_tip_amount`, `suggested_tip_amounts`, `provider_data`, `photo_url`, `photo_size`, `photo_width`, `photo_height`, `need_name`, `need_phone_number`, `need_email`, `need_shipping_address`, `send_phone_number_to_provider`, `send_email_to_provider`, and `is_flexible`.
 //
 // https://core.telegram.org/bots/api#createinvoicelink
 type OptionsCreateInvoiceLink MethodOptions
 
+// SetSubscriptionPeriod sets the `subscription_period` value of OptionsCreateInvoiceLink.
+//
+// `subscriptionPeriod` is in seconds, and only StarsSubscriptionPeriod (30 days) is allowed for now. (Telegram Stars only)
+func (o OptionsCreateInvoiceLink) SetSubscriptionPeriod(subscriptionPeriod int) OptionsCreateInvoiceLink {
+	o["subscription_period"] = subscriptionPeriod
+	return o
+}
+
 // SetMaxTipAmount sets the `max_tip_amount` value of OptionsCreateInvoiceLink.
 func (o OptionsCreateInvoiceLink) SetMaxTipAmount(maxTipAmount int) OptionsCreateInvoiceLink {
 	o["max_tip_amount"] = maxTipAmount
@@ -2289,11 +2850,17 @@ func (o OptionsCreateInvoiceLink) SetIsFlexible(isFlexible bool) OptionsCreateIn
 
 // OptionsSendGame struct for SendGame()
 //
-// options include: `message_thread_id`, `disable_notification`, `protect_content`, `reply_to_message_id`, `allow_sending_without_reply`, and `reply_markup`.
+// options include: `message_thread_id`, `disable_notification`, `protect_content`, `reply_to_message_id`, `reply_parameters`, `allow_sending_without_reply`, `reply_markup`, and `business_connection_id`.
 //
 // https://core.telegram.org/bots/api#sendgame
 type OptionsSendGame MethodOptions
 
+// SetBusinessConnectionID sets the `business_connection_id` value of OptionsSendGame.
+func (o OptionsSendGame) SetBusinessConnectionID(businessConnectionID string) OptionsSendGame {
+	o["business_connection_id"] = businessConnectionID
+	return o
+}
+
 // SetMessageThreadID sets the `message_thread_id` value of OptionsSendGame.
 func (o OptionsSendGame) SetMessageThreadID(messageThreadID int64) OptionsSendGame {
 	o["message_thread_id"] = messageThreadID
@@ -2318,6 +2885,12 @@ func (o OptionsSendGame) SetReplyToMessageID(replyToMessageID int64) OptionsSend
 	return o
 }
 
+// SetReplyParameters sets the `reply_parameters` value of OptionsSendGame.
+func (o OptionsSendGame) SetReplyParameters(replyParameters ReplyParameters) OptionsSendGame {
+	o["reply_parameters"] = replyParameters
+	return o
+}
+
 // SetAllowSendingWithoutReply sets the `allow_sending_without_reply` value of OptionsSendGame.
 func (o OptionsSendGame) SetAllowSendingWithoutReply(allow bool) OptionsSendGame {
 	o["allow_sending_without_reply"] = allow
@@ -0,0 +1,222 @@
+package telegrambot
+
+// Metrics of API calls, received updates, handler panics, and rate limits, exposed in Prometheus text format.
+//
+// https://prometheus.io/docs/instrumenting/exposition_formats/
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultMetricsPath = "/metrics"
+
+	metricsNamespace = "telegrambot"
+)
+
+// upper bounds (in seconds) of the buckets of API request latencies
+var metricsLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics collects metrics of a bot, and serves them in Prometheus text format.
+//
+//	telegrambot_api_requests_total{method, status}          counter (status: "ok", `error_code`, or "error")
+//	telegrambot_api_request_duration_seconds{method}        histogram
+//	telegrambot_updates_received_total{type}                counter
+//	telegrambot_handler_panics_total{handler}               counter
+//	telegrambot_rate_limit_hits_total{source}               counter (source: "local" for throttled requests, "server" for 429 errors)
+type Metrics struct {
+	requests  map[[2]string]uint64 // by method and status
+	latencies map[string]*metricsHistogram
+	updates   map[string]uint64 // by update type
+	panics    map[string]uint64 // by handler name
+	rateLimit map[string]uint64 // by source
+
+	lock sync.Mutex
+}
+
+// a histogram of observed values
+type metricsHistogram struct {
+	counts []uint64 // (non-cumulative) counts of each bucket in metricsLatencyBuckets, and +Inf
+	sum    float64
+	count  uint64
+}
+
+// EnableMetrics mounts metrics of the bot on the internal server at given path (default: "/metrics"), and returns them.
+//
+// The returned Metrics is also an http.Handler, for serving it elsewhere.
+//
+//	client.EnableMetrics("")
+//	client.StartInternalServer("127.0.0.1:6060")
+//
+//	# prometheus.yml
+//	scrape_configs:
+//	  - job_name: "bot"
+//	    static_configs:
+//	      - targets: ["127.0.0.1:6060"]
+func (b *Bot) EnableMetrics(path string) *Metrics {
+	if path == "" {
+		path = defaultMetricsPath
+	}
+
+	metrics := &Metrics{
+		requests:  map[[2]string]uint64{},
+		latencies: map[string]*metricsHistogram{},
+		updates:   map[string]uint64{},
+		panics:    map[string]uint64{},
+		rateLimit: map[string]uint64{},
+	}
+	b.InternalServeMux().Handle(path, metrics)
+	b.metrics = metrics
+
+	b.AddAfterResponseHook(metrics.observeRequest)
+
+	return metrics
+}
+
+// ServeHTTP writes the metrics in Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.Write(w)
+}
+
+// Write writes the metrics in Prometheus text format to `w`.
+func (m *Metrics) Write(w io.Writer) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var sb strings.Builder
+
+	writeMetricsHeader(&sb, "api_requests_total", "counter", "Number of API requests by method and status.")
+	for _, key := range sortedKeys(m.requests, func(k [2]string) string { return k[0] + "\xff" + k[1] }) {
+		writeMetricsSample(&sb, "api_requests_total", []string{"method", key[0], "status", key[1]}, float64(m.requests[key]))
+	}
+
+	writeMetricsHeader(&sb, "api_request_duration_seconds", "histogram", "Latency of API requests by method.")
+	for _, method := range sortedKeys(m.latencies, func(k string) string { return k }) {
+		histogram := m.latencies[method]
+
+		var cumulative uint64
+		for i, bound := range metricsLatencyBuckets {
+			cumulative += histogram.counts[i]
+			writeMetricsSample(&sb, "api_request_duration_seconds_bucket", []string{"method", method, "le", strconv.FormatFloat(bound, 'g', -1, 64)}, float64(cumulative))
+		}
+		writeMetricsSample(&sb, "api_request_duration_seconds_bucket", []string{"method", method, "le", "+Inf"}, float64(histogram.count))
+		writeMetricsSample(&sb, "api_request_duration_seconds_sum", []string{"method", method}, histogram.sum)
+		writeMetricsSample(&sb, "api_request_duration_seconds_count", []string{"method", method}, float64(histogram.count))
+	}
+
+	writeMetricsHeader(&sb, "updates_received_total", "counter", "Number of received updates by type.")
+	for _, updateType := range sortedKeys(m.updates, func(k string) string { return k }) {
+		writeMetricsSample(&sb, "updates_received_total", []string{"type", updateType}, float64(m.updates[updateType]))
+	}
+
+	writeMetricsHeader(&sb, "handler_panics_total", "counter", "Number of panics recovered from update handlers.")
+	for _, handler := range sortedKeys(m.panics, func(k string) string { return k }) {
+		writeMetricsSample(&sb, "handler_panics_total", []string{"handler", handler}, float64(m.panics[handler]))
+	}
+
+	writeMetricsHeader(&sb, "rate_limit_hits_total", "counter", "Number of requests throttled locally, or rejected with 429 errors.")
+	for _, source := range sortedKeys(m.rateLimit, func(k string) string { return k }) {
+		writeMetricsSample(&sb, "rate_limit_hits_total", []string{"source", source}, float64(m.rateLimit[source]))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// observe a completed API request (as an AfterResponseHook)
+func (m *Metrics) observeRequest(event RequestEvent) {
+	status := "ok"
+	if !event.Ok {
+		if event.ErrorCode != 0 {
+			status = strconv.Itoa(event.ErrorCode)
+		} else {
+			status = "error"
+		}
+	}
+	seconds := event.Duration.Seconds()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.requests[[2]string{event.Method, status}]++
+
+	histogram, exists := m.latencies[event.Method]
+	if !exists {
+		histogram = &metricsHistogram{counts: make([]uint64, len(metricsLatencyBuckets)+1)}
+		m.latencies[event.Method] = histogram
+	}
+	index := sort.SearchFloat64s(metricsLatencyBuckets, seconds) // first bucket whose bound >= seconds
+	histogram.counts[index]++
+	histogram.sum += seconds
+	histogram.count++
+
+	if event.ErrorCode == 429 {
+		m.rateLimit["server"]++
+	}
+}
+
+// count a received update
+func (m *Metrics) observeUpdate(update Update) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.updates[string(update.Type())]++
+}
+
+// count a panic of a handler
+func (m *Metrics) observePanic(handler string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.panics[handler]++
+}
+
+// count a request throttled by local rate limits
+func (m *Metrics) observeThrottle() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.rateLimit["local"]++
+}
+
+// write HELP and TYPE lines of a metric
+func writeMetricsHeader(sb *strings.Builder, name, metricType, help string) {
+	fmt.Fprintf(sb, "# HELP %s_%s %s\n", metricsNamespace, name, help)
+	fmt.Fprintf(sb, "# TYPE %s_%s %s\n", metricsNamespace, name, metricType)
+}
+
+// write a sample line with labels (pairs of names and values)
+func writeMetricsSample(sb *strings.Builder, name string, labels []string, value float64) {
+	sb.WriteString(metricsNamespace + "_" + name)
+	if len(labels) > 0 {
+		sb.WriteByte('{')
+		for i := 0; i < len(labels); i += 2 {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(labels[i] + `="` + metricsLabelEscaper.Replace(labels[i+1]) + `"`)
+		}
+		sb.WriteByte('}')
+	}
+	sb.WriteString(" " + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+}
+
+// replacer for escaping label values
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// keys of given map, sorted by their string representations
+func sortedKeys[K comparable, V any](m map[K]V, str func(K) string) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return str(keys[i]) < str(keys[j]) })
+	return keys
+}
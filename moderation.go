@@ -0,0 +1,417 @@
+package telegrambot
+
+// High-level moderation helpers: temporary bans, mutes, and warnings, with human-readable durations.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ModerationAction is a type of moderation action
+type ModerationAction string
+
+// ModerationAction strings
+const (
+	ModerationActionBan    ModerationAction = "ban"
+	ModerationActionUnban  ModerationAction = "unban"
+	ModerationActionKick   ModerationAction = "kick"
+	ModerationActionMute   ModerationAction = "mute"
+	ModerationActionUnmute ModerationAction = "unmute"
+	ModerationActionWarn   ModerationAction = "warn"
+)
+
+// default values of ModerationConfig
+const (
+	defaultMaxWarnings        = 3
+	defaultWarnActionDuration = 24 * time.Hour
+)
+
+// ModerationConfig is a configuration for moderation helpers
+type ModerationConfig struct {
+	AuditChatID ChatID // chat (eg. a private channel) where moderation actions are logged (nil for no logging)
+
+	Store       CacheStore    // store for warnings (default: a new MemoryCacheStore)
+	WarningsTTL time.Duration // warnings are forgotten after this duration (0 for never)
+
+	MaxWarnings        int              // number of warnings which triggers WarnAction (default: 3)
+	WarnAction         ModerationAction // ModerationActionMute (default), ModerationActionBan, or ModerationActionKick
+	WarnActionDuration time.Duration    // duration of WarnAction (default: 24 hours)
+}
+
+// fill default values of the configuration
+func (c ModerationConfig) withDefaults() ModerationConfig {
+	if c.Store == nil {
+		c.Store = NewMemoryCacheStore()
+	}
+	if c.MaxWarnings <= 0 {
+		c.MaxWarnings = defaultMaxWarnings
+	}
+	if c.WarnAction == "" {
+		c.WarnAction = ModerationActionMute
+	}
+	if c.WarnActionDuration <= 0 {
+		c.WarnActionDuration = defaultWarnActionDuration
+	}
+	return c
+}
+
+// SetModerationConfig sets the configuration of moderation helpers. (TempBan, Kick, Mute, Unmute, and Warn)
+func (b *Bot) SetModerationConfig(config ModerationConfig) {
+	b.moderation = config.withDefaults()
+}
+
+////////////////////////////////
+// durations
+//
+
+// units of human durations
+var _durationUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseDuration parses a human duration like "30m", "2h", "1d12h", or "1w".
+//
+// Units are: `s`, `m`, `h`, `d` (days), and `w` (weeks).
+// An empty string, "0", "forever", or "permanent" is parsed as 0 (= forever).
+func ParseDuration(str string) (duration time.Duration, err error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+
+	switch str {
+	case "", "0", "forever", "permanent":
+		return 0, nil
+	}
+
+	remaining := str
+	for remaining != "" {
+		// number
+		i := 0
+		for i < len(remaining) && remaining[i] >= '0' && remaining[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration: '%s'", str)
+		}
+		value, err := strconv.ParseInt(remaining[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: '%s'", str)
+		}
+		remaining = remaining[i:]
+
+		// unit
+		j := 0
+		for j < len(remaining) && (remaining[j] < '0' || remaining[j] > '9') {
+			j++
+		}
+		unit, exists := _durationUnits[strings.TrimSpace(remaining[:j])]
+		if !exists {
+			return 0, fmt.Errorf("invalid unit of duration: '%s'", str)
+		}
+		remaining = remaining[j:]
+
+		duration += time.Duration(value) * unit
+	}
+
+	return duration, nil
+}
+
+// FormatDuration formats given duration in the format of ParseDuration(). (eg. "1d12h")
+//
+// Durations shorter than a second (including 0) are formatted as "forever".
+func FormatDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return "forever"
+	}
+
+	var sb strings.Builder
+	for _, unit := range []string{"w", "d", "h", "m", "s"} {
+		if n := duration / _durationUnits[unit]; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10) + unit)
+			duration -= n * _durationUnits[unit]
+		}
+	}
+	return sb.String()
+}
+
+// range of durations of bans and restrictions (Telegram treats shorter or longer ones as forever)
+const (
+	minRestrictionDuration    = 30 * time.Second
+	maxRestrictionDuration    = 366 * 24 * time.Hour
+	restrictionDurationMargin = 10 * time.Second // margin for the latency of requests
+)
+
+// clamp given duration of a ban or restriction, so that it is not treated as forever (0 for forever)
+func clampRestrictionDuration(duration time.Duration) time.Duration {
+	switch {
+	case duration <= 0:
+		return 0
+	case duration < minRestrictionDuration+restrictionDurationMargin:
+		return minRestrictionDuration + restrictionDurationMargin
+	case duration > maxRestrictionDuration-restrictionDurationMargin:
+		return maxRestrictionDuration - restrictionDurationMargin
+	}
+	return duration
+}
+
+// `until_date` for given duration (0 for forever), and the duration clamped with clampRestrictionDuration()
+func (b *Bot) untilDate(duration time.Duration) (until int, clamped time.Duration) {
+	if clamped = clampRestrictionDuration(duration); clamped != duration {
+		b.verbose("moderation: clamped duration %s to %s", duration, clamped)
+	}
+	if clamped <= 0 {
+		return 0, 0
+	}
+	return int(time.Now().Add(clamped).Unix()), clamped
+}
+
+////////////////////////////////
+// actions
+//
+
+// TempBan bans a user from a chat for given duration (0 for forever), and logs it to the audit chat.
+//
+// Durations are clamped between 30 seconds and 366 days, as Telegram treats others as forever.
+//
+//	bot.TempBan(chatID, userID, 24*time.Hour, "spam")
+func (b *Bot) TempBan(chatID ChatID, userID int64, duration time.Duration, reason string) (result APIResponse[bool]) {
+	options := OptionsBanChatMember{}
+	until, duration := b.untilDate(duration)
+	if until != 0 {
+		options.SetUntilDate(until)
+	}
+
+	result = b.BanChatMember(chatID, userID, options)
+	if result.Ok {
+		b.logModeration(ModerationActionBan, chatID, userID, duration, reason)
+	}
+	return result
+}
+
+// TempBanFor bans a user from a chat for given human duration (see ParseDuration()), and logs it to the audit chat.
+//
+//	bot.TempBanFor(chatID, userID, "1d", "spam")
+func (b *Bot) TempBanFor(chatID ChatID, userID int64, duration, reason string) (result APIResponse[bool]) {
+	parsed, err := ParseDuration(duration)
+	if err != nil {
+		errStr := err.Error()
+
+		b.error(errStr)
+
+		return APIResponse[bool]{Ok: false, Description: &errStr}
+	}
+	return b.TempBan(chatID, userID, parsed, reason)
+}
+
+// Kick removes a user from a chat (the user can join again), and logs it to the audit chat.
+func (b *Bot) Kick(chatID ChatID, userID int64, reason string) (result APIResponse[bool]) {
+	if result = b.BanChatMember(chatID, userID, nil); !result.Ok {
+		return result
+	}
+	if result = b.UnbanChatMember(chatID, userID, true); result.Ok {
+		b.logModeration(ModerationActionKick, chatID, userID, 0, reason)
+	}
+	return result
+}
+
+// Mute restricts a user to read-only in a chat for given duration (0 for forever), and logs it to the audit chat.
+//
+// Durations are clamped between 30 seconds and 366 days, as Telegram treats others as forever.
+//
+//	bot.Mute(chatID, userID, 30*time.Minute, "flooding")
+func (b *Bot) Mute(chatID ChatID, userID int64, duration time.Duration, reason string) (result APIResponse[bool]) {
+	options := OptionsRestrictChatMember{}
+	until, duration := b.untilDate(duration)
+	if until != 0 {
+		options.SetUntilDate(until)
+	}
+
+	result = b.RestrictChatMember(chatID, userID, ReadOnlyChatPermissions(), options)
+	if result.Ok {
+		b.logModeration(ModerationActionMute, chatID, userID, duration, reason)
+	}
+	return result
+}
+
+// MuteFor restricts a user to read-only in a chat for given human duration (see ParseDuration()), and logs it to the audit chat.
+//
+//	bot.MuteFor(chatID, userID, "30m", "flooding")
+func (b *Bot) MuteFor(chatID ChatID, userID int64, duration, reason string) (result APIResponse[bool]) {
+	parsed, err := ParseDuration(duration)
+	if err != nil {
+		errStr := err.Error()
+
+		b.error(errStr)
+
+		return APIResponse[bool]{Ok: false, Description: &errStr}
+	}
+	return b.Mute(chatID, userID, parsed, reason)
+}
+
+// Unmute lifts restrictions of a user in a chat to the chat's default permissions, and logs it to the audit chat.
+func (b *Bot) Unmute(chatID ChatID, userID int64, reason string) (result APIResponse[bool]) {
+	result = b.RestrictChatMember(chatID, userID, b.defaultChatPermissions(chatID), nil)
+	if result.Ok {
+		b.logModeration(ModerationActionUnmute, chatID, userID, 0, reason)
+	}
+	return result
+}
+
+// Warn gives a warning to a user in a chat, and logs it to the audit chat.
+//
+// When the user's warnings reach `MaxWarnings` of ModerationConfig, `WarnAction` is applied
+// and the warnings are reset.
+//
+// Result is the number of warnings of the user, including this one.
+func (b *Bot) Warn(chatID ChatID, userID int64, reason string) (result APIResponse[int]) {
+	config := b.moderation
+	key := warningsKey(chatID, userID)
+
+	// NOTE: serialize warnings of the same user, so that concurrent ones are not lost (or acted on twice)
+	unlock := b.warningLocks.acquire(key)
+	defer unlock()
+
+	warnings := b.Warnings(chatID, userID) + 1
+	config.Store.Set(key, warnings, config.WarningsTTL)
+
+	b.logModeration(ModerationActionWarn, chatID, userID, 0, fmt.Sprintf("%s (%d/%d)", reason, warnings, config.MaxWarnings))
+
+	if warnings >= config.MaxWarnings {
+		reason = fmt.Sprintf("%d warnings: %s", warnings, reason)
+
+		var res APIResponse[bool]
+		switch config.WarnAction {
+		case ModerationActionBan:
+			res = b.TempBan(chatID, userID, config.WarnActionDuration, reason)
+		case ModerationActionKick:
+			res = b.Kick(chatID, userID, reason)
+		default:
+			res = b.Mute(chatID, userID, config.WarnActionDuration, reason)
+		}
+		if !res.Ok {
+			return APIResponse[int]{Ok: false, ErrorCode: res.ErrorCode, Description: res.Description, Parameters: res.Parameters, Result: &warnings}
+		}
+
+		config.Store.Delete(key)
+	}
+
+	return APIResponse[int]{Ok: true, Result: &warnings}
+}
+
+// Warnings returns the number of warnings of a user in a chat.
+func (b *Bot) Warnings(chatID ChatID, userID int64) int {
+	if value, exists := b.moderation.Store.Get(warningsKey(chatID, userID)); exists {
+		switch v := value.(type) {
+		case int:
+			return v
+		case int32:
+			return int(v)
+		case int64:
+			return int(v)
+		case float32:
+			return int(v)
+		case float64: // eg. decoded from JSON
+			return int(v)
+		case json.Number:
+			if warnings, err := v.Int64(); err == nil {
+				return int(warnings)
+			}
+		case string:
+			if warnings, err := strconv.Atoi(v); err == nil {
+				return warnings
+			}
+		}
+		b.error("ignoring warnings with unexpected value: %v (%T)", value, value)
+	}
+	return 0
+}
+
+// ResetWarnings resets the warnings of a user in a chat.
+func (b *Bot) ResetWarnings(chatID ChatID, userID int64) {
+	b.moderation.Store.Delete(warningsKey(chatID, userID))
+}
+
+// key of warnings in the store
+func warningsKey(chatID ChatID, userID int64) string {
+	return fmt.Sprintf("warnings:%v:%d", chatID, userID)
+}
+
+// mutexes per key, which are removed when unused
+type keyedLocks struct {
+	locks map[string]*keyedLock
+	lock  sync.Mutex
+}
+
+// a mutex with the number of its holders and waiters
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock the mutex of given key, and return a function which unlocks it
+func (l *keyedLocks) acquire(key string) (unlock func()) {
+	l.lock.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*keyedLock{}
+	}
+	kl, exists := l.locks[key]
+	if !exists {
+		kl = &keyedLock{}
+		l.locks[key] = kl
+	}
+	kl.refs++
+	l.lock.Unlock()
+
+	kl.Lock()
+
+	return func() {
+		kl.Unlock()
+
+		l.lock.Lock()
+		kl.refs--
+		if kl.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.lock.Unlock()
+	}
+}
+
+// default permissions of given chat (or all permissions if they cannot be fetched)
+func (b *Bot) defaultChatPermissions(chatID ChatID) ChatPermissions {
+	if res := b.GetChat(chatID); res.Ok && res.Result != nil && res.Result.Permissions != nil {
+		return *res.Result.Permissions
+	}
+	return AllChatPermissions()
+}
+
+// log a moderation action to the audit chat
+func (b *Bot) logModeration(action ModerationAction, chatID ChatID, userID int64, duration time.Duration, reason string) {
+	b.verbose("moderation: %s user %d in chat %v (%s)", action, userID, chatID, reason)
+
+	if b.moderation.AuditChatID == nil {
+		return
+	}
+
+	lines := []string{
+		"#" + string(action),
+		fmt.Sprintf("chat: %v", chatID),
+		fmt.Sprintf("user: %d", userID),
+	}
+	switch action {
+	case ModerationActionBan, ModerationActionMute:
+		lines = append(lines, "duration: "+FormatDuration(duration))
+	}
+	if reason != "" {
+		lines = append(lines, "reason: "+reason)
+	}
+
+	if res := b.SendMessage(b.moderation.AuditChatID, strings.Join(lines, "\n"), nil); !res.Ok {
+		b.error("failed to log moderation action to audit chat: %s", res.Err())
+	}
+}
@@ -0,0 +1,188 @@
+package telegrambot
+
+// Paginated inline keyboards for picking one of many items.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// default labels of navigation buttons
+const (
+	defaultPaginatorPrevText = "« Prev"
+	defaultPaginatorNextText = "Next »"
+)
+
+// callback data kinds of paginated keyboards
+const (
+	paginatorKindPage      = "p"
+	paginatorKindItem      = "i"
+	paginatorKindIndicator = "n"
+)
+
+// Paginator generates paginated inline keyboards of items, and decodes their callback data.
+//
+// Callback data are in the form of "prefix:kind:number", so `Prefix` should be unique among
+// callback data of the bot, and the items should not change between pages (eg. sorted results).
+//
+//	paginator := NewPaginator("fruits", fruits, 5, func(fruit Fruit) string { return fruit.Name })
+//	bot.SendMessage(chatID, "Pick one:", OptionsSendMessage{}.SetReplyMarkup(paginator.Keyboard(0)))
+//
+//	// on callback query:
+//	if selection, ok := paginator.Decode(*query.Data); ok {
+//		if selection.Item != nil {
+//			// selected: *selection.Item
+//		} else if !selection.Indicator {
+//			bot.EditMessageReplyMarkup(OptionsEditMessageReplyMarkup{}.
+//				SetIDs(query.Message.Chat.ID, query.Message.MessageID).
+//				SetReplyMarkup(paginator.Keyboard(selection.Page)))
+//		}
+//	}
+type Paginator[T any] struct {
+	Prefix   string
+	Items    []T
+	PageSize int
+	Label    func(item T) string
+
+	Columns  int    // number of item buttons in a row (default: 1)
+	PrevText string // label of the previous page button
+	NextText string // label of the next page button
+}
+
+// NewPaginator returns a new Paginator for given items.
+func NewPaginator[T any](prefix string, items []T, pageSize int, label func(item T) string) *Paginator[T] {
+	return &Paginator[T]{
+		Prefix:   prefix,
+		Items:    items,
+		PageSize: pageSize,
+		Label:    label,
+
+		Columns:  1,
+		PrevText: defaultPaginatorPrevText,
+		NextText: defaultPaginatorNextText,
+	}
+}
+
+// PaginatorSelection is a decoded callback data of a paginated keyboard
+type PaginatorSelection[T any] struct {
+	Page      int  // page to show (for navigation), or the page of the selected item
+	Index     int  // index of the selected item in Items (-1 for navigation)
+	Item      *T   // selected item (nil for navigation)
+	Indicator bool // whether the page indicator (which does nothing) was pressed
+}
+
+// Pages returns the number of pages.
+func (p *Paginator[T]) Pages() int {
+	if len(p.Items) == 0 {
+		return 1
+	}
+	return (len(p.Items) + p.pageSize() - 1) / p.pageSize()
+}
+
+// Keyboard returns the inline keyboard of given page (0-based, clamped to valid pages).
+func (p *Paginator[T]) Keyboard(page int) InlineKeyboardMarkup {
+	page = p.clamp(page)
+
+	columns := p.Columns
+	if columns <= 0 {
+		columns = 1
+	}
+
+	keyboard := [][]InlineKeyboardButton{}
+
+	// items
+	row := []InlineKeyboardButton{}
+	for i := page * p.pageSize(); i < len(p.Items) && i < (page+1)*p.pageSize(); i++ {
+		row = append(row, p.button(p.Label(p.Items[i]), paginatorKindItem, i))
+		if len(row) >= columns {
+			keyboard = append(keyboard, row)
+			row = []InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	// navigation
+	if pages := p.Pages(); pages > 1 {
+		navigation := []InlineKeyboardButton{}
+		if page > 0 {
+			navigation = append(navigation, p.button(p.PrevText, paginatorKindPage, page-1))
+		}
+		navigation = append(navigation, p.button(fmt.Sprintf("%d/%d", page+1, pages), paginatorKindIndicator, page))
+		if page < pages-1 {
+			navigation = append(navigation, p.button(p.NextText, paginatorKindPage, page+1))
+		}
+		keyboard = append(keyboard, navigation)
+	}
+
+	return InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// Decode decodes callback data from a keyboard of this paginator.
+//
+// `ok` is false when the callback data is not from this paginator, or it points to an item which does not exist.
+func (p *Paginator[T]) Decode(data string) (selection PaginatorSelection[T], ok bool) {
+	rest, found := strings.CutPrefix(data, p.Prefix+":")
+	if !found {
+		return selection, false
+	}
+	kind, number, found := strings.Cut(rest, ":")
+	if !found {
+		return selection, false
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 0 {
+		return selection, false
+	}
+
+	switch kind {
+	case paginatorKindPage, paginatorKindIndicator:
+		return PaginatorSelection[T]{
+			Page:      p.clamp(n),
+			Index:     -1,
+			Indicator: kind == paginatorKindIndicator,
+		}, true
+	case paginatorKindItem:
+		if n >= len(p.Items) {
+			return selection, false
+		}
+		return PaginatorSelection[T]{
+			Page:  n / p.pageSize(),
+			Index: n,
+			Item:  &p.Items[n],
+		}, true
+	}
+
+	return selection, false
+}
+
+// number of items in a page
+func (p *Paginator[T]) pageSize() int {
+	if p.PageSize <= 0 {
+		return 1
+	}
+	return p.PageSize
+}
+
+// clamp given page to valid pages
+func (p *Paginator[T]) clamp(page int) int {
+	if page >= p.Pages() {
+		page = p.Pages() - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	return page
+}
+
+// button with callback data
+func (p *Paginator[T]) button(text, kind string, number int) InlineKeyboardButton {
+	data := fmt.Sprintf("%s:%s:%d", p.Prefix, kind, number)
+
+	return InlineKeyboardButton{
+		Text:         text,
+		CallbackData: &data,
+	}
+}
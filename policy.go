@@ -0,0 +1,445 @@
+package telegrambot
+
+// Per-method policies (timeouts, retries, and rate limits) of API requests.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MethodClass is a class of API methods which share a MethodPolicy
+type MethodClass string
+
+// MethodClass strings
+const (
+	MethodClassUpdates MethodClass = "updates" // getUpdates (long polling)
+	MethodClassUpload  MethodClass = "upload"  // requests with files to upload
+	MethodClassSend    MethodClass = "send"    // sendXXX, forwardMessage(s), and copyMessage(s)
+	MethodClassDefault MethodClass = "default" // all other methods
+)
+
+// default timeouts of method classes
+const (
+	defaultMethodTimeout = 10 * time.Second
+	defaultUploadTimeout = 5 * time.Minute
+	defaultRetryDelay    = 1 * time.Second
+	defaultMaxRetryDelay = 30 * time.Second
+)
+
+// MethodPolicy is a policy of API requests for a MethodClass
+type MethodPolicy struct {
+	// timeout of each request (0 for no timeout)
+	//
+	// For getUpdates, the long polling `timeout` param is added to this value.
+	Timeout time.Duration
+
+	// number of retries on network errors, 5xx errors, and 429 Too Many Requests (0 for no retry)
+	//
+	// NOTE: a request which timed out (or failed with a 5xx error) may have been processed by Telegram,
+	// so retried sends can be duplicated. (see SetIdempotencyKey() of send options)
+	MaxRetries int
+
+	// initial delay before retrying on network errors and 5xx errors (default: 1 second),
+	// doubled for each retry with jitter (429 errors are retried after their `retry_after`)
+	RetryDelay time.Duration
+
+	// maximum delay between retries on network errors and 5xx errors (default: 30 seconds)
+	MaxRetryDelay time.Duration
+
+	// name of the rate limit bucket (see SetRateLimit()) which requests wait for ("" for no rate limit)
+	RateLimitBucket string
+}
+
+// default policies of method classes
+func defaultMethodPolicies() map[MethodClass]MethodPolicy {
+	return map[MethodClass]MethodPolicy{
+		MethodClassUpdates: {Timeout: defaultMethodTimeout},
+		MethodClassUpload:  {Timeout: defaultUploadTimeout, RateLimitBucket: string(MethodClassSend)},
+		MethodClassSend:    {Timeout: defaultMethodTimeout, RateLimitBucket: string(MethodClassSend)},
+		MethodClassDefault: {Timeout: defaultMethodTimeout},
+	}
+}
+
+// methodPolicies is a registry of method policies
+type methodPolicies struct {
+	policies   map[MethodClass]MethodPolicy
+	classes    map[string]MethodClass // overridden classes of methods
+	rateLimits map[string]*rateLimiter
+	chatLimits *chatRateLimiters // rate limiters per chat (nil for no limit)
+
+	lock sync.RWMutex
+}
+
+// SetMethodPolicy sets the policy of given method class.
+//
+//	// retry getUpdates on network errors, and wait longer for uploads
+//	client.SetMethodPolicy(MethodClassUpdates, MethodPolicy{Timeout: 10 * time.Second, MaxRetries: 3})
+//	client.SetMethodPolicy(MethodClassUpload, MethodPolicy{Timeout: 10 * time.Minute})
+func (b *Bot) SetMethodPolicy(class MethodClass, policy MethodPolicy) {
+	b.policies.lock.Lock()
+	defer b.policies.lock.Unlock()
+
+	b.policies.policies[class] = policy
+}
+
+// MethodPolicy returns the policy of given method class.
+func (b *Bot) MethodPolicy(class MethodClass) MethodPolicy {
+	b.policies.lock.RLock()
+	defer b.policies.lock.RUnlock()
+
+	if policy, exists := b.policies.policies[class]; exists {
+		return policy
+	}
+	return b.policies.policies[MethodClassDefault]
+}
+
+// SetMethodClass overrides the class of given method. (eg. a custom class for "answerCallbackQuery")
+//
+// A custom class without a policy falls back to the policy of MethodClassDefault.
+func (b *Bot) SetMethodClass(method string, class MethodClass) {
+	b.policies.lock.Lock()
+	defer b.policies.lock.Unlock()
+
+	b.policies.classes[method] = class
+}
+
+// SetRateLimit sets the rate limit of given bucket: `perSecond` requests per second, with bursts of `burst` requests.
+// (no rate limit if `perSecond` <= 0)
+//
+// Requests of method classes with the bucket (MethodPolicy.RateLimitBucket) wait for it.
+//
+//	// Telegram allows about 30 messages per second
+//	client.SetRateLimit(string(MethodClassSend), 30, 30)
+func (b *Bot) SetRateLimit(bucket string, perSecond float64, burst int) {
+	b.policies.lock.Lock()
+	defer b.policies.lock.Unlock()
+
+	if perSecond <= 0 {
+		delete(b.policies.rateLimits, bucket)
+		return
+	}
+	b.policies.rateLimits[bucket] = newRateLimiter(perSecond, burst)
+}
+
+// SetMaxRetries sets the number of retries on network errors, 5xx errors, and 429 Too Many Requests
+// for all method classes. (0 for no retry)
+//
+// Retries are done with jittered exponential backoff, and 429 errors are retried after their `retry_after`.
+// Use SetMethodPolicy() for configuring each method class.
+func (b *Bot) SetMaxRetries(maxRetries int) {
+	b.policies.lock.Lock()
+	defer b.policies.lock.Unlock()
+
+	for class, policy := range b.policies.policies {
+		policy.MaxRetries = maxRetries
+		b.policies.policies[class] = policy
+	}
+}
+
+// ChatRateLimit is a configuration of rate limits for sending messages, per chat and in total
+type ChatRateLimit struct {
+	PerSecond float64 // messages per second to each private chat (default: 1)
+	Burst     int     // bursts of messages to each private chat (default: 1)
+
+	GroupPerSecond float64 // messages per second to each group or channel (default: 20 per minute)
+	GroupBurst     int     // bursts of messages to each group or channel (default: 3)
+
+	GlobalPerSecond float64 // messages per second to all chats (default: 30, negative for no limit)
+	GlobalBurst     int     // bursts of messages to all chats (default: `GlobalPerSecond`)
+}
+
+// default values of ChatRateLimit (from Telegram's documented limits)
+const (
+	defaultChatPerSecond       = 1
+	defaultChatBurst           = 1
+	defaultGroupChatPerSecond  = 20.0 / 60
+	defaultGroupChatBurst      = 3
+	defaultGlobalSendPerSecond = 30
+)
+
+// fill default values of the configuration
+func (c ChatRateLimit) withDefaults() ChatRateLimit {
+	if c.PerSecond <= 0 {
+		c.PerSecond = defaultChatPerSecond
+	}
+	if c.Burst <= 0 {
+		c.Burst = defaultChatBurst
+	}
+	if c.GroupPerSecond <= 0 {
+		c.GroupPerSecond = defaultGroupChatPerSecond
+	}
+	if c.GroupBurst <= 0 {
+		c.GroupBurst = defaultGroupChatBurst
+	}
+	if c.GlobalPerSecond == 0 {
+		c.GlobalPerSecond = defaultGlobalSendPerSecond
+	}
+	if c.GlobalBurst <= 0 {
+		c.GlobalBurst = int(math.Max(1, c.GlobalPerSecond))
+	}
+	return c
+}
+
+// SetChatRateLimit sets rate limits of sending messages (requests of MethodClassSend and MethodClassUpload),
+// or removes them (including the global bucket of MethodClassSend) if `limit` is nil.
+//
+// Requests wait for the token bucket of their `chat_id` (private chats and groups have different limits),
+// and the global bucket of MethodClassSend (see SetRateLimit()), instead of failing with 429 errors.
+//
+//	client.SetChatRateLimit(&ChatRateLimit{}) // with default limits
+func (b *Bot) SetChatRateLimit(limit *ChatRateLimit) {
+	if limit == nil {
+		b.policies.lock.Lock()
+		b.policies.chatLimits = nil
+		b.policies.lock.Unlock()
+
+		b.SetRateLimit(string(MethodClassSend), 0, 0)
+		return
+	}
+
+	config := limit.withDefaults()
+
+	b.policies.lock.Lock()
+	b.policies.chatLimits = &chatRateLimiters{
+		config:   config,
+		limiters: map[string]*rateLimiter{},
+	}
+	b.policies.lock.Unlock()
+
+	b.SetRateLimit(string(MethodClassSend), config.GlobalPerSecond, config.GlobalBurst)
+}
+
+// class of given method with params
+func (b *Bot) methodClass(method string, params map[string]any) MethodClass {
+	b.policies.lock.RLock()
+	class, exists := b.policies.classes[method]
+	b.policies.lock.RUnlock()
+	if exists {
+		return class
+	}
+
+	switch {
+	case method == "getUpdates":
+		return MethodClassUpdates
+	case checkIfFileParamExists(params):
+		return MethodClassUpload
+	case strings.HasPrefix(method, "send") || method == "forwardMessage" || method == "copyMessage" ||
+		method == "forwardMessages" || method == "copyMessages":
+		return MethodClassSend
+	}
+	return MethodClassDefault
+}
+
+// context with the timeout of given policy (and params)
+func (p MethodPolicy) context(class MethodClass, params map[string]any) (context.Context, context.CancelFunc) {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	if class == MethodClassUpdates {
+		if seconds, ok := params["timeout"].(int); ok && seconds > 0 {
+			timeout += time.Duration(seconds) * time.Second
+		}
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// wait for the rate limit of given bucket
+func (b *Bot) waitRateLimit(bucket string) {
+	if bucket == "" {
+		return
+	}
+
+	b.policies.lock.RLock()
+	limiter := b.policies.rateLimits[bucket]
+	b.policies.lock.RUnlock()
+
+	if limiter != nil && limiter.wait() > 0 && b.metrics != nil {
+		b.metrics.observeThrottle()
+	}
+}
+
+// wait for the rate limit of the chat in given params, if it is a request for sending messages
+func (b *Bot) waitChatRateLimit(class MethodClass, params map[string]any) {
+	if class != MethodClassSend && class != MethodClassUpload {
+		return
+	}
+	chatID, exists := params["chat_id"]
+	if !exists {
+		return
+	}
+
+	b.policies.lock.RLock()
+	limiters := b.policies.chatLimits
+	b.policies.lock.RUnlock()
+
+	if limiters != nil && limiters.limiter(chatID).wait() > 0 && b.metrics != nil {
+		b.metrics.observeThrottle()
+	}
+}
+
+// delay before retrying a request which failed with `err` or `resp` (decoded with `codec`) on given attempt (from 0),
+// or false if it should not be retried
+func (p MethodPolicy) retryDelay(codec JSONCodec, resp []byte, err error, attempt int) (delay time.Duration, retry bool) {
+	if err != nil {
+		return p.backoff(attempt), true
+	}
+
+	var res APIResponse[json.RawMessage]
+	if codec.Unmarshal(resp, &res) != nil || res.Ok {
+		return 0, false
+	}
+	switch {
+	case res.ErrorCode == 429:
+		if res.Parameters != nil && res.Parameters.RetryAfter > 0 {
+			return time.Duration(res.Parameters.RetryAfter) * time.Second, true
+		}
+		return p.backoff(attempt), true
+	case res.ErrorCode >= 500:
+		return p.backoff(attempt), true
+	}
+	return 0, false
+}
+
+// jittered exponential backoff for given attempt (from 0): a random delay between the half and the whole of
+// `RetryDelay` * 2^attempt, capped at `MaxRetryDelay`
+func (p MethodPolicy) backoff(attempt int) time.Duration {
+	base, maxDelay := p.RetryDelay, p.MaxRetryDelay
+	if base <= 0 {
+		base = defaultRetryDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+
+	delay := base
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+////////////////////////////////
+// rate limiters
+//
+
+// rateLimiter is a token bucket rate limiter
+type rateLimiter struct {
+	perSecond float64
+	burst     float64
+
+	tokens float64
+	last   time.Time
+	lock   sync.Mutex
+}
+
+// number of new chat rate limiters between sweeps of idle ones
+const chatRateLimitersSweepInterval = 1024
+
+// chatRateLimiters is a set of rate limiters per chat
+type chatRateLimiters struct {
+	config   ChatRateLimit
+	limiters map[string]*rateLimiter
+	created  int
+
+	lock sync.Mutex
+}
+
+// rate limiter of given chat id (created if not exists)
+func (c *chatRateLimiters) limiter(chatID ChatID) *rateLimiter {
+	key := fmt.Sprint(chatID)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if limiter, exists := c.limiters[key]; exists {
+		return limiter
+	}
+
+	// sweep limiters with full buckets periodically, as they are the same as new ones
+	if c.created++; c.created >= chatRateLimitersSweepInterval {
+		c.created = 0
+
+		for k, limiter := range c.limiters {
+			if limiter.idle() {
+				delete(c.limiters, k)
+			}
+		}
+	}
+
+	var limiter *rateLimiter
+	if isGroupChatID(chatID) {
+		limiter = newRateLimiter(c.config.GroupPerSecond, c.config.GroupBurst)
+	} else {
+		limiter = newRateLimiter(c.config.PerSecond, c.config.Burst)
+	}
+	c.limiters[key] = limiter
+
+	return limiter
+}
+
+// check if given chat id is of a group, supergroup, or channel (negative ids, or usernames)
+func isGroupChatID(chatID ChatID) bool {
+	switch id := chatID.(type) {
+	case int64:
+		return id < 0
+	case int:
+		return id < 0
+	case string:
+		return strings.HasPrefix(id, "@") || strings.HasPrefix(id, "-")
+	}
+	return false
+}
+
+// new rate limiter with a full bucket
+func newRateLimiter(perSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		perSecond: perSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		last:      time.Now(),
+	}
+}
+
+// check if the bucket is full (so it has not been used recently)
+func (l *rateLimiter) idle() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.tokens+time.Since(l.last).Seconds()*l.perSecond >= l.burst
+}
+
+// wait for a token, and return how long it waited
+func (l *rateLimiter) wait() (delay time.Duration) {
+	l.lock.Lock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.perSecond)
+	l.last = now
+
+	l.tokens--
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.perSecond * float64(time.Second))
+	}
+
+	l.lock.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return delay
+}
@@ -0,0 +1,192 @@
+package telegrambot
+
+// Long polling loop with a context, which drains in-flight handlers on shutdown.
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// default values of PollingOptions
+const (
+	defaultPollingTimeoutSeconds = 30
+	defaultPollingLimit          = 100
+	defaultPollingWorkers        = 1
+	defaultPollingMinBackoff     = 1 * time.Second
+	defaultPollingMaxBackoff     = 1 * time.Minute
+)
+
+// PollingOptions is a configuration for StartPolling()
+type PollingOptions struct {
+	Offset         int64           // offset of the first update (0 for all unconfirmed updates)
+	Timeout        int             // timeout of long polling in seconds (default: 30)
+	Limit          int             // maximum number of updates in each request, 1-100 (default: 100, see also SetAdaptiveBatchSize())
+	AllowedUpdates []AllowedUpdate // types of updates to receive (nil for the previous setting)
+
+	// number of goroutines which handle updates (default: 1, updates are handled in order)
+	Workers int
+
+	// maximum delay between retries on errors of getUpdates (default: 1 minute),
+	// doubled from 1 second on each consecutive error
+	MaxBackoff time.Duration
+
+	// called last for each update (and for errors of getUpdates), like the handler of StartMonitoringUpdates()
+	//
+	// It can be nil when handlers are added with AddUpdateHandler().
+	UpdateHandler func(b *Bot, update Update, err error)
+}
+
+// fill default values of the options
+func (o PollingOptions) withDefaults() PollingOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = defaultPollingTimeoutSeconds
+	}
+	if o.Limit <= 0 || o.Limit > defaultPollingLimit {
+		o.Limit = defaultPollingLimit
+	}
+	if o.Workers <= 0 {
+		o.Workers = defaultPollingWorkers
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultPollingMaxBackoff
+	}
+	return o
+}
+
+// result of a getUpdates request
+type polledUpdates struct {
+	updates []Update
+	offset  int64 // next offset
+	err     error
+}
+
+// StartPolling retrieves updates with long polling and passes them to handlers, until `ctx` is done.
+//
+// The offset is tracked automatically, and failed requests are retried with exponential backoff.
+// When `ctx` is done, it stops fetching, waits for handlers of fetched updates to finish,
+// confirms the handled updates to the API server (so they are not delivered again), and returns.
+//
+// If webhook is registered, it may not work properly. So make sure webhook is deleted, or not registered.
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//
+//	client.AddUpdateHandler("main", handleUpdate)
+//	client.StartPolling(ctx, PollingOptions{
+//		AllowedUpdates: []AllowedUpdate{AllowMessage, AllowCallbackQuery},
+//	})
+func (b *Bot) StartPolling(ctx context.Context, options PollingOptions) {
+	options = options.withDefaults()
+
+	b.verbose("starting polling (timeout: %d, workers: %d) ...", options.Timeout, options.Workers)
+
+	// set update handler
+	if options.UpdateHandler == nil && !b.hasUpdateHandlers() {
+		b.error("given update handler is nil")
+		return
+	}
+	b.updateHandler = options.UpdateHandler
+
+	// internal server for profiling
+	b.startInternalServerFromEnv()
+
+	sizer := b.batchSizer
+	limit := options.Limit
+	if sizer != nil {
+		limit = sizer.limit
+	}
+
+	// workers
+	queue := make(chan Update)
+	var wg, handling sync.WaitGroup // NOTE: `handling` also covers updates handled in the worker pool
+	done := func() {
+		if sizer != nil {
+			sizer.done()
+		}
+		handling.Done()
+	}
+	for i := 0; i < options.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for update := range queue {
+				handling.Add(1)
+				b.handleUpdateThen(update, done)
+			}
+		}()
+	}
+
+	offset := options.Offset
+	backoff := time.Duration(0)
+
+loop:
+	for {
+		// https://core.telegram.org/bots/api#getupdates
+		fetchOptions := OptionsGetUpdates{}.
+			SetOffset(offset).
+			SetLimit(limit).
+			SetTimeout(options.Timeout)
+		if options.AllowedUpdates != nil {
+			fetchOptions.SetAllowedUpdates(options.AllowedUpdates)
+		}
+
+		// NOTE: a long polling request cannot be cancelled, so stop waiting for it when `ctx` is done
+		// (updates fetched by it are not confirmed, and will be delivered again)
+		fetched := make(chan polledUpdates, 1)
+		go func() {
+			updates, err := b.pollUpdates(fetchOptions)
+			next, _ := fetchOptions["offset"].(int64)
+			fetched <- polledUpdates{updates: updates, offset: next, err: err}
+		}()
+
+		var result polledUpdates
+		select {
+		case <-ctx.Done():
+			break loop
+		case result = <-fetched:
+		}
+
+		if result.err != nil {
+			b.handleUpdate(Update{}, result.err)
+
+			if backoff *= 2; backoff < defaultPollingMinBackoff {
+				backoff = defaultPollingMinBackoff
+			} else if backoff > options.MaxBackoff {
+				backoff = options.MaxBackoff
+			}
+
+			select {
+			case <-ctx.Done():
+				break loop
+			case <-time.After(backoff):
+			}
+			continue
+		}
+		backoff = 0
+
+		if sizer != nil {
+			sizer.fetched(len(result.updates))
+			limit = sizer.next(len(result.updates))
+		}
+		for _, update := range result.updates {
+			queue <- update // NOTE: fetched updates are handled even when `ctx` is done
+		}
+		offset = result.offset
+	}
+
+	// drain in-flight handlers
+	close(queue)
+	wg.Wait()
+	handling.Wait()
+
+	// confirm handled updates
+	if offset != options.Offset {
+		if res := b.GetUpdates(OptionsGetUpdates{}.SetOffset(offset).SetLimit(1).SetTimeout(0)); !res.Ok {
+			b.error("failed to confirm handled updates (offset: %d): %s", offset, res.Err())
+		}
+	}
+
+	b.verbose("stopped polling (offset: %d)", offset)
+}
@@ -0,0 +1,482 @@
+package telegrambot
+
+// Holding non-urgent messages during recipients' quiet hours, and delivering them in the morning.
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultQuietHoursCheckInterval = 1 * time.Minute // default interval of checking held messages
+
+	quietHoursHeldKey = "quiet-hours:held" // key of held messages in the store
+)
+
+// QuietHours is a range of quiet hours in a timezone
+//
+// `Start` and `End` are offsets from midnight (eg. 22 * time.Hour and 7 * time.Hour),
+// and the range wraps around midnight when `Start` is later than `End`.
+// Messages held during quiet hours are delivered at `End`. (the morning time)
+type QuietHours struct {
+	Location *time.Location // timezone of the recipient (default: time.UTC)
+	Start    time.Duration  // start of quiet hours
+	End      time.Duration  // end of quiet hours
+}
+
+// whether this range is empty
+func (q QuietHours) disabled() bool {
+	return q.Start == q.End
+}
+
+// offset of given time from its midnight in the location
+func (q QuietHours) offset(t time.Time) time.Duration {
+	t = t.In(q.location())
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+// location, or time.UTC if not set
+func (q QuietHours) location() *time.Location {
+	if q.Location == nil {
+		return time.UTC
+	}
+	return q.Location
+}
+
+// Contains returns whether given time is in quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.disabled() {
+		return false
+	}
+
+	offset := q.offset(t)
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End // wraps around midnight
+}
+
+// NextEnd returns the first end of quiet hours after given time.
+func (q QuietHours) NextEnd(t time.Time) time.Time {
+	loc := q.location()
+	t = t.In(loc)
+
+	end := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(q.End)
+	if !end.After(t) {
+		end = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc).Add(q.End)
+	}
+	return end
+}
+
+// QuietHoursConfig is a configuration for EnableQuietHours()
+type QuietHoursConfig struct {
+	Default       QuietHours    // quiet hours of chats without their own settings (no quiet hours if empty)
+	CheckInterval time.Duration // interval of checking held messages (default: 1 minute)
+
+	// store for held messages (default: a new MemoryCacheStore)
+	//
+	// With a persistent store, messages held before a restart are delivered after enabled again with the same store.
+	// It should not be shared by multiple processes, or held messages may be delivered more than once.
+	Store CacheStore
+
+	// called with the result of each held message when it is delivered
+	OnDelivered func(b *Bot, chatID int64, item Sendable, result APIResponse[[]Message])
+}
+
+// fill default values of the configuration
+func (c QuietHoursConfig) withDefaults() QuietHoursConfig {
+	if c.CheckInterval <= 0 {
+		c.CheckInterval = defaultQuietHoursCheckInterval
+	}
+	if c.Store == nil {
+		c.Store = NewMemoryCacheStore()
+	}
+	return c
+}
+
+// heldMessage is a message held until the end of quiet hours (as JSON in the store)
+type heldMessage struct {
+	ChatID    int64           `json:"chat_id"`
+	Kind      string          `json:"kind"` // kind of the held Sendable
+	Item      json.RawMessage `json:"item"`
+	Options   MethodOptions   `json:"options,omitempty"`
+	DeliverAt time.Time       `json:"deliver_at"`
+}
+
+// Sendable with a file in the store
+type storedSendableFile struct {
+	File      *storedInputFile `json:"file"`
+	Caption   string           `json:"caption,omitempty"`
+	ParseMode ParseMode        `json:"parse_mode,omitempty"`
+}
+
+// QuietHoursDelivery delivers messages, holding non-urgent ones during recipients' quiet hours.
+//
+// Held messages are kept in the store of QuietHoursConfig, so only the Sendable types of this library
+// (except SendableAlbum, SendableInvoice, and files with `Reader`) can be held.
+type QuietHoursDelivery struct {
+	bot    *Bot
+	config QuietHoursConfig
+
+	chats map[int64]QuietHours
+	lock  sync.Mutex
+
+	done chan struct{}
+	once sync.Once
+}
+
+// EnableQuietHours starts a background delivery of messages held during quiet hours, and returns it.
+//
+// Messages sent with Deliver() are held while the recipient is in quiet hours, and delivered
+// at the end of them, unless they are urgent.
+//
+//	delivery := client.EnableQuietHours(QuietHoursConfig{
+//		Default: QuietHours{Start: 22 * time.Hour, End: 8 * time.Hour},
+//	})
+//	defer delivery.Stop()
+//
+//	seoul, _ := time.LoadLocation("Asia/Seoul")
+//	delivery.SetChatQuietHours(chatID, QuietHours{Location: seoul, Start: 23 * time.Hour, End: 7 * time.Hour})
+//
+//	delivery.Deliver(chatID, SendableText{Text: "Your weekly report is ready."}, nil, false)
+//
+// It can also be set to BroadcastConfig.QuietHours for holding broadcast messages.
+func (b *Bot) EnableQuietHours(config QuietHoursConfig) *QuietHoursDelivery {
+	d := &QuietHoursDelivery{
+		bot:    b,
+		config: config.withDefaults(),
+		chats:  map[int64]QuietHours{},
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		ticker := time.NewTicker(d.config.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-d.done:
+				b.verbose("stopped quiet hours delivery")
+				return
+			case now := <-ticker.C:
+				d.deliverDue(now)
+			}
+		}
+	}()
+
+	b.verbose("started quiet hours delivery (interval: %s)", d.config.CheckInterval)
+
+	return d
+}
+
+// Stop stops delivering held messages. (messages still held are kept in the store)
+func (d *QuietHoursDelivery) Stop() {
+	d.once.Do(func() { close(d.done) })
+}
+
+// SetChatQuietHours sets quiet hours of a chat. (an empty QuietHours for no quiet hours)
+func (d *QuietHoursDelivery) SetChatQuietHours(chatID int64, quietHours QuietHours) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.chats[chatID] = quietHours
+}
+
+// RemoveChatQuietHours removes quiet hours of a chat, so the default ones are applied.
+func (d *QuietHoursDelivery) RemoveChatQuietHours(chatID int64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	delete(d.chats, chatID)
+}
+
+// ChatQuietHours returns quiet hours of a chat.
+func (d *QuietHoursDelivery) ChatQuietHours(chatID int64) QuietHours {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.chatQuietHours(chatID)
+}
+
+// quiet hours of a chat (should be called with the lock)
+func (d *QuietHoursDelivery) chatQuietHours(chatID int64) QuietHours {
+	if quietHours, exists := d.chats[chatID]; exists {
+		return quietHours
+	}
+	return d.config.Default
+}
+
+// Deliver sends given content to a chat with Send(), or holds it until the end of the chat's quiet hours.
+//
+// Urgent contents are sent immediately regardless of quiet hours.
+// When the content is held, `held` is true and `result` is empty.
+// It fails without sending if the content should be held but cannot be stored.
+func (d *QuietHoursDelivery) Deliver(chatID int64, item Sendable, options MethodOptions, urgent bool) (result APIResponse[[]Message], held bool) {
+	if !urgent {
+		var err error
+		if held, err = d.hold(chatID, item, options, time.Now()); err != nil {
+			errStr := fmt.Sprintf("failed to hold message for chat %d: %s", chatID, err)
+
+			d.bot.error(errStr)
+
+			return APIResponse[[]Message]{Ok: false, Description: &errStr}, false
+		} else if held {
+			return result, true
+		}
+	}
+
+	return d.bot.Send(chatID, item, options), false
+}
+
+// hold given content in the store if the chat is in quiet hours at `now`
+func (d *QuietHoursDelivery) hold(chatID int64, item Sendable, options MethodOptions, now time.Time) (held bool, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	quietHours := d.chatQuietHours(chatID)
+	if !quietHours.Contains(now) {
+		return false, nil
+	}
+
+	message, err := newHeldMessage(chatID, item, options, quietHours.NextEnd(now))
+	if err != nil {
+		return false, err
+	}
+	messages, err := d.loadHeld()
+	if err != nil {
+		return false, err
+	}
+	if err = d.saveHeld(append(messages, message)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Held returns the number of messages held for a chat.
+func (d *QuietHoursDelivery) Held(chatID int64) (count int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	messages, err := d.loadHeld()
+	if err != nil {
+		d.bot.error("failed to load held messages: %s", err)
+	}
+	for _, message := range messages {
+		if message.ChatID == chatID {
+			count++
+		}
+	}
+	return count
+}
+
+// load held messages from the store (should be called with the lock)
+func (d *QuietHoursDelivery) loadHeld() ([]heldMessage, error) {
+	value, exists := d.config.Store.Get(quietHoursHeldKey)
+	if !exists {
+		return nil, nil
+	}
+	return decodeStoreValue[[]heldMessage](value)
+}
+
+// save held messages to the store (should be called with the lock)
+func (d *QuietHoursDelivery) saveHeld(messages []heldMessage) error {
+	if len(messages) == 0 {
+		d.config.Store.Delete(quietHoursHeldKey)
+		return nil
+	}
+
+	encoded, err := encodeStoreValue(messages)
+	if err != nil {
+		return err
+	}
+	d.config.Store.Set(quietHoursHeldKey, encoded, 0)
+	return nil
+}
+
+// deliver held messages which are due at given time, in the order they were held
+func (d *QuietHoursDelivery) deliverDue(now time.Time) {
+	d.lock.Lock()
+	messages, err := d.loadHeld()
+	if err != nil {
+		d.lock.Unlock()
+		d.bot.error("failed to load held messages: %s", err)
+		return
+	}
+	due, remaining := []heldMessage{}, []heldMessage{}
+	for _, message := range messages {
+		if message.DeliverAt.After(now) {
+			remaining = append(remaining, message)
+		} else {
+			due = append(due, message)
+		}
+	}
+	if len(due) > 0 {
+		err = d.saveHeld(remaining)
+	}
+	d.lock.Unlock()
+
+	if err != nil {
+		d.bot.error("failed to save held messages: %s", err)
+		return
+	}
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].DeliverAt.Before(due[j].DeliverAt) })
+
+	for _, message := range due {
+		item, err := message.sendable()
+		if err != nil {
+			d.bot.error("dropping held message to chat %d: %s", message.ChatID, err)
+			continue
+		}
+
+		result := d.bot.Send(message.ChatID, item, message.Options)
+		if !result.Ok {
+			d.bot.error("failed to deliver held message to chat %d: %s", message.ChatID, result.Err())
+		}
+		if d.config.OnDelivered != nil {
+			d.config.OnDelivered(d.bot, message.ChatID, item, result)
+		}
+	}
+}
+
+// convert given content for the store
+func newHeldMessage(chatID int64, item Sendable, options MethodOptions, deliverAt time.Time) (message heldMessage, err error) {
+	for k, v := range options {
+		switch v.(type) {
+		case InputFile, *InputFile:
+			return message, fmt.Errorf("option '%s' with a file cannot be held", k)
+		}
+	}
+
+	var kind string
+	var value any
+	switch v := item.(type) {
+	case SendableText:
+		kind, value = "text", v
+	case SendableLocation:
+		kind, value = "location", v
+	case SendableVenue:
+		kind, value = "venue", v
+	case SendableContact:
+		kind, value = "contact", v
+	case SendablePoll:
+		kind, value = "poll", v
+	case SendableDice:
+		kind, value = "dice", v
+	case SendablePhoto:
+		kind = "photo"
+		value, err = newStoredSendableFile(v.File, v.Caption, v.ParseMode)
+	case SendableDocument:
+		kind = "document"
+		value, err = newStoredSendableFile(v.File, v.Caption, v.ParseMode)
+	case SendableVideo:
+		kind = "video"
+		value, err = newStoredSendableFile(v.File, v.Caption, v.ParseMode)
+	case SendableAudio:
+		kind = "audio"
+		value, err = newStoredSendableFile(v.File, v.Caption, v.ParseMode)
+	case SendableAnimation:
+		kind = "animation"
+		value, err = newStoredSendableFile(v.File, v.Caption, v.ParseMode)
+	case SendableVoice:
+		kind = "voice"
+		value, err = newStoredSendableFile(v.File, v.Caption, v.ParseMode)
+	case SendableVideoNote:
+		kind = "video_note"
+		value, err = newStoredSendableFile(v.File, "", "")
+	case SendableSticker:
+		kind = "sticker"
+		value, err = newStoredSendableFile(v.File, "", "")
+	default:
+		return message, fmt.Errorf("%T cannot be held", item)
+	}
+	if err != nil {
+		return message, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return message, err
+	}
+
+	copied := MethodOptions{}
+	for k, v := range options {
+		copied[k] = v
+	}
+
+	return heldMessage{
+		ChatID:    chatID,
+		Kind:      kind,
+		Item:      data,
+		Options:   copied,
+		DeliverAt: deliverAt,
+	}, nil
+}
+
+// convert a file with a caption for the store
+func newStoredSendableFile(file InputFile, caption string, parseMode ParseMode) (stored storedSendableFile, err error) {
+	if stored.File, err = newStoredInputFile(&file); err != nil {
+		return stored, err
+	}
+	stored.Caption, stored.ParseMode = caption, parseMode
+	return stored, nil
+}
+
+// convert back to Sendable
+func (m heldMessage) sendable() (Sendable, error) {
+	switch m.Kind {
+	case "text":
+		return unmarshalSendable[SendableText](m.Item)
+	case "location":
+		return unmarshalSendable[SendableLocation](m.Item)
+	case "venue":
+		return unmarshalSendable[SendableVenue](m.Item)
+	case "contact":
+		return unmarshalSendable[SendableContact](m.Item)
+	case "poll":
+		return unmarshalSendable[SendablePoll](m.Item)
+	case "dice":
+		return unmarshalSendable[SendableDice](m.Item)
+	}
+
+	var stored storedSendableFile
+	if err := json.Unmarshal(m.Item, &stored); err != nil {
+		return nil, err
+	}
+	var file InputFile
+	if f := stored.File.inputFile(); f != nil {
+		file = *f
+	}
+
+	switch m.Kind {
+	case "photo":
+		return SendablePhoto{File: file, Caption: stored.Caption, ParseMode: stored.ParseMode}, nil
+	case "document":
+		return SendableDocument{File: file, Caption: stored.Caption, ParseMode: stored.ParseMode}, nil
+	case "video":
+		return SendableVideo{File: file, Caption: stored.Caption, ParseMode: stored.ParseMode}, nil
+	case "audio":
+		return SendableAudio{File: file, Caption: stored.Caption, ParseMode: stored.ParseMode}, nil
+	case "animation":
+		return SendableAnimation{File: file, Caption: stored.Caption, ParseMode: stored.ParseMode}, nil
+	case "voice":
+		return SendableVoice{File: file, Caption: stored.Caption, ParseMode: stored.ParseMode}, nil
+	case "video_note":
+		return SendableVideoNote{File: file}, nil
+	case "sticker":
+		return SendableSticker{File: file}, nil
+	}
+	return nil, fmt.Errorf("unknown kind of held message: '%s'", m.Kind)
+}
+
+// unmarshal a Sendable of type T
+func unmarshalSendable[T Sendable](data []byte) (Sendable, error) {
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
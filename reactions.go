@@ -0,0 +1,108 @@
+package telegrambot
+
+// Helpers for setting reactions on messages.
+//
+// https://core.telegram.org/bots/api#setmessagereaction
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReactionEmojis is the list of emojis which can be used in ReactionType of type "emoji".
+//
+// https://core.telegram.org/bots/api#reactiontypeemoji
+var ReactionEmojis = []string{
+	"👍", "👎", "❤", "🔥", "🥰", "👏", "😁", "🤔", "🤯", "😱", "🤬", "😢", "🎉", "🤩", "🤮", "💩",
+	"🙏", "👌", "🕊", "🤡", "🥱", "🥴", "😍", "🐳", "❤‍🔥", "🌚", "🌭", "💯", "🤣", "⚡", "🍌", "🏆",
+	"💔", "🤨", "😐", "🍓", "🍾", "💋", "🖕", "😈", "😴", "😭", "🤓", "👻", "👨‍💻", "👀", "🎃", "🙈",
+	"😇", "😨", "🤝", "✍", "🤗", "🫡", "🎅", "🎄", "☃", "💅", "🤪", "🗿", "🆒", "💘", "🙉", "🦄",
+	"😘", "💊", "🙊", "😎", "👾", "🤷‍♂", "🤷", "🤷‍♀", "😡",
+}
+
+// set of ReactionEmojis
+var _reactionEmojis = func() map[string]bool {
+	set := map[string]bool{}
+	for _, emoji := range ReactionEmojis {
+		set[emoji] = true
+	}
+	return set
+}()
+
+// normalize given emoji for reactions (variation selectors like "❤️" are not accepted by the API)
+func normalizeReactionEmoji(emoji string) string {
+	return strings.ReplaceAll(emoji, "\uFE0F", "")
+}
+
+// IsReactionEmoji checks if given emoji can be used as a reaction.
+func IsReactionEmoji(emoji string) bool {
+	return _reactionEmojis[normalizeReactionEmoji(emoji)]
+}
+
+// NewReactionTypeEmoji returns a new ReactionType with given emoji.
+func NewReactionTypeEmoji(emoji string) ReactionType {
+	emoji = normalizeReactionEmoji(emoji)
+
+	return ReactionType{
+		Type:  ReactionTypeTypeEmoji,
+		Emoji: &emoji,
+	}
+}
+
+// NewReactionTypeCustomEmoji returns a new ReactionType with given custom emoji id.
+func NewReactionTypeCustomEmoji(customEmojiID string) ReactionType {
+	return ReactionType{
+		Type:          ReactionTypeTypeCustomEmoji,
+		CustomEmojiID: &customEmojiID,
+	}
+}
+
+// maximum number of reactions which a bot can set on a message (bots are non-premium users)
+const maxBotReactions = 1
+
+// React sets given emojis as the bot's reactions on a message, replacing the previous ones.
+//
+// Emojis are validated against ReactionEmojis before sending the request.
+// Without any emoji, the bot's reactions on the message are cleared.
+//
+// NOTE: Bots are non-premium users, so they can set only one reaction per message;
+// it fails without sending the request if more emojis are given.
+//
+//	bot.React(chatID, messageID, "👍")
+func (b *Bot) React(chatID ChatID, messageID int64, emojis ...string) (result APIResponse[bool]) {
+	if len(emojis) > maxBotReactions {
+		errStr := fmt.Sprintf("too many reactions: bots can set only %d reaction per message (%d given)", maxBotReactions, len(emojis))
+
+		b.error(errStr)
+
+		return APIResponse[bool]{Ok: false, Description: &errStr}
+	}
+
+	reactions := []ReactionType{}
+	for _, emoji := range emojis {
+		if !IsReactionEmoji(emoji) {
+			errStr := fmt.Sprintf("invalid reaction: %q is not an allowed reaction emoji", emoji)
+
+			b.error(errStr)
+
+			return APIResponse[bool]{Ok: false, Description: &errStr}
+		}
+		reactions = append(reactions, NewReactionTypeEmoji(emoji))
+	}
+
+	return b.SetMessageReaction(chatID, messageID, OptionsSetMessageReaction{}.SetReaction(reactions))
+}
+
+// ClearReactions clears the bot's reactions on a message.
+func (b *Bot) ClearReactions(chatID ChatID, messageID int64) (result APIResponse[bool]) {
+	return b.React(chatID, messageID)
+}
+
+// React sets given emoji as the bot's reaction on this message. (see Bot.React)
+//
+// Without any emoji, the bot's reactions on this message are cleared.
+//
+//	message.React(bot, "🔥")
+func (m *Message) React(b *Bot, emojis ...string) (result APIResponse[bool]) {
+	return b.React(m.Chat.ID, m.MessageID, emojis...)
+}
@@ -0,0 +1,208 @@
+package telegrambot
+
+// Remembering reply keyboards shown to users, and handling their buttons by texts.
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReplyKeyboardState is a state of the reply keyboard which a user has in a chat (stored as JSON)
+type ReplyKeyboardState struct {
+	Name      string    `json:"name"`       // name of the registered keyboard ("" if the keyboard was removed)
+	MessageID int64     `json:"message_id"` // id of the message which showed (or removed) the keyboard
+	UpdatedAt time.Time `json:"updated_at"` // when the keyboard was shown (or removed)
+}
+
+// a registered reply keyboard
+type replyKeyboard struct {
+	markup   ReplyKeyboardMarkup
+	handlers map[string]Handler // handlers by button texts
+}
+
+// ReplyKeyboards remembers which reply keyboard each user has, and handles pressed buttons with registered handlers.
+//
+// Telegram gives no feedback about which keyboard a user sees, so states are recorded when keyboards are
+// shown or removed with this, and kept in a CacheStore for re-sending or clearing them after restarts.
+type ReplyKeyboards struct {
+	bot   *Bot
+	store CacheStore
+
+	keyboards map[string]replyKeyboard
+	names     []string // names of keyboards in registered order
+	lock      sync.RWMutex
+}
+
+// EnableReplyKeyboards enables managing reply keyboards, and returns the ReplyKeyboards for registering and showing them.
+//
+// States of users' keyboards are kept in `store` (eg. a persistent CacheStore implementation).
+// If `store` is nil, a new MemoryCacheStore will be used.
+//
+// It is added with AddUpdateHandler(), and texts of messages which match buttons of the sender's current keyboard
+// are handled (and consumed) by the buttons' handlers.
+//
+//	keyboards := client.EnableReplyKeyboards(nil)
+//	markup := ReplyKeyboardMarkup{Keyboard: [][]KeyboardButton{NewKeyboardButtons("📋 Menu", "❓ Help")}, ResizeKeyboard: true}
+//	keyboards.Register("main", markup, map[string]Handler{
+//		"📋 Menu": func(ctx *Ctx) error { ... },
+//		"❓ Help": func(ctx *Ctx) error { ... },
+//	})
+//	keyboards.Show(chatID, userID, "main", "Choose an item:", nil)
+func (b *Bot) EnableReplyKeyboards(store CacheStore) *ReplyKeyboards {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+
+	k := &ReplyKeyboards{
+		bot:       b,
+		store:     store,
+		keyboards: map[string]replyKeyboard{},
+	}
+
+	b.AddUpdateHandler("reply_keyboards", func(ctx *Ctx) error {
+		return k.handle(ctx)
+	})
+
+	return k
+}
+
+// Register registers a reply keyboard with given name, and handlers of its buttons by their texts.
+//
+// Registering with an existing name replaces the keyboard.
+func (k *ReplyKeyboards) Register(name string, markup ReplyKeyboardMarkup, handlers map[string]Handler) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if _, exists := k.keyboards[name]; !exists {
+		k.names = append(k.names, name)
+	}
+
+	copied := map[string]Handler{}
+	for text, handler := range handlers {
+		copied[text] = handler
+	}
+	k.keyboards[name] = replyKeyboard{markup: markup, handlers: copied}
+}
+
+// Show sends a message with the registered keyboard of given name, and records it as the user's current keyboard.
+func (k *ReplyKeyboards) Show(chatID, userID int64, name, text string, options OptionsSendMessage) (result APIResponse[Message]) {
+	k.lock.RLock()
+	keyboard, exists := k.keyboards[name]
+	k.lock.RUnlock()
+
+	if !exists {
+		errStr := fmt.Sprintf("no such reply keyboard: '%s'", name)
+
+		k.bot.error(errStr)
+
+		return APIResponse[Message]{Ok: false, Description: &errStr}
+	}
+
+	return k.send(chatID, userID, name, text, keyboard.markup, options)
+}
+
+// Resend sends a message with the user's current keyboard again. (eg. after restarts, or when the user lost it)
+//
+// It fails if the user has no recorded keyboard.
+func (k *ReplyKeyboards) Resend(chatID, userID int64, text string, options OptionsSendMessage) (result APIResponse[Message]) {
+	state, exists := k.Current(chatID, userID)
+	if !exists || state.Name == "" {
+		errStr := fmt.Sprintf("no reply keyboard recorded for user %d in chat %d", userID, chatID)
+
+		k.bot.error(errStr)
+
+		return APIResponse[Message]{Ok: false, Description: &errStr}
+	}
+
+	return k.Show(chatID, userID, state.Name, text, options)
+}
+
+// Clear sends a message which removes the user's keyboard, and records the removal.
+func (k *ReplyKeyboards) Clear(chatID, userID int64, text string, options OptionsSendMessage) (result APIResponse[Message]) {
+	return k.send(chatID, userID, "", text, NewReplyKeyboardRemove(), options)
+}
+
+// Current returns the state of the user's keyboard, and whether it was recorded or not.
+func (k *ReplyKeyboards) Current(chatID, userID int64) (state ReplyKeyboardState, exists bool) {
+	if value, exists := k.store.Get(replyKeyboardStateKey(chatID, userID)); exists {
+		decoded, err := decodeStoreValue[ReplyKeyboardState](value)
+		if err == nil {
+			return decoded, true
+		}
+		k.bot.error("ignoring reply keyboard state of user %d in chat %d: %s", userID, chatID, err)
+	}
+	return state, false
+}
+
+// Forget deletes the recorded state of the user's keyboard, without sending anything.
+func (k *ReplyKeyboards) Forget(chatID, userID int64) {
+	k.store.Delete(replyKeyboardStateKey(chatID, userID))
+}
+
+// send a message with given reply markup, and record the keyboard's name as the user's state
+func (k *ReplyKeyboards) send(chatID, userID int64, name, text string, markup any, options OptionsSendMessage) (result APIResponse[Message]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+	options.SetReplyMarkup(markup)
+
+	if result = k.bot.SendMessage(chatID, text, options); result.Ok && result.Result != nil {
+		encoded, err := encodeStoreValue(ReplyKeyboardState{
+			Name:      name,
+			MessageID: result.Result.MessageID,
+			UpdatedAt: time.Now(),
+		})
+		if err != nil {
+			k.bot.error("failed to encode reply keyboard state of user %d in chat %d: %s", userID, chatID, err)
+			return result
+		}
+		k.store.Set(replyKeyboardStateKey(chatID, userID), encoded, 0)
+	}
+
+	return result
+}
+
+// handle a message whose text matches a button of the sender's keyboard
+func (k *ReplyKeyboards) handle(ctx *Ctx) error {
+	message := ctx.Update.Message
+	if message == nil || message.Text == nil || message.From == nil {
+		return nil
+	}
+
+	handler := k.handler(message.Chat.ID, message.From.ID, *message.Text)
+	if handler == nil {
+		return nil
+	}
+
+	ctx.Consume()
+	return handler(ctx)
+}
+
+// handler of given button text, from the user's current keyboard,
+// or from any registered keyboard if the user's keyboard is unknown
+func (k *ReplyKeyboards) handler(chatID, userID int64, text string) Handler {
+	state, recorded := k.Current(chatID, userID)
+
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	if recorded {
+		if keyboard, exists := k.keyboards[state.Name]; exists {
+			return keyboard.handlers[text]
+		}
+		return nil
+	}
+
+	for _, name := range k.names {
+		if handler, exists := k.keyboards[name].handlers[text]; exists {
+			return handler
+		}
+	}
+	return nil
+}
+
+// key of users' keyboard states in the store
+func replyKeyboardStateKey(chatID, userID int64) string {
+	return fmt.Sprintf("reply_keyboard:%d:%d", chatID, userID)
+}
@@ -0,0 +1,88 @@
+package telegrambot
+
+// Resolving "@username"s of chats to their numeric ids, with caching.
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultChatIDCacheTTL         = 24 * time.Hour  // ttl of resolved chat ids
+	defaultChatIDNegativeCacheTTL = 5 * time.Minute // ttl of not-found usernames
+)
+
+// params which can have "@username"s, substituted with resolved chat ids
+var _chatIDParams = []string{"chat_id", "from_chat_id"}
+
+// SetChatIDResolverCache sets the cache store and TTLs of ResolveChatID().
+//
+// `negativeTTL` is for usernames which were not found. (not cached if `negativeTTL` <= 0)
+//
+// If `store` is nil, a new MemoryCacheStore will be used.
+func (b *Bot) SetChatIDResolverCache(store CacheStore, ttl, negativeTTL time.Duration) {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+
+	b.chatIDCache = store
+	b.chatIDCacheTTL = ttl
+	b.chatIDNegativeCacheTTL = negativeTTL
+}
+
+// ResolveChatID returns the numeric id of given chat username (eg. "@channelusername") with getChat, and caches it.
+//
+// Once resolved, the username in `chat_id` (or `from_chat_id`) params of subsequent API calls
+// is transparently substituted with the cached numeric id.
+//
+// Usernames which were not found are also cached (for a shorter TTL), and their errors are returned without API calls.
+func (b *Bot) ResolveChatID(username string) (chatID int64, err error) {
+	if !strings.HasPrefix(username, "@") {
+		username = "@" + username
+	}
+	key := chatIDCacheKey(username)
+
+	if cached, exists := b.chatIDCache.Get(key); exists {
+		switch value := cached.(type) {
+		case int64:
+			return value, nil
+		case *APIError:
+			return 0, value
+		}
+	}
+
+	res := b.GetChat(username)
+	if err := res.Err(); err != nil {
+		if apiErr, ok := err.(*APIError); ok && IsChatNotFound(apiErr) && b.chatIDNegativeCacheTTL > 0 {
+			b.chatIDCache.Set(key, apiErr, b.chatIDNegativeCacheTTL)
+		}
+		return 0, err
+	}
+
+	chatID = res.Result.ID
+	b.chatIDCache.Set(key, chatID, b.chatIDCacheTTL)
+
+	return chatID, nil
+}
+
+// cache key for a resolved chat id (usernames are case-insensitive)
+func chatIDCacheKey(username string) string {
+	return fmt.Sprintf("chat_id:%s", strings.ToLower(username))
+}
+
+// substitute "@username"s in given params with resolved chat ids, if they are cached
+func (b *Bot) substituteResolvedChatIDs(params map[string]any) {
+	for _, param := range _chatIDParams {
+		username, ok := params[param].(string)
+		if !ok || !strings.HasPrefix(username, "@") {
+			continue
+		}
+
+		if cached, exists := b.chatIDCache.Get(chatIDCacheKey(username)); exists {
+			if chatID, ok := cached.(int64); ok {
+				params[param] = chatID
+			}
+		}
+	}
+}
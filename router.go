@@ -0,0 +1,319 @@
+package telegrambot
+
+// Routing updates to handlers which receive typed payloads.
+
+import (
+	"regexp"
+	"sync"
+)
+
+// Payload is a type of payloads in updates, which can be routed with On()
+type Payload interface {
+	Message | CallbackQuery | InlineQuery | ChosenInlineResult | ShippingQuery | PreCheckoutQuery |
+		Poll | PollAnswer | ChatMemberUpdated | ChatJoinRequest
+}
+
+// a route of Router, which returns the handler of an update if matched
+type route func(update Update) (handler Handler, matched bool)
+
+// Router routes each update to the first matching handler, registered with On(), OnFilter(), or OnXXX() methods.
+type Router struct {
+	routes []route
+	lock   sync.RWMutex
+}
+
+// NewRouter returns a new Router.
+//
+// Add it with AddUpdateHandler() for routing updates:
+//
+//	router := NewRouter()
+//	router.OnMessage(`^/start`, func(ctx *Ctx, message Message) error { ... })
+//	router.OnCallbackQuery(`^vote:`, func(ctx *Ctx, query CallbackQuery) error { ... })
+//	router.OnChatJoinRequest(func(ctx *Ctx, request ChatJoinRequest) error { ... })
+//	client.AddUpdateHandler("router", router.Handle)
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle calls the first matching handler of given update, and consumes the update if it was handled.
+func (r *Router) Handle(ctx *Ctx) error {
+	r.lock.RLock()
+	routes := r.routes
+	r.lock.RUnlock()
+
+	for _, route := range routes {
+		if handler, matched := route(ctx.Update); matched {
+			ctx.Consume()
+			return handler(ctx)
+		}
+	}
+	return nil
+}
+
+// add a route
+func (r *Router) add(route route) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.routes = append(r.routes, route)
+}
+
+// On registers a handler for updates with payloads of type T, whose texts match `pattern`. ("" for all)
+//
+// Texts of payloads are matched with `pattern` as a regular expression:
+//   - Message: text (or caption) of `message`
+//   - CallbackQuery: data
+//   - InlineQuery, ChosenInlineResult: query
+//   - ShippingQuery, PreCheckoutQuery: invoice payload
+//   - Poll: question
+//   - PollAnswer: poll id
+//   - ChatMemberUpdated (of `my_chat_member` or `chat_member`), ChatJoinRequest: "" (empty)
+//
+// It panics if `pattern` is not a valid regular expression.
+//
+//	On(router, `^/echo `, func(ctx *Ctx, message Message) error {
+//		return message.Reply(ctx.Bot, strings.TrimPrefix(*message.Text, "/echo "), nil).Err()
+//	})
+func On[T Payload](router *Router, pattern string, handler func(ctx *Ctx, payload T) error) {
+	addRoute(router, payloadOf[T], patternMatcher[T](pattern), handler)
+}
+
+// OnFilter registers a handler for updates with payloads of type T, which satisfy `predicate`.
+//
+//	OnFilter(router, func(message Message) bool {
+//		return message.Photo != nil
+//	}, handlePhoto)
+func OnFilter[T Payload](router *Router, predicate func(payload T) bool, handler func(ctx *Ctx, payload T) error) {
+	addRoute(router, payloadOf[T], predicate, handler)
+}
+
+// OnUpdate registers a handler for updates which satisfy `predicate`.
+func (r *Router) OnUpdate(predicate func(update Update) bool, handler Handler) {
+	r.add(func(update Update) (Handler, bool) {
+		return handler, predicate(update)
+	})
+}
+
+// OnMessage registers a handler for `message`s whose texts (or captions) match `pattern`. ("" for all)
+func (r *Router) OnMessage(pattern string, handler func(ctx *Ctx, message Message) error) {
+	addRoute(r, func(update Update) (Message, bool) { return deref(update.Message) }, patternMatcher[Message](pattern), handler)
+}
+
+// OnEditedMessage registers a handler for `edited_message`s whose texts (or captions) match `pattern`. ("" for all)
+func (r *Router) OnEditedMessage(pattern string, handler func(ctx *Ctx, message Message) error) {
+	addRoute(r, func(update Update) (Message, bool) { return deref(update.EditedMessage) }, patternMatcher[Message](pattern), handler)
+}
+
+// OnChannelPost registers a handler for `channel_post`s whose texts (or captions) match `pattern`. ("" for all)
+func (r *Router) OnChannelPost(pattern string, handler func(ctx *Ctx, message Message) error) {
+	addRoute(r, func(update Update) (Message, bool) { return deref(update.ChannelPost) }, patternMatcher[Message](pattern), handler)
+}
+
+// OnEditedChannelPost registers a handler for `edited_channel_post`s whose texts (or captions) match `pattern`. ("" for all)
+func (r *Router) OnEditedChannelPost(pattern string, handler func(ctx *Ctx, message Message) error) {
+	addRoute(r, func(update Update) (Message, bool) { return deref(update.EditedChannelPost) }, patternMatcher[Message](pattern), handler)
+}
+
+// OnInlineQuery registers a handler for `inline_query`s whose queries match `pattern`. ("" for all)
+func (r *Router) OnInlineQuery(pattern string, handler func(ctx *Ctx, query InlineQuery) error) {
+	addRoute(r, func(update Update) (InlineQuery, bool) { return deref(update.InlineQuery) }, patternMatcher[InlineQuery](pattern), handler)
+}
+
+// OnChosenInlineResult registers a handler for `chosen_inline_result`s whose queries match `pattern`. ("" for all)
+func (r *Router) OnChosenInlineResult(pattern string, handler func(ctx *Ctx, result ChosenInlineResult) error) {
+	addRoute(r, func(update Update) (ChosenInlineResult, bool) { return deref(update.ChosenInlineResult) }, patternMatcher[ChosenInlineResult](pattern), handler)
+}
+
+// OnCallbackQuery registers a handler for `callback_query`s whose data match `pattern`. ("" for all)
+func (r *Router) OnCallbackQuery(pattern string, handler func(ctx *Ctx, query CallbackQuery) error) {
+	addRoute(r, func(update Update) (CallbackQuery, bool) { return deref(update.CallbackQuery) }, patternMatcher[CallbackQuery](pattern), handler)
+}
+
+// OnShippingQuery registers a handler for `shipping_query`s whose invoice payloads match `pattern`. ("" for all)
+func (r *Router) OnShippingQuery(pattern string, handler func(ctx *Ctx, query ShippingQuery) error) {
+	addRoute(r, func(update Update) (ShippingQuery, bool) { return deref(update.ShippingQuery) }, patternMatcher[ShippingQuery](pattern), handler)
+}
+
+// OnPreCheckoutQuery registers a handler for `pre_checkout_query`s whose invoice payloads match `pattern`. ("" for all)
+func (r *Router) OnPreCheckoutQuery(pattern string, handler func(ctx *Ctx, query PreCheckoutQuery) error) {
+	addRoute(r, func(update Update) (PreCheckoutQuery, bool) { return deref(update.PreCheckoutQuery) }, patternMatcher[PreCheckoutQuery](pattern), handler)
+}
+
+// OnPoll registers a handler for `poll`s whose questions match `pattern`. ("" for all)
+func (r *Router) OnPoll(pattern string, handler func(ctx *Ctx, poll Poll) error) {
+	addRoute(r, func(update Update) (Poll, bool) { return deref(update.Poll) }, patternMatcher[Poll](pattern), handler)
+}
+
+// OnPollAnswer registers a handler for all `poll_answer`s.
+func (r *Router) OnPollAnswer(handler func(ctx *Ctx, answer PollAnswer) error) {
+	addRoute(r, func(update Update) (PollAnswer, bool) { return deref(update.PollAnswer) }, nil, handler)
+}
+
+// OnMyChatMember registers a handler for all `my_chat_member` updates. (changes of the bot's membership)
+func (r *Router) OnMyChatMember(handler func(ctx *Ctx, updated ChatMemberUpdated) error) {
+	addRoute(r, func(update Update) (ChatMemberUpdated, bool) { return deref(update.MyChatMember) }, nil, handler)
+}
+
+// OnChatMember registers a handler for all `chat_member` updates. (changes of other members' membership)
+func (r *Router) OnChatMember(handler func(ctx *Ctx, updated ChatMemberUpdated) error) {
+	addRoute(r, func(update Update) (ChatMemberUpdated, bool) { return deref(update.ChatMember) }, nil, handler)
+}
+
+// OnChatJoinRequest registers a handler for all `chat_join_request`s.
+func (r *Router) OnChatJoinRequest(handler func(ctx *Ctx, request ChatJoinRequest) error) {
+	addRoute(r, func(update Update) (ChatJoinRequest, bool) { return deref(update.ChatJoinRequest) }, nil, handler)
+}
+
+// OnMessageReaction registers a handler for all `message_reaction` updates. (changes of reactions by users)
+func (r *Router) OnMessageReaction(handler func(ctx *Ctx, updated MessageReactionUpdated) error) {
+	addRoute(r, func(update Update) (MessageReactionUpdated, bool) { return deref(update.MessageReaction) }, nil, handler)
+}
+
+// OnMessageReactionCount registers a handler for all `message_reaction_count` updates. (changes of anonymous reactions)
+func (r *Router) OnMessageReactionCount(handler func(ctx *Ctx, updated MessageReactionCountUpdated) error) {
+	addRoute(r, func(update Update) (MessageReactionCountUpdated, bool) { return deref(update.MessageReactionCount) }, nil, handler)
+}
+
+// OnBusinessConnection registers a handler for all `business_connection` updates. (connections with business accounts)
+func (r *Router) OnBusinessConnection(handler func(ctx *Ctx, connection BusinessConnection) error) {
+	addRoute(r, func(update Update) (BusinessConnection, bool) { return deref(update.BusinessConnection) }, nil, handler)
+}
+
+// OnBusinessMessage registers a handler for `business_message`s whose texts (or captions) match `pattern`. ("" for all)
+func (r *Router) OnBusinessMessage(pattern string, handler func(ctx *Ctx, message Message) error) {
+	addRoute(r, func(update Update) (Message, bool) { return deref(update.BusinessMessage) }, patternMatcher[Message](pattern), handler)
+}
+
+// OnEditedBusinessMessage registers a handler for `edited_business_message`s whose texts (or captions) match `pattern`. ("" for all)
+func (r *Router) OnEditedBusinessMessage(pattern string, handler func(ctx *Ctx, message Message) error) {
+	addRoute(r, func(update Update) (Message, bool) { return deref(update.EditedBusinessMessage) }, patternMatcher[Message](pattern), handler)
+}
+
+// OnDeletedBusinessMessages registers a handler for all `deleted_business_messages` updates.
+func (r *Router) OnDeletedBusinessMessages(handler func(ctx *Ctx, deleted BusinessMessagesDeleted) error) {
+	addRoute(r, func(update Update) (BusinessMessagesDeleted, bool) { return deref(update.DeletedBusinessMessages) }, nil, handler)
+}
+
+// OnChatBoost registers a handler for all `chat_boost` updates. (boosts added to chats)
+func (r *Router) OnChatBoost(handler func(ctx *Ctx, updated ChatBoostUpdated) error) {
+	addRoute(r, func(update Update) (ChatBoostUpdated, bool) { return deref(update.ChatBoost) }, nil, handler)
+}
+
+// OnRemovedChatBoost registers a handler for all `removed_chat_boost` updates. (boosts removed from chats)
+func (r *Router) OnRemovedChatBoost(handler func(ctx *Ctx, removed ChatBoostRemoved) error) {
+	addRoute(r, func(update Update) (ChatBoostRemoved, bool) { return deref(update.RemovedChatBoost) }, nil, handler)
+}
+
+// add a route which extracts a payload from updates, and calls the handler when it matches (nil `matches` for all)
+func addRoute[T any](router *Router, extract func(update Update) (T, bool), matches func(payload T) bool, handler func(ctx *Ctx, payload T) error) {
+	router.add(func(update Update) (Handler, bool) {
+		payload, exists := extract(update)
+		if !exists || (matches != nil && !matches(payload)) {
+			return nil, false
+		}
+
+		return func(ctx *Ctx) error {
+			return handler(ctx, payload)
+		}, true
+	})
+}
+
+// matcher of payloads' texts with given pattern (nil for an empty pattern)
+//
+// It panics if `pattern` is not a valid regular expression.
+func patternMatcher[T Payload](pattern string) func(payload T) bool {
+	if pattern == "" {
+		return nil
+	}
+
+	re := regexp.MustCompile(pattern)
+	return func(payload T) bool {
+		return re.MatchString(payloadText(payload))
+	}
+}
+
+// value of given pointer, and whether it is not nil
+func deref[T any](pointer *T) (value T, ok bool) {
+	if pointer == nil {
+		return value, false
+	}
+	return *pointer, true
+}
+
+// payload of type T in given update, and whether it exists or not
+func payloadOf[T Payload](update Update) (payload T, exists bool) {
+	switch p := any(&payload).(type) {
+	case *Message:
+		if update.Message != nil {
+			*p, exists = *update.Message, true
+		}
+	case *CallbackQuery:
+		if update.CallbackQuery != nil {
+			*p, exists = *update.CallbackQuery, true
+		}
+	case *InlineQuery:
+		if update.InlineQuery != nil {
+			*p, exists = *update.InlineQuery, true
+		}
+	case *ChosenInlineResult:
+		if update.ChosenInlineResult != nil {
+			*p, exists = *update.ChosenInlineResult, true
+		}
+	case *ShippingQuery:
+		if update.ShippingQuery != nil {
+			*p, exists = *update.ShippingQuery, true
+		}
+	case *PreCheckoutQuery:
+		if update.PreCheckoutQuery != nil {
+			*p, exists = *update.PreCheckoutQuery, true
+		}
+	case *Poll:
+		if update.Poll != nil {
+			*p, exists = *update.Poll, true
+		}
+	case *PollAnswer:
+		if update.PollAnswer != nil {
+			*p, exists = *update.PollAnswer, true
+		}
+	case *ChatMemberUpdated:
+		if update.MyChatMember != nil {
+			*p, exists = *update.MyChatMember, true
+		} else if update.ChatMember != nil {
+			*p, exists = *update.ChatMember, true
+		}
+	case *ChatJoinRequest:
+		if update.ChatJoinRequest != nil {
+			*p, exists = *update.ChatJoinRequest, true
+		}
+	}
+	return payload, exists
+}
+
+// text of given payload for matching patterns
+func payloadText(payload any) string {
+	switch p := payload.(type) {
+	case Message:
+		if p.Text != nil {
+			return *p.Text
+		} else if p.Caption != nil {
+			return *p.Caption
+		}
+	case CallbackQuery:
+		if p.Data != nil {
+			return *p.Data
+		}
+	case InlineQuery:
+		return p.Query
+	case ChosenInlineResult:
+		return p.Query
+	case ShippingQuery:
+		return p.InvoicePayload
+	case PreCheckoutQuery:
+		return p.InvoicePayload
+	case Poll:
+		return p.Question
+	case PollAnswer:
+		return p.PollID
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package main
+
+// Kafka publisher/consumer with kafka-go (github.com/segmentio/kafka-go)
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+
+	bot "github.com/git2akh/telegram-bot-go"
+)
+
+////////////////////////////////
+// publisher
+//
+
+// kafkaPublisher publishes updates to a Kafka topic
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// return a new publisher for given brokers and topic
+func newKafkaPublisher(brokers []string, topic string) *kafkaPublisher {
+	return &kafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{}, // updates of the same bot go to the same partition, in order
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+// Publish writes given update as a JSON message, keyed with the bot's id.
+func (p *kafkaPublisher) Publish(ctx context.Context, update bot.PublishedUpdate) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(update.BotID),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "update_id", Value: []byte(strconv.FormatInt(update.UpdateID, 10))},
+		},
+	})
+}
+
+////////////////////////////////
+// consumer
+//
+
+// kafkaConsumer receives updates from a Kafka topic, as a member of a consumer group
+type kafkaConsumer struct {
+	reader *kafka.Reader
+}
+
+// return a new consumer for given brokers, topic, and consumer group
+func newKafkaConsumer(brokers []string, topic, group string) *kafkaConsumer {
+	return &kafkaConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			Topic:   topic,
+			GroupID: group,
+		}),
+	}
+}
+
+// Receive fetches the next message, and returns an ack function which commits its offset.
+func (c *kafkaConsumer) Receive(ctx context.Context) (update bot.PublishedUpdate, ack func() error, err error) {
+	msg, err := c.reader.FetchMessage(ctx)
+	if err != nil {
+		return update, nil, err
+	}
+
+	ack = func() error {
+		return c.reader.CommitMessages(context.Background(), msg)
+	}
+
+	err = json.Unmarshal(msg.Value, &update)
+	return update, ack, err
+}
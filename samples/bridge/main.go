@@ -0,0 +1,150 @@
+// sample code for telegram-bot-go (bridge updates through a message broker),
+//
+// run with "publish" for receiving webhooks and publishing them to a broker,
+// or with "consume" for consuming updates from the broker and handling them.
+//
+// last update: 2026.10.17.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	bot "github.com/git2akh/telegram-bot-go"
+)
+
+const (
+	apiToken     = "01234567:abcdefghijklmn_ABCDEFGHIJKLMNOPQRST"
+	webhookHost  = "my.host.com"
+	webhookPort  = 8443
+	certFilepath = "./cert.pem"
+	keyFilepath  = "./cert.key"
+
+	natsURL        = "nats://127.0.0.1:4222"
+	natsSubject    = "telegram.updates"
+	natsQueueGroup = "workers"
+
+	redisAddr   = "127.0.0.1:6379"
+	redisStream = "telegram:updates"
+	redisGroup  = "workers"
+
+	kafkaBroker = "127.0.0.1:9092"
+	kafkaTopic  = "telegram-updates"
+	kafkaGroup  = "workers"
+
+	numWorkers = 4
+
+	verbose = true
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Printf("Usage: %s publish|consume [nats|redis|kafka]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	// select a broker with the second argument: "nats" (default), "redis", or "kafka"
+	broker := "nats"
+	if len(os.Args) > 2 {
+		broker = os.Args[2]
+	}
+
+	client := bot.NewClient(apiToken)
+	client.Verbose = verbose
+
+	switch os.Args[1] {
+	case "publish":
+		publish(client, broker)
+	case "consume":
+		consume(client, broker)
+	default:
+		fmt.Printf("unknown command: %s\n", os.Args[1])
+		os.Exit(1)
+	}
+}
+
+// receive webhooks and publish them to the broker
+func publish(client *bot.Bot, broker string) {
+	var publisher bot.Publisher
+	var err error
+	switch broker {
+	case "redis":
+		publisher, err = newRedisStreamsPublisher(redisAddr, redisStream)
+	case "kafka":
+		publisher = newKafkaPublisher([]string{kafkaBroker}, kafkaTopic)
+	default:
+		publisher, err = newNATSPublisher(natsURL, natsSubject)
+	}
+	if err != nil {
+		panic("failed to connect to the broker: " + err.Error())
+	}
+
+	// publish messages and callback queries only
+	client.SetUpdateFilter(func(update *bot.LazyUpdate) bool {
+		return update.Type == bot.UpdateTypeMessage || update.Type == bot.UpdateTypeCallbackQuery
+	})
+
+	// generate certificate and private key for testing
+	if err := bot.GenCertAndKey(webhookHost, certFilepath, keyFilepath, 10*365); err != nil {
+		panic("failed to generate cert/key: " + err.Error())
+	}
+
+	// set webhook
+	if hooked := client.SetWebhook(
+		webhookHost,
+		webhookPort,
+		bot.OptionsSetWebhook{}.
+			SetCertificate(certFilepath),
+	); !hooked.Ok {
+		panic("failed to set webhook")
+	}
+
+	log.Printf("publishing updates to %s...", broker)
+
+	// on success, start webhook bridge
+	client.StartWebhookBridgeAndWait(certFilepath, keyFilepath, publisher)
+}
+
+// consume updates from the broker and handle them
+func consume(client *bot.Bot, broker string) {
+	hostname, _ := os.Hostname()
+
+	var source bot.UpdateSource
+	var err error
+	switch broker {
+	case "redis":
+		source, err = newRedisStreamsConsumer(redisAddr, redisStream, redisGroup, fmt.Sprintf("%s-%d", hostname, os.Getpid()))
+	case "kafka":
+		source = newKafkaConsumer([]string{kafkaBroker}, kafkaTopic, kafkaGroup)
+	default:
+		source, err = newNATSConsumer(natsURL, natsSubject, natsQueueGroup)
+	}
+	if err != nil {
+		panic("failed to connect to the broker: " + err.Error())
+	}
+
+	log.Printf("consuming updates from %s...", broker)
+
+	client.StartConsumingUpdates(source, numWorkers, handleUpdate)
+}
+
+// update handler function
+func handleUpdate(b *bot.Bot, update bot.Update, err error) {
+	if err != nil {
+		log.Printf("*** error while consuming updates (%s)", err)
+		return
+	}
+
+	if update.HasMessage() && update.Message.HasText() {
+		if sent := b.SendMessage(
+			update.Message.Chat.ID,
+			fmt.Sprintf("I received your message: %s", *update.Message.Text),
+			bot.OptionsSendMessage{}.
+				SetReplyToMessageID(update.Message.MessageID),
+		); !sent.Ok {
+			log.Printf("*** failed to send message: %s", *sent.Description)
+		}
+	}
+}
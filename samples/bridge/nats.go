@@ -0,0 +1,83 @@
+package main
+
+// NATS publisher/consumer with the official client (github.com/nats-io/nats.go)
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nats-io/nats.go"
+
+	bot "github.com/git2akh/telegram-bot-go"
+)
+
+////////////////////////////////
+// publisher
+//
+
+// natsPublisher publishes updates to a NATS subject
+type natsPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// connect to a NATS server and return a new publisher
+func newNATSPublisher(url, subject string) (*natsPublisher, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &natsPublisher{conn: conn, subject: subject}, nil
+}
+
+// Publish publishes given update as a JSON message.
+func (p *natsPublisher) Publish(ctx context.Context, update bot.PublishedUpdate) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	if err := p.conn.Publish(p.subject, payload); err != nil {
+		return err
+	}
+	return p.conn.FlushWithContext(ctx) // make sure that the server received it
+}
+
+////////////////////////////////
+// consumer
+//
+
+// natsConsumer receives updates from a NATS subject, as a member of a queue group
+//
+// NOTE: core NATS delivers messages at most once, so there is nothing to acknowledge.
+type natsConsumer struct {
+	sub *nats.Subscription
+}
+
+// connect to a NATS server and return a new consumer
+func newNATSConsumer(url, subject, queueGroup string) (*natsConsumer, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := conn.QueueSubscribeSync(subject, queueGroup)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &natsConsumer{sub: sub}, nil
+}
+
+// Receive waits for the next message.
+func (c *natsConsumer) Receive(ctx context.Context) (update bot.PublishedUpdate, ack func() error, err error) {
+	msg, err := c.sub.NextMsgWithContext(ctx)
+	if err != nil {
+		return update, nil, err
+	}
+
+	err = json.Unmarshal(msg.Data, &update)
+	return update, nil, err
+}
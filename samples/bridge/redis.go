@@ -0,0 +1,118 @@
+package main
+
+// Redis Streams publisher/consumer with go-redis (github.com/redis/go-redis)
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	bot "github.com/git2akh/telegram-bot-go"
+)
+
+const (
+	redisBlockTimeout = 5 * time.Second // timeout of a blocking XREADGROUP
+	redisUpdateField  = "update"        // field of stream entries which holds an update
+)
+
+////////////////////////////////
+// publisher
+//
+
+// redisStreamsPublisher publishes updates to a Redis stream with XADD
+type redisStreamsPublisher struct {
+	client *redis.Client
+	stream string
+}
+
+// connect to a Redis server and return a new publisher
+func newRedisStreamsPublisher(addr, stream string) (*redisStreamsPublisher, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	return &redisStreamsPublisher{client: client, stream: stream}, nil
+}
+
+// Publish appends given update to the stream, as a JSON field of an entry.
+func (p *redisStreamsPublisher) Publish(ctx context.Context, update bot.PublishedUpdate) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		Values: map[string]any{redisUpdateField: string(payload)},
+	}).Err()
+}
+
+////////////////////////////////
+// consumer
+//
+
+// redisStreamsConsumer receives updates from a Redis stream with XREADGROUP, as a member of a consumer group
+type redisStreamsConsumer struct {
+	client *redis.Client
+
+	stream   string
+	group    string
+	consumer string
+}
+
+// connect to a Redis server and return a new consumer
+func newRedisStreamsConsumer(addr, stream, group, consumer string) (*redisStreamsConsumer, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	// create the consumer group (if it does not exist yet)
+	if err := client.XGroupCreateMkStream(context.Background(), stream, group, "$").Err(); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		return nil, err
+	}
+
+	return &redisStreamsConsumer{
+		client:   client,
+		stream:   stream,
+		group:    group,
+		consumer: consumer,
+	}, nil
+}
+
+// Receive reads the next entry of the stream, and returns an ack function which XACKs it.
+func (c *redisStreamsConsumer) Receive(ctx context.Context) (update bot.PublishedUpdate, ack func() error, err error) {
+	for ctx.Err() == nil {
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.group,
+			Consumer: c.consumer,
+			Streams:  []string{c.stream, ">"},
+			Count:    1,
+			Block:    redisBlockTimeout,
+		}).Result()
+		if err == redis.Nil { // timed out
+			continue
+		} else if err != nil {
+			return update, nil, err
+		}
+		if len(streams) == 0 || len(streams[0].Messages) == 0 {
+			continue
+		}
+
+		entry := streams[0].Messages[0]
+		ack = func() error {
+			return c.client.XAck(context.Background(), c.stream, c.group, entry.ID).Err()
+		}
+
+		payload, ok := entry.Values[redisUpdateField].(string)
+		if !ok {
+			return update, ack, fmt.Errorf("no update in stream entry: %s", entry.ID)
+		}
+		err = json.Unmarshal([]byte(payload), &update)
+		return update, ack, err
+	}
+
+	return update, nil, ctx.Err()
+}
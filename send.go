@@ -0,0 +1,250 @@
+package telegrambot
+
+// Sending any kind of content with one method, for pipelines (queues, schedulers, or broadcasts) which do not care about types.
+
+// Sendable is a content which can be sent with Send()
+//
+// Implement this interface for sending custom contents with Send().
+type Sendable interface {
+	// SendTo sends this content to given chat, with given options (a copy which can be modified).
+	SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message]
+}
+
+// Send sends given content to a chat with the matching sendXXX method, and returns sent messages.
+//
+// `options` are shared options of sendXXX methods (eg. `disable_notification`, `reply_markup`),
+// and are copied for each call, so they can be reused.
+//
+//	items := []Sendable{
+//		SendableText{Text: "Today's photos:"},
+//		SendableAlbum{Media: media},
+//		SendableLocation{Latitude: 37.5665, Longitude: 126.9780},
+//	}
+//	for _, item := range items {
+//		bot.Send(chatID, item, MethodOptions{"disable_notification": true})
+//	}
+func (b *Bot) Send(chatID ChatID, item Sendable, options MethodOptions) (result APIResponse[[]Message]) {
+	copied := MethodOptions{}
+	for k, v := range options {
+		copied[k] = v
+	}
+
+	return item.SendTo(b, chatID, copied)
+}
+
+// wrap a response of a single message as a response of messages
+func singleMessageResponse(res APIResponse[Message]) APIResponse[[]Message] {
+	result := APIResponse[[]Message]{
+		Ok:          res.Ok,
+		ErrorCode:   res.ErrorCode,
+		Description: res.Description,
+		Parameters:  res.Parameters,
+		raw:         res.raw,
+	}
+	if res.Result != nil {
+		result.Result = &[]Message{*res.Result}
+	}
+	return result
+}
+
+// set caption and parse mode options, if any
+func setCaptionOptions(options MethodOptions, caption string, parseMode ParseMode) {
+	if caption != "" {
+		options["caption"] = caption
+	}
+	if parseMode != "" {
+		options["parse_mode"] = parseMode
+	}
+}
+
+// SendableText is a text message
+type SendableText struct {
+	Text      string
+	ParseMode ParseMode // "" for plain text
+}
+
+// SendTo sends this text with SendMessage().
+func (s SendableText) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	if s.ParseMode != "" {
+		options["parse_mode"] = s.ParseMode
+	}
+	return singleMessageResponse(b.SendMessage(chatID, s.Text, OptionsSendMessage(options)))
+}
+
+// SendablePhoto is a photo with an optional caption
+type SendablePhoto struct {
+	File      InputFile
+	Caption   string
+	ParseMode ParseMode
+}
+
+// SendTo sends this photo with SendPhoto().
+func (s SendablePhoto) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	setCaptionOptions(options, s.Caption, s.ParseMode)
+	return singleMessageResponse(b.SendPhoto(chatID, s.File, OptionsSendPhoto(options)))
+}
+
+// SendableDocument is a document with an optional caption
+type SendableDocument struct {
+	File      InputFile
+	Caption   string
+	ParseMode ParseMode
+}
+
+// SendTo sends this document with SendDocument().
+func (s SendableDocument) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	setCaptionOptions(options, s.Caption, s.ParseMode)
+	return singleMessageResponse(b.SendDocument(chatID, s.File, OptionsSendDocument(options)))
+}
+
+// SendableVideo is a video with an optional caption
+type SendableVideo struct {
+	File      InputFile
+	Caption   string
+	ParseMode ParseMode
+}
+
+// SendTo sends this video with SendVideo().
+func (s SendableVideo) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	setCaptionOptions(options, s.Caption, s.ParseMode)
+	return singleMessageResponse(b.SendVideo(chatID, s.File, OptionsSendVideo(options)))
+}
+
+// SendableAudio is an audio with an optional caption
+type SendableAudio struct {
+	File      InputFile
+	Caption   string
+	ParseMode ParseMode
+}
+
+// SendTo sends this audio with SendAudio().
+func (s SendableAudio) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	setCaptionOptions(options, s.Caption, s.ParseMode)
+	return singleMessageResponse(b.SendAudio(chatID, s.File, OptionsSendAudio(options)))
+}
+
+// SendableAnimation is an animation with an optional caption
+type SendableAnimation struct {
+	File      InputFile
+	Caption   string
+	ParseMode ParseMode
+}
+
+// SendTo sends this animation with SendAnimation().
+func (s SendableAnimation) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	setCaptionOptions(options, s.Caption, s.ParseMode)
+	return singleMessageResponse(b.SendAnimation(chatID, s.File, OptionsSendAnimation(options)))
+}
+
+// SendableVoice is a voice message with an optional caption
+type SendableVoice struct {
+	File      InputFile
+	Caption   string
+	ParseMode ParseMode
+}
+
+// SendTo sends this voice message with SendVoice().
+func (s SendableVoice) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	setCaptionOptions(options, s.Caption, s.ParseMode)
+	return singleMessageResponse(b.SendVoice(chatID, s.File, OptionsSendVoice(options)))
+}
+
+// SendableVideoNote is a video note
+type SendableVideoNote struct {
+	File InputFile
+}
+
+// SendTo sends this video note with SendVideoNote().
+func (s SendableVideoNote) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	return singleMessageResponse(b.SendVideoNote(chatID, s.File, OptionsSendVideoNote(options)))
+}
+
+// SendableSticker is a sticker
+type SendableSticker struct {
+	File InputFile
+}
+
+// SendTo sends this sticker with SendSticker().
+func (s SendableSticker) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	return singleMessageResponse(b.SendSticker(chatID, s.File, OptionsSendSticker(options)))
+}
+
+// SendableAlbum is a group of media, split into multiple media groups if there are more than MediaGroupMaxItems items
+type SendableAlbum struct {
+	Media []InputMedia
+}
+
+// SendTo sends this album with SendLargeMediaGroup().
+func (s SendableAlbum) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	delete(options, "reply_markup") // NOTE: media groups cannot have reply markups
+	return b.SendLargeMediaGroup(chatID, s.Media, OptionsSendMediaGroup(options))
+}
+
+// SendableLocation is a location
+type SendableLocation struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// SendTo sends this location with SendLocation().
+func (s SendableLocation) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	return singleMessageResponse(b.SendLocation(chatID, s.Latitude, s.Longitude, OptionsSendLocation(options)))
+}
+
+// SendableVenue is a venue
+type SendableVenue struct {
+	Latitude  float64
+	Longitude float64
+	Title     string
+	Address   string
+}
+
+// SendTo sends this venue with SendVenue().
+func (s SendableVenue) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	return singleMessageResponse(b.SendVenue(chatID, s.Latitude, s.Longitude, s.Title, s.Address, OptionsSendVenue(options)))
+}
+
+// SendableContact is a phone contact
+type SendableContact struct {
+	PhoneNumber string
+	FirstName   string
+}
+
+// SendTo sends this contact with SendContact().
+func (s SendableContact) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	return singleMessageResponse(b.SendContact(chatID, s.PhoneNumber, s.FirstName, OptionsSendContact(options)))
+}
+
+// SendablePoll is a poll
+type SendablePoll struct {
+	Question string
+	Options  []string
+}
+
+// SendTo sends this poll with SendPoll().
+func (s SendablePoll) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	return singleMessageResponse(b.SendPoll(chatID, s.Question, s.Options, OptionsSendPoll(options)))
+}
+
+// SendableDice is a dice with a random value
+type SendableDice struct {
+	Emoji string // "" for the default dice (🎲), or one of DiceEmoji (eg. string(DiceEmojiDarts))
+}
+
+// SendTo sends this dice with SendDice().
+func (s SendableDice) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	if s.Emoji != "" {
+		options["emoji"] = s.Emoji
+	}
+	return singleMessageResponse(b.SendDice(chatID, OptionsSendDice(options)))
+}
+
+// SendableInvoice is an invoice built with InvoiceBuilder
+type SendableInvoice struct {
+	Invoice *InvoiceBuilder
+}
+
+// SendTo sends this invoice with SendInvoiceWithBuilder().
+func (s SendableInvoice) SendTo(b *Bot, chatID ChatID, options MethodOptions) APIResponse[[]Message] {
+	return singleMessageResponse(b.SendInvoiceWithBuilder(chatID, s.Invoice, OptionsSendInvoice(options)))
+}
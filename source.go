@@ -0,0 +1,128 @@
+package telegrambot
+
+// Consuming updates from sources other than the Bot API (eg. message brokers fed by the webhook bridge).
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// delay before retrying to receive from an update source after an error
+const updateSourceRetryDelay = 1 * time.Second
+
+// UpdateSource is an interface for sources of updates, such as consumers of message brokers
+// which receive updates published with WebhookBridgeHandler(). (eg. NATS, Redis Streams, Kafka)
+//
+// See samples/bridge/ for examples.
+type UpdateSource interface {
+	// Receive blocks until the next update is available, or `ctx` is done.
+	//
+	// Returned `ack` (can be nil) is called after the update is handled, for acknowledging it to the broker.
+	Receive(ctx context.Context) (update PublishedUpdate, ack func() error, err error)
+}
+
+// a received update with its ack function
+type sourcedUpdate struct {
+	update Update
+	ack    func() error
+}
+
+// StartConsumingUpdates receives updates from `source` constantly, and handles them with `workers` goroutines.
+//
+// Updates are handled the same way as with webhook or polling (eg. update filter and chat cache are applied),
+// and acknowledged after `updateHandler` returns.
+//
+// It stops with StopMonitoringUpdates().
+func (b *Bot) StartConsumingUpdates(source UpdateSource, workers int, updateHandler func(b *Bot, update Update, err error)) {
+	b.verbose("starting consuming updates (workers: %d) ...", workers)
+
+	// set update handler
+	if updateHandler == nil && !b.hasUpdateHandlers() {
+		b.error("given update handler is nil")
+		return
+	}
+	b.updateHandler = updateHandler
+
+	// internal server for profiling
+	b.startInternalServerFromEnv()
+
+	if workers <= 0 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// cancel on quit
+	go func() {
+		select {
+		case <-b.quitLoop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// workers
+	queue := make(chan sourcedUpdate)
+	var wg, handling sync.WaitGroup // NOTE: `handling` also covers updates handled in the worker pool
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for sourced := range queue {
+				sourced := sourced
+				handling.Add(1)
+				b.handleUpdateThen(sourced.update, func() {
+					defer handling.Done()
+
+					if sourced.ack != nil {
+						if err := sourced.ack(); err != nil {
+							b.error("failed to acknowledge update %d (%s)", sourced.update.UpdateID, err)
+						}
+					}
+				})
+			}
+		}()
+	}
+
+	// receiver
+	for ctx.Err() == nil {
+		published, ack, err := source.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+
+			go b.handleUpdate(Update{}, err)
+
+			select {
+			case <-time.After(updateSourceRetryDelay):
+			case <-ctx.Done():
+			}
+			continue
+		}
+
+		var update Update
+		if accepted, err := b.decodeUpdate(published.Raw, &update); err != nil {
+			b.error("failed to decode update: %d (%s)", published.UpdateID, err)
+		} else if accepted {
+			queue <- sourcedUpdate{update: update, ack: ack}
+			continue
+		}
+
+		// acknowledge updates which are filtered out or not decodable, so they will not be redelivered
+		if ack != nil {
+			if err := ack(); err != nil {
+				b.error("failed to acknowledge update %d (%s)", published.UpdateID, err)
+			}
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+	handling.Wait()
+
+	b.verbose("stopped consuming updates")
+}
@@ -0,0 +1,341 @@
+package telegrambot
+
+// Counting messages, commands, callback clicks, and unique users per chat and day.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// format of dates in stats
+const statsDateFormat = "2006-01-02"
+
+// default number of days kept in MemoryStatsStore
+const defaultMemoryStatsMaxDays = 90
+
+// StatsEvent is a kind of counted events
+type StatsEvent string
+
+// StatsEvent strings
+const (
+	StatsEventMessage  StatsEvent = "message"  // a message (including commands)
+	StatsEventCommand  StatsEvent = "command"  // a message which starts with a bot command
+	StatsEventCallback StatsEvent = "callback" // a callback query (click on an inline keyboard button)
+)
+
+// DailyStats is stats of a chat on a day
+type DailyStats struct {
+	Date      string  // date in "2006-01-02" format
+	Messages  int     // number of messages
+	Commands  int     // number of commands
+	Callbacks int     // number of callback queries
+	UserIDs   []int64 // ids of users who were active
+}
+
+// StatsStore is an interface for storing stats.
+//
+// Implement this interface for keeping stats persistently or sharing them between processes (eg. with a database).
+type StatsStore interface {
+	// Record counts an event of a user in a chat on a date. (in "2006-01-02" format)
+	Record(chatID int64, date string, event StatsEvent, userID int64)
+
+	// Get returns stats of a chat on a date. (in "2006-01-02" format)
+	Get(chatID int64, date string) DailyStats
+}
+
+// stats of a chat on a day in MemoryStatsStore
+type memoryDailyStats struct {
+	counts map[StatsEvent]int
+	users  map[int64]struct{}
+}
+
+// MemoryStatsStore is an in-memory StatsStore.
+//
+// Only stats of the latest `maxDays` days are kept. (default: 90, change with SetMaxDays())
+type MemoryStatsStore struct {
+	days    map[string]*memoryDailyStats
+	maxDays int
+	latest  string // latest recorded date
+
+	lock sync.Mutex
+}
+
+// NewMemoryStatsStore returns a new in-memory StatsStore.
+func NewMemoryStatsStore() *MemoryStatsStore {
+	return &MemoryStatsStore{
+		days:    map[string]*memoryDailyStats{},
+		maxDays: defaultMemoryStatsMaxDays,
+	}
+}
+
+// SetMaxDays sets the number of latest days whose stats are kept, and returns the store.
+//
+// Stats of older days are dropped when a newer day is recorded. (all of them are kept if `maxDays` <= 0)
+func (s *MemoryStatsStore) SetMaxDays(maxDays int) *MemoryStatsStore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.maxDays = maxDays
+	return s
+}
+
+// Record counts an event of a user in a chat on a date.
+func (s *MemoryStatsStore) Record(chatID int64, date string, event StatsEvent, userID int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if date > s.latest {
+		s.latest = date
+		s.dropOldDays()
+	}
+
+	key := fmt.Sprintf("%d:%s", chatID, date)
+	day, exists := s.days[key]
+	if !exists {
+		day = &memoryDailyStats{
+			counts: map[StatsEvent]int{},
+			users:  map[int64]struct{}{},
+		}
+		s.days[key] = day
+	}
+
+	day.counts[event]++
+	if userID != 0 {
+		day.users[userID] = struct{}{}
+	}
+}
+
+// drop stats of days older than `maxDays` days before the latest date
+func (s *MemoryStatsStore) dropOldDays() {
+	if s.maxDays <= 0 {
+		return
+	}
+	latest, err := time.Parse(statsDateFormat, s.latest)
+	if err != nil {
+		return
+	}
+
+	oldest := latest.AddDate(0, 0, -(s.maxDays - 1)).Format(statsDateFormat)
+	for key := range s.days {
+		if _, date, _ := strings.Cut(key, ":"); date < oldest {
+			delete(s.days, key)
+		}
+	}
+}
+
+// Get returns stats of a chat on a date.
+func (s *MemoryStatsStore) Get(chatID int64, date string) DailyStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := DailyStats{Date: date, UserIDs: []int64{}}
+	if day, exists := s.days[fmt.Sprintf("%d:%s", chatID, date)]; exists {
+		stats.Messages = day.counts[StatsEventMessage]
+		stats.Commands = day.counts[StatsEventCommand]
+		stats.Callbacks = day.counts[StatsEventCallback]
+		for userID := range day.users {
+			stats.UserIDs = append(stats.UserIDs, userID)
+		}
+		sort.Slice(stats.UserIDs, func(i, j int) bool { return stats.UserIDs[i] < stats.UserIDs[j] })
+	}
+	return stats
+}
+
+// StatsSummary is stats of a chat over days
+type StatsSummary struct {
+	Messages    int // number of messages
+	Commands    int // number of commands
+	Callbacks   int // number of callback queries
+	UniqueUsers int // number of distinct active users
+
+	Days []DailyStats // stats of each day, in order
+}
+
+// StatsConfig is a configuration for EnableStats()
+type StatsConfig struct {
+	Store    StatsStore     // store for stats (default: a new MemoryStatsStore which keeps at least `CommandDays` days)
+	Location *time.Location // location for splitting days (default: time.UTC)
+
+	// name of the command which replies with stats of the chat (eg. "stats"; empty for no command)
+	Command string
+	// days of stats replied with `Command` (default: 7)
+	CommandDays int
+	// whether `Command` is allowed for administrators of the chat only (always allowed in private chats)
+	CommandAdminsOnly bool
+}
+
+// default number of days replied with the stats command
+const defaultStatsCommandDays = 7
+
+// fill default values of the configuration
+func (c StatsConfig) withDefaults() StatsConfig {
+	if c.CommandDays <= 0 {
+		c.CommandDays = defaultStatsCommandDays
+	}
+	if c.Store == nil {
+		store := NewMemoryStatsStore()
+		if c.CommandDays > defaultMemoryStatsMaxDays {
+			store.SetMaxDays(c.CommandDays)
+		}
+		c.Store = store
+	}
+	if c.Location == nil {
+		c.Location = time.UTC
+	}
+	c.Command = strings.TrimPrefix(c.Command, "/")
+	return c
+}
+
+// Stats counts messages, commands, callback queries, and unique users per chat and day.
+type Stats struct {
+	bot    *Bot
+	config StatsConfig
+}
+
+// EnableStats enables counting stats of chats, and returns the Stats for querying them.
+//
+// It is added with AddUpdateHandler(), so it should be enabled before other handlers which consume updates.
+//
+//	stats := client.EnableStats(StatsConfig{Command: "stats", CommandAdminsOnly: true})
+//
+//	summary := stats.Range(chatID, time.Now().AddDate(0, 0, -29), time.Now())
+//	fmt.Printf("%d messages from %d users in 30 days\n", summary.Messages, summary.UniqueUsers)
+func (b *Bot) EnableStats(config StatsConfig) *Stats {
+	s := &Stats{
+		bot:    b,
+		config: config.withDefaults(),
+	}
+
+	b.AddUpdateHandler("stats", func(ctx *Ctx) error {
+		return s.handle(ctx)
+	})
+
+	return s
+}
+
+// Record counts an event of a user in a chat at given time.
+//
+// Events from updates are counted automatically, so use this for counting events from other sources.
+func (s *Stats) Record(chatID int64, at time.Time, event StatsEvent, userID int64) {
+	s.config.Store.Record(chatID, s.date(at), event, userID)
+}
+
+// Day returns stats of a chat on the day of given time.
+func (s *Stats) Day(chatID int64, day time.Time) DailyStats {
+	return s.config.Store.Get(chatID, s.date(day))
+}
+
+// Today returns stats of a chat today.
+func (s *Stats) Today(chatID int64) DailyStats {
+	return s.Day(chatID, time.Now())
+}
+
+// Range returns stats of a chat from the day of `from` to the day of `to`. (both inclusive)
+func (s *Stats) Range(chatID int64, from, to time.Time) StatsSummary {
+	summary := StatsSummary{Days: []DailyStats{}}
+
+	users := map[int64]struct{}{}
+	for _, date := range s.dates(from, to) {
+		day := s.config.Store.Get(chatID, date)
+
+		summary.Messages += day.Messages
+		summary.Commands += day.Commands
+		summary.Callbacks += day.Callbacks
+		for _, userID := range day.UserIDs {
+			users[userID] = struct{}{}
+		}
+		summary.Days = append(summary.Days, day)
+	}
+	summary.UniqueUsers = len(users)
+
+	return summary
+}
+
+// LastDays returns stats of a chat in the last `days` days, including today.
+func (s *Stats) LastDays(chatID int64, days int) StatsSummary {
+	now := time.Now()
+	return s.Range(chatID, now.AddDate(0, 0, -(days-1)), now)
+}
+
+// handle an update for stats
+func (s *Stats) handle(ctx *Ctx) error {
+	update := ctx.Update
+
+	if message := update.Message; message != nil {
+		userID := int64(0)
+		if message.From != nil {
+			userID = message.From.ID
+		}
+		at := time.Unix(int64(message.Date), 0)
+
+		s.Record(message.Chat.ID, at, StatsEventMessage, userID)
+
+		if command, ok := messageCommand(*message); ok {
+			s.Record(message.Chat.ID, at, StatsEventCommand, userID)
+
+			if s.config.Command != "" && command == s.config.Command {
+				ctx.Consume()
+				return s.reply(*message)
+			}
+		}
+	}
+
+	if query := update.CallbackQuery; query != nil && query.Message != nil {
+		s.Record(query.Message.Chat.ID, time.Now(), StatsEventCallback, query.From.ID)
+	}
+
+	return nil
+}
+
+// reply to the stats command with stats of the chat
+func (s *Stats) reply(message Message) error {
+	if s.config.CommandAdminsOnly && message.Chat.Type != ChatTypePrivate {
+		if message.From == nil {
+			return nil
+		}
+		if res := s.bot.GetChatMember(message.Chat.ID, message.From.ID); !res.Ok || !res.Result.IsAdministrator() {
+			return nil
+		}
+	}
+
+	summary := s.LastDays(message.Chat.ID, s.config.CommandDays)
+
+	lines := []string{
+		fmt.Sprintf("Stats of the last %d day(s):", s.config.CommandDays),
+		fmt.Sprintf("messages: %d", summary.Messages),
+		fmt.Sprintf("commands: %d", summary.Commands),
+		fmt.Sprintf("callbacks: %d", summary.Callbacks),
+		fmt.Sprintf("unique users: %d", summary.UniqueUsers),
+	}
+
+	if res := s.bot.SendMessage(message.Chat.ID, strings.Join(lines, "\n"), OptionsSendMessage{}.SetReplyToMessageID(message.MessageID)); !res.Ok {
+		return fmt.Errorf("failed to reply stats to chat %d: %w", message.Chat.ID, res.Err())
+	}
+	return nil
+}
+
+// date of given time in the configured location
+func (s *Stats) date(t time.Time) string {
+	return t.In(s.config.Location).Format(statsDateFormat)
+}
+
+// dates from the day of `from` to the day of `to`
+func (s *Stats) dates(from, to time.Time) (dates []string) {
+	from, to = from.In(s.config.Location), to.In(s.config.Location)
+
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, s.config.Location)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, s.config.Location)
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(statsDateFormat))
+	}
+	return dates
+}
+
+// name of the bot command which starts given message (without '/' and the bot's username)
+func messageCommand(message Message) (command string, ok bool) {
+	parsed, ok := ParseCommand(message)
+	return parsed.Name, ok
+}
@@ -0,0 +1,172 @@
+package telegrambot
+
+// Fixture builders for testing update handlers without live updates.
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	testBotID       = 1234567890
+	testBotUsername = "test_bot"
+)
+
+// counters for generating unique ids of test fixtures
+var _testUpdateID int64
+var _testMessageID int64
+var _testQueryID int64
+
+////////////////////////////////
+// Helper functions for building test Updates
+//
+
+// NewTestUser returns a fully-populated User for tests.
+func NewTestUser(userID int64) User {
+	lastName := "User"
+	username := fmt.Sprintf("test_user_%d", userID)
+	languageCode := "en"
+
+	return User{
+		ID:           userID,
+		IsBot:        false,
+		FirstName:    "Test",
+		LastName:     &lastName,
+		Username:     &username,
+		LanguageCode: &languageCode,
+	}
+}
+
+// NewTestChat returns a fully-populated Chat for tests.
+//
+// If `chatID` equals `userID`, it will be a private chat with the user. Otherwise, a group chat.
+func NewTestChat(chatID, userID int64) Chat {
+	if chatID == userID {
+		user := NewTestUser(userID)
+
+		return Chat{
+			ID:        chatID,
+			Type:      ChatTypePrivate,
+			FirstName: &user.FirstName,
+			LastName:  user.LastName,
+			Username:  user.Username,
+		}
+	}
+
+	title := fmt.Sprintf("Test Group %d", chatID)
+	return Chat{
+		ID:    chatID,
+		Type:  ChatTypeGroup,
+		Title: &title,
+	}
+}
+
+// NewTestMessage returns a fully-populated text Message for tests.
+//
+// If `text` starts with a '/', a `bot_command` entity will be added for the command.
+func NewTestMessage(chatID, userID int64, text string) Message {
+	from := NewTestUser(userID)
+
+	message := Message{
+		MessageID: atomic.AddInt64(&_testMessageID, 1),
+		From:      &from,
+		Date:      int(time.Now().Unix()),
+		Chat:      NewTestChat(chatID, userID),
+		Text:      &text,
+	}
+
+	if strings.HasPrefix(text, "/") {
+		command, _, _ := strings.Cut(text, " ")
+		message.Entities = []MessageEntity{
+			{
+				Type:   MessageEntityTypeBotCommand,
+				Offset: 0,
+				Length: utf16Len(command),
+			},
+		}
+	}
+
+	return message
+}
+
+// NewTestMessageUpdate returns an Update with a text message for tests.
+func NewTestMessageUpdate(chatID, userID int64, text string) Update {
+	message := NewTestMessage(chatID, userID, text)
+
+	return Update{
+		UpdateID: atomic.AddInt64(&_testUpdateID, 1),
+		Message:  &message,
+	}
+}
+
+// NewTestEditedMessageUpdate returns an Update with an edited text message for tests.
+func NewTestEditedMessageUpdate(chatID, userID int64, text string) Update {
+	message := NewTestMessage(chatID, userID, text)
+	message.EditDate = message.Date
+
+	return Update{
+		UpdateID:      atomic.AddInt64(&_testUpdateID, 1),
+		EditedMessage: &message,
+	}
+}
+
+// NewTestCallbackUpdate returns an Update with a callback query for tests.
+//
+// The callback query will have a message (sent by the bot, with an inline keyboard) in a private chat.
+func NewTestCallbackUpdate(data string) Update {
+	const userID = 1000
+
+	botUsername := testBotUsername
+	botUser := User{
+		ID:        testBotID,
+		IsBot:     true,
+		FirstName: "Test Bot",
+		Username:  &botUsername,
+	}
+	text := "Choose one:"
+	message := Message{
+		MessageID: atomic.AddInt64(&_testMessageID, 1),
+		From:      &botUser,
+		Date:      int(time.Now().Unix()),
+		Chat:      NewTestChat(userID, userID),
+		Text:      &text,
+		ReplyMarkup: &InlineKeyboardMarkup{
+			InlineKeyboard: [][]InlineKeyboardButton{
+				NewInlineKeyboardButtonsWithCallbackData(map[string]string{
+					data: data,
+				}),
+			},
+		},
+	}
+
+	return Update{
+		UpdateID: atomic.AddInt64(&_testUpdateID, 1),
+		CallbackQuery: &CallbackQuery{
+			ID:           fmt.Sprintf("%d", atomic.AddInt64(&_testQueryID, 1)),
+			From:         NewTestUser(userID),
+			Message:      &message,
+			ChatInstance: fmt.Sprintf("%d", userID),
+			Data:         &data,
+		},
+	}
+}
+
+// NewTestInlineQueryUpdate returns an Update with an inline query for tests.
+func NewTestInlineQueryUpdate(query string) Update {
+	const userID = 1000
+
+	chatType := string(ChatTypePrivate)
+
+	return Update{
+		UpdateID: atomic.AddInt64(&_testUpdateID, 1),
+		InlineQuery: &InlineQuery{
+			ID:       fmt.Sprintf("%d", atomic.AddInt64(&_testQueryID, 1)),
+			From:     NewTestUser(userID),
+			Query:    query,
+			Offset:   "",
+			ChatType: &chatType,
+		},
+	}
+}
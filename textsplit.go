@@ -0,0 +1,722 @@
+package telegrambot
+
+// Splitting long texts into multiple chunks, keeping their formatting valid.
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	// MessageTextMaxLength is the maximum length of a message's text (after entities parsing)
+	MessageTextMaxLength = 4096
+
+	// CaptionMaxLength is the maximum length of a media's caption (after entities parsing)
+	CaptionMaxLength = 1024
+)
+
+// TextChunk is a chunk of a split text
+type TextChunk struct {
+	Text     string
+	Entities []MessageEntity // rebased entities of this chunk (only when split with entities)
+}
+
+// break priorities of text units
+const (
+	breakNone    = 0
+	breakSpace   = 1
+	breakNewline = 2
+)
+
+// a unit of text which cannot be split
+type textUnit struct {
+	raw    string // raw string (with markups)
+	length int    // length in UTF-16 code units, after parsing
+
+	opener string // markup which this unit opens (eg. "<b>"), paired with `closer`
+	closer string // markup which closes this unit's opener (eg. "</b>")
+	closes bool   // whether this unit closes the last opened markup or not
+
+	breakAfter int // break priority after this unit
+}
+
+// an opened markup
+type openedMarkup struct {
+	opener string
+	closer string
+}
+
+// SplitText splits given text into chunks of at most `maxLength` characters (in UTF-16 code units, after parsing),
+// at newlines or spaces if possible.
+//
+// If `parseMode` is given, markups (eg. HTML tags) opened at the end of a chunk are closed there,
+// and reopened at the start of the next chunk.
+// Otherwise, given `entities` are split and rebased for each chunk.
+func SplitText(text string, parseMode ParseMode, entities []MessageEntity, maxLength int) []TextChunk {
+	chunks, _ := splitTextChunks(text, parseMode, entities, maxLength)
+	return chunks
+}
+
+// split given text into chunks, and also return their offsets in the whole text (in UTF-16 code units, after parsing)
+func splitTextChunks(text string, parseMode ParseMode, entities []MessageEntity, maxLength int) (chunks []TextChunk, offsets []int) {
+	if maxLength <= 0 {
+		maxLength = MessageTextMaxLength
+	}
+
+	var units []textUnit
+	switch parseMode {
+	case ParseModeHTML:
+		units = htmlTextUnits(text)
+	case ParseModeMarkdownV2:
+		units = markdownTextUnits(text, true)
+	case ParseModeMarkdown:
+		units = markdownTextUnits(text, false)
+	default:
+		units = plainTextUnits(text)
+	}
+
+	chunks = []TextChunk{}
+	for _, split := range splitTextUnits(units, maxLength) {
+		chunk := TextChunk{Text: split.text}
+
+		if parseMode == "" {
+			// rebase entities
+			chunk.Text = strings.TrimRightFunc(chunk.Text, unicode.IsSpace)
+			end := split.offset + utf16Len(chunk.Text)
+
+			for _, entity := range entities {
+				from, to := entity.Offset, entity.Offset+entity.Length
+				if from < split.offset {
+					from = split.offset
+				}
+				if to > end {
+					to = end
+				}
+				if from < to {
+					rebased := entity
+					rebased.Offset = from - split.offset
+					rebased.Length = to - from
+					chunk.Entities = append(chunk.Entities, rebased)
+				}
+			}
+		}
+
+		chunks = append(chunks, chunk)
+		offsets = append(offsets, split.offset)
+	}
+
+	return chunks, offsets
+}
+
+// a split chunk of text units
+type splitText struct {
+	text   string
+	offset int // offset of the chunk in the whole text (in UTF-16 code units, after parsing)
+}
+
+// split given text units into chunks of `maxLength`
+func splitTextUnits(units []textUnit, maxLength int) (splits []splitText) {
+	var opened []openedMarkup // markups opened at the start of current chunk
+	offset := 0
+
+	for start := 0; start < len(units); {
+		// skip markups which are closed right at the start of this chunk (already closed in the previous chunk)
+		for start < len(units) && units[start].closes && len(opened) > 0 {
+			opened = opened[:len(opened)-1]
+			start++
+		}
+		if start >= len(units) {
+			break
+		}
+
+		// find the best break point for this chunk
+		var bestNewline, bestSpace int // indices (exclusive) of units after break points
+		var lengthAtNewline, lengthAtSpace int
+
+		i, length := start, 0
+		for ; i < len(units); i++ {
+			unit := units[i]
+			if length+unit.length > maxLength {
+				break
+			}
+			length += unit.length
+
+			switch unit.breakAfter {
+			case breakNewline:
+				bestNewline, lengthAtNewline = i+1, length
+			case breakSpace:
+				bestSpace, lengthAtSpace = i+1, length
+			}
+		}
+
+		cut, lengthAtCut := i, length
+		if i < len(units) { // not the last chunk
+			if bestNewline > start && (lengthAtNewline >= maxLength/2 || bestSpace <= start || lengthAtSpace < maxLength/2) {
+				cut, lengthAtCut = bestNewline, lengthAtNewline
+			} else if bestSpace > start {
+				cut, lengthAtCut = bestSpace, lengthAtSpace
+			}
+		}
+		if cut == start { // should not happen (length of a unit is at most 2)
+			cut, lengthAtCut = start+1, units[start].length
+		}
+
+		// build the chunk: reopened markups + units + closing markups
+		var sb strings.Builder
+		for _, markup := range opened {
+			sb.WriteString(markup.opener)
+		}
+		stack := append([]openedMarkup{}, opened...)
+		visible := false
+		for _, unit := range units[start:cut] {
+			sb.WriteString(unit.raw)
+			stack = applyTextUnit(stack, unit)
+
+			if unit.length > 0 && strings.TrimSpace(unit.raw) != "" {
+				visible = true
+			}
+		}
+		for j := len(stack) - 1; j >= 0; j-- {
+			sb.WriteString(stack[j].closer)
+		}
+
+		// skip chunks without visible characters (Telegram does not allow empty messages)
+		if visible {
+			splits = append(splits, splitText{text: sb.String(), offset: offset})
+		}
+
+		offset += lengthAtCut
+		start = cut
+		opened = stack
+	}
+
+	return splits
+}
+
+// apply given text unit to the stack of opened markups
+func applyTextUnit(stack []openedMarkup, unit textUnit) []openedMarkup {
+	if unit.opener != "" {
+		return append(stack, openedMarkup{opener: unit.opener, closer: unit.closer})
+	}
+	if unit.closes && len(stack) > 0 {
+		return stack[:len(stack)-1]
+	}
+	return stack
+}
+
+// text unit of a rune
+func runeTextUnit(r rune) textUnit {
+	unit := textUnit{raw: string(r), length: utf16.RuneLen(r)}
+	if unit.length < 0 { // invalid rune
+		unit.length = 1
+	}
+
+	switch {
+	case r == '\n':
+		unit.breakAfter = breakNewline
+	case unicode.IsSpace(r):
+		unit.breakAfter = breakSpace
+	}
+
+	return unit
+}
+
+// split plain text into text units
+func plainTextUnits(text string) (units []textUnit) {
+	for _, r := range text {
+		units = append(units, runeTextUnit(r))
+	}
+	return units
+}
+
+// split HTML-formatted text into text units
+//
+// https://core.telegram.org/bots/api#html-style
+func htmlTextUnits(text string) (units []textUnit) {
+	for i := 0; i < len(text); {
+		switch text[i] {
+		case '<':
+			if end := strings.IndexByte(text[i:], '>'); end > 0 {
+				tag := text[i : i+end+1]
+				i += end + 1
+
+				if strings.HasPrefix(tag, "</") {
+					units = append(units, textUnit{raw: tag, closes: true})
+				} else {
+					name := strings.TrimSuffix(strings.TrimPrefix(tag, "<"), ">")
+					if idx := strings.IndexAny(name, " \t\n"); idx >= 0 {
+						name = name[:idx]
+					}
+					units = append(units, textUnit{raw: tag, opener: tag, closer: "</" + name + ">"})
+				}
+				continue
+			}
+		case '&':
+			if end := strings.IndexByte(text[i:], ';'); end > 0 && end <= 10 {
+				units = append(units, textUnit{raw: text[i : i+end+1], length: 1})
+				i += end + 1
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		units = append(units, runeTextUnit(r))
+		i += size
+	}
+
+	return units
+}
+
+// split Markdown(V2)-formatted text into text units
+//
+// https://core.telegram.org/bots/api#markdownv2-style
+// https://core.telegram.org/bots/api#markdown-style
+func markdownTextUnits(text string, v2 bool) (units []textUnit) {
+	var stack []string // opened markers
+
+	top := func() string {
+		if len(stack) > 0 {
+			return stack[len(stack)-1]
+		}
+		return ""
+	}
+	open := func(opener, marker string) {
+		stack = append(stack, marker)
+		units = append(units, textUnit{raw: opener, opener: opener, closer: marker})
+	}
+	closeTop := func() {
+		units = append(units, textUnit{raw: top(), closes: true})
+		stack = stack[:len(stack)-1]
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		inCode := top() == "`" || top() == "```"
+
+		// escaped character
+		if rest[0] == '\\' && len(rest) > 1 &&
+			((v2 && (!inCode || rest[1] == '`' || rest[1] == '\\')) || (!v2 && !inCode && strings.ContainsRune("_*`[", rune(rest[1])))) {
+			r, size := utf8.DecodeRuneInString(rest[1:])
+			unit := runeTextUnit(r)
+			unit.raw = rest[:1+size]
+			unit.breakAfter = breakNone
+			units = append(units, unit)
+			i += 1 + size
+			continue
+		}
+
+		switch {
+		case top() == "```":
+			if strings.HasPrefix(rest, "```") {
+				closeTop()
+				i += 3
+				continue
+			}
+		case top() == "`":
+			if rest[0] == '`' {
+				closeTop()
+				i++
+				continue
+			}
+		case strings.HasPrefix(rest, "```"):
+			// opener with optional language (eg. "```python\n")
+			opener := "```"
+			if newline := strings.IndexByte(rest, '\n'); newline > 0 && !strings.ContainsAny(rest[3:newline], " `") {
+				opener = rest[:newline+1]
+			}
+			open(opener, "```")
+			i += len(opener)
+			continue
+		case rest[0] == '`':
+			open("`", "`")
+			i++
+			continue
+		case v2 && (strings.HasPrefix(rest, "||") || strings.HasPrefix(rest, "__")):
+			if top() == rest[:2] {
+				closeTop()
+			} else {
+				open(rest[:2], rest[:2])
+			}
+			i += 2
+			continue
+		case rest[0] == '*' || rest[0] == '_' || (v2 && rest[0] == '~'):
+			if top() == rest[:1] {
+				closeTop()
+			} else {
+				open(rest[:1], rest[:1])
+			}
+			i++
+			continue
+		case rest[0] == '[' || (v2 && strings.HasPrefix(rest, "![")):
+			// link (or custom emoji): opened with "[", closed with "](url)"
+			bracket := strings.IndexByte(rest, '[')
+			if closer, length := markdownLinkCloser(rest[bracket+1:]); length > 0 {
+				units = append(units, textUnit{raw: rest[:bracket+1], opener: rest[:bracket+1], closer: closer})
+
+				// text units of the link text (links are not broken)
+				for _, unit := range markdownTextUnits(rest[bracket+1:bracket+1+length], v2) {
+					unit.breakAfter = breakNone
+					units = append(units, unit)
+				}
+
+				units = append(units, textUnit{raw: closer, closes: true})
+				i += bracket + 1 + length + len(closer)
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		units = append(units, runeTextUnit(r))
+		i += size
+	}
+
+	return units
+}
+
+// find the closer of a markdown link (eg. "](https://...)") in given text (after "["),
+// and return it with the length of the link text
+func markdownLinkCloser(text string) (closer string, length int) {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case ']':
+			if i+1 < len(text) && text[i+1] == '(' {
+				for j := i + 2; j < len(text); j++ {
+					if text[j] == '\\' {
+						j++
+					} else if text[j] == ')' {
+						return text[i : j+1], i
+					}
+				}
+			}
+			return "", 0
+		}
+	}
+	return "", 0
+}
+
+// SendLongMessage sends a text message, splitting it into multiple messages if it is longer than MessageTextMaxLength.
+//
+// Formatting with `parse_mode` or `entities` in options is kept valid across split messages.
+// `reply_to_message_id` and `reply_parameters` are applied to the first message only,
+// and `reply_markup` to the last message only.
+//
+// If sending one of the split messages fails, the result will contain messages sent so far.
+//
+// See SetAutoSplitLongMessages() for splitting texts of SendMessage() automatically.
+func (b *Bot) SendLongMessage(chatID ChatID, text string, options OptionsSendMessage) (result APIResponse[[]Message]) {
+	// NOTE: copy options, so that the caller's map is not modified
+	cloned := OptionsSendMessage{}
+	for k, v := range options {
+		cloned[k] = v
+	}
+	options = cloned
+	if _, exists := options[markdownV2ArgsKey]; exists {
+		options["text"] = text
+		formatMarkdownV2Params(options)
+		text, _ = options["text"].(string)
+	}
+
+	var parseMode ParseMode
+	switch mode := options["parse_mode"].(type) {
+	case ParseMode:
+		parseMode = mode
+	case string:
+		parseMode = ParseMode(mode)
+	}
+	entities, _ := options["entities"].([]MessageEntity)
+
+	chunks := SplitText(text, parseMode, entities, MessageTextMaxLength)
+
+	maxMessages, fallback := options[documentFallbackKey].(int)
+	delete(options, documentFallbackKey)
+	if fallback && len(chunks) > maxMessages {
+		res := b.sendTextAsDocument(chatID, text, options)
+		if !res.Ok {
+			return APIResponse[[]Message]{
+				Ok:          false,
+				Description: res.Description,
+				ErrorCode:   res.ErrorCode,
+				Parameters:  res.Parameters,
+			}
+		}
+		return APIResponse[[]Message]{Ok: true, Result: &[]Message{*res.Result}}
+	}
+
+	sent := []Message{}
+	for i, chunk := range chunks {
+		chunkOptions := OptionsSendMessage{}
+		for k, v := range options {
+			chunkOptions[k] = v
+		}
+		if i > 0 {
+			delete(chunkOptions, "reply_to_message_id")
+			delete(chunkOptions, "reply_parameters")
+		}
+		if key, exists := chunkOptions[idempotencyKeyKey].(string); exists && key != "" {
+			chunkOptions[idempotencyKeyKey] = fmt.Sprintf("%s#%d", key, i)
+		}
+		if i < len(chunks)-1 {
+			delete(chunkOptions, "reply_markup")
+		}
+		if parseMode == "" && len(entities) > 0 {
+			if len(chunk.Entities) > 0 {
+				chunkOptions["entities"] = chunk.Entities
+			} else {
+				delete(chunkOptions, "entities")
+			}
+		}
+
+		res := b.SendMessage(chatID, chunk.Text, chunkOptions)
+		if !res.Ok {
+			return APIResponse[[]Message]{
+				Ok:          false,
+				Description: res.Description,
+				ErrorCode:   res.ErrorCode,
+				Parameters:  res.Parameters,
+				Result:      &sent,
+			}
+		}
+		sent = append(sent, *res.Result)
+	}
+
+	return APIResponse[[]Message]{Ok: true, Result: &sent}
+}
+
+// SetAutoSplitLongMessages enables or disables splitting texts of SendMessage() which are longer than
+// MessageTextMaxLength into multiple messages, like SendLongMessage().
+//
+// When enabled, SendMessage() returns the last of the split messages (which has `reply_markup`, if any).
+// With OptionsSendMessage.SetDocumentFallback(), texts which need more messages than its maximum are sent as documents.
+func (b *Bot) SetAutoSplitLongMessages(enabled bool) {
+	b.autoSplitLongMessages = enabled
+}
+
+////////////////////////////////
+// document fallback
+//
+
+// library-local option key for the document fallback of long texts (not sent to the API)
+const documentFallbackKey = "__document_fallback"
+
+const (
+	// filename of texts sent as documents
+	documentFallbackFilename = "message.txt"
+
+	// maximum length of the preview in captions of texts sent as documents
+	documentFallbackPreviewLength = 200
+)
+
+// send a text message with options, or split it into multiple messages (when auto-split is enabled)
+// or send it as a document (when the fallback is set) if it does not fit in a message
+func (b *Bot) requestMessageWithDocumentFallback(options map[string]any) (result APIResponse[Message]) {
+	formatMarkdownV2Params(options)
+
+	maxMessages, fallback := options[documentFallbackKey].(int)
+	delete(options, documentFallbackKey)
+
+	text, _ := options["text"].(string)
+	if (!fallback && !b.autoSplitLongMessages) || utf8.RuneCountInString(text) <= MessageTextMaxLength/2 { // fits in a message for sure
+		return b.requestMessage("sendMessage", options)
+	}
+
+	var parseMode ParseMode
+	switch mode := options["parse_mode"].(type) {
+	case ParseMode:
+		parseMode = mode
+	case string:
+		parseMode = ParseMode(mode)
+	}
+	entities, _ := options["entities"].([]MessageEntity)
+
+	numChunks := len(SplitText(text, parseMode, entities, MessageTextMaxLength))
+	if numChunks <= 1 {
+		return b.requestMessage("sendMessage", options)
+	}
+
+	if b.autoSplitLongMessages && (!fallback || numChunks <= maxMessages) {
+		return lastSentMessage(b.SendLongMessage(options["chat_id"], text, options))
+	}
+
+	return b.sendTextAsDocument(options["chat_id"], text, options)
+}
+
+// result of the last message in given result of SendLongMessage()
+func lastSentMessage(res APIResponse[[]Message]) (result APIResponse[Message]) {
+	result = APIResponse[Message]{
+		Ok:          res.Ok,
+		Description: res.Description,
+		ErrorCode:   res.ErrorCode,
+		Parameters:  res.Parameters,
+	}
+	if res.Result != nil && len(*res.Result) > 0 {
+		result.Result = &(*res.Result)[len(*res.Result)-1]
+	}
+	return result
+}
+
+// option keys of text messages which are not valid for documents
+var textOnlyOptionKeys = map[string]bool{
+	"chat_id":                  true,
+	"text":                     true,
+	"parse_mode":               true,
+	"entities":                 true,
+	"link_preview_options":     true,
+	"disable_web_page_preview": true,
+	documentFallbackKey:        true,
+}
+
+// send given text as a .txt document, with a preview of it as the caption
+//
+// All options of the text message except text-only ones (eg. `parse_mode`, `entities`) are kept.
+func (b *Bot) sendTextAsDocument(chatID ChatID, text string, options map[string]any) (result APIResponse[Message]) {
+	documentOptions := OptionsSendDocument{}
+	for key, value := range options {
+		if !textOnlyOptionKeys[key] {
+			documentOptions[key] = value
+		}
+	}
+
+	// preview of the first line
+	preview, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if runes := []rune(preview); len(runes) > documentFallbackPreviewLength {
+		preview = string(runes[:documentFallbackPreviewLength]) + captionEllipsis
+	}
+	documentOptions.SetCaption(preview)
+
+	b.verbose("sending a long text (%d bytes) as a document", len(text))
+
+	return b.SendDocument(chatID, InputFileFromBytesWithFilename([]byte(text), documentFallbackFilename), documentOptions)
+}
+
+////////////////////////////////
+// caption overflow
+//
+
+// CaptionOverflow is a way of handling captions longer than CaptionMaxLength
+type CaptionOverflow string
+
+// CaptionOverflow strings
+const (
+	// truncate the caption and append an ellipsis
+	CaptionOverflowTruncate CaptionOverflow = "truncate"
+
+	// send the remainder of the caption as follow-up text messages, replying to the sent media
+	CaptionOverflowFollowUp CaptionOverflow = "follow_up"
+)
+
+// library-local option key for CaptionOverflow (not sent to the API)
+const captionOverflowKey = "__caption_overflow"
+
+// ellipsis appended to truncated captions
+const captionEllipsis = "…"
+
+// send a media message with `method` and `options`, handling its caption's overflow if set in options
+func (b *Bot) requestMessageWithCaption(method string, options map[string]any) (result APIResponse[Message]) {
+	formatMarkdownV2Params(options)
+
+	overflow, _ := options[captionOverflowKey].(CaptionOverflow)
+	delete(options, captionOverflowKey)
+
+	caption, _ := options["caption"].(string)
+	if overflow == "" || caption == "" {
+		return b.requestMessage(method, options)
+	}
+
+	var parseMode ParseMode
+	switch mode := options["parse_mode"].(type) {
+	case ParseMode:
+		parseMode = mode
+	case string:
+		parseMode = ParseMode(mode)
+	}
+	entities, _ := options["caption_entities"].([]MessageEntity)
+
+	chunks, offsets := splitTextChunks(caption, parseMode, entities, CaptionMaxLength)
+	if len(chunks) <= 1 {
+		return b.requestMessage(method, options)
+	}
+
+	switch overflow {
+	case CaptionOverflowTruncate:
+		truncated := SplitText(caption, parseMode, entities, CaptionMaxLength-utf16Len(captionEllipsis))[0]
+
+		options["caption"] = truncated.Text + captionEllipsis
+		if parseMode == "" && len(entities) > 0 {
+			options["caption_entities"] = truncated.Entities
+		}
+
+		return b.requestMessage(method, options)
+	case CaptionOverflowFollowUp:
+		// remainder of the caption
+		var remainder string
+		var remainderEntities []MessageEntity
+		if parseMode == "" {
+			encoded := utf16.Encode([]rune(caption))
+			remainder = string(utf16.Decode(encoded[offsets[1]:]))
+
+			for _, entity := range entities {
+				from, to := entity.Offset-offsets[1], entity.Offset+entity.Length-offsets[1]
+				if from < 0 {
+					from = 0
+				}
+				if from < to {
+					rebased := entity
+					rebased.Offset = from
+					rebased.Length = to - from
+					remainderEntities = append(remainderEntities, rebased)
+				}
+			}
+		} else {
+			var sb strings.Builder
+			for _, chunk := range chunks[1:] {
+				sb.WriteString(chunk.Text)
+			}
+			remainder = sb.String()
+		}
+
+		options["caption"] = chunks[0].Text
+		if parseMode == "" && len(entities) > 0 {
+			options["caption_entities"] = chunks[0].Entities
+		}
+
+		result = b.requestMessage(method, options)
+		if !result.Ok {
+			return result
+		}
+
+		// send the remainder as a reply to the sent media
+		followUpOptions := OptionsSendMessage{}.
+			SetReplyToMessageID(result.Result.MessageID)
+		for _, key := range []string{"message_thread_id", "disable_notification", "protect_content"} {
+			if value, exists := options[key]; exists {
+				followUpOptions[key] = value
+			}
+		}
+		if parseMode != "" {
+			followUpOptions["parse_mode"] = parseMode
+		} else if len(remainderEntities) > 0 {
+			followUpOptions["entities"] = remainderEntities
+		}
+
+		chatID := options["chat_id"]
+		if res := b.SendLongMessage(chatID, remainder, followUpOptions); !res.Ok {
+			// return the sent media message along with the error
+			return APIResponse[Message]{
+				Ok:          false,
+				Description: res.Description,
+				ErrorCode:   res.ErrorCode,
+				Parameters:  res.Parameters,
+				Result:      result.Result,
+			}
+		}
+
+		return result
+	}
+
+	return b.requestMessage(method, options)
+}
@@ -2,6 +2,10 @@ package telegrambot
 
 // https://core.telegram.org/bots/api#available-types
 
+import (
+	"io"
+)
+
 // ChatID can be `Message.Chat.Id`,
 // or target channel name (in string, eg. "@channelusername")
 type ChatID any
@@ -11,9 +15,10 @@ type ChatType string
 
 // ChatType strings
 const (
-	ChatTypePrivate ChatType = "private"
-	ChatTypeGroup   ChatType = "group"
-	ChatTypeChannel ChatType = "channel"
+	ChatTypePrivate    ChatType = "private"
+	ChatTypeGroup      ChatType = "group"
+	ChatTypeSupergroup ChatType = "supergroup"
+	ChatTypeChannel    ChatType = "channel"
 )
 
 // ParseMode is a mode of parse
@@ -49,6 +54,53 @@ const (
 	ChatActionUploadVideoNote ChatAction = "upload_video_note"
 )
 
+// DiceEmoji is an emoji of dice
+type DiceEmoji string
+
+// DiceEmoji strings
+const (
+	DiceEmojiDice        DiceEmoji = "🎲" // 1~6
+	DiceEmojiDarts       DiceEmoji = "🎯" // 1~6
+	DiceEmojiBowling     DiceEmoji = "🎳" // 1~6
+	DiceEmojiBasketball  DiceEmoji = "🏀" // 1~5
+	DiceEmojiFootball    DiceEmoji = "⚽" // 1~5
+	DiceEmojiSlotMachine DiceEmoji = "🎰" // 1~64
+)
+
+// ReactionTypeType is a type of ReactionType
+//
+// https://core.telegram.org/bots/api#reactiontype
+type ReactionTypeType string
+
+// ReactionTypeType strings
+const (
+	ReactionTypeTypeEmoji       ReactionTypeType = "emoji"
+	ReactionTypeTypeCustomEmoji ReactionTypeType = "custom_emoji"
+)
+
+// ChatBoostSourceType is a source type of ChatBoostSource
+//
+// https://core.telegram.org/bots/api#chatboostsource
+type ChatBoostSourceType string
+
+// ChatBoostSourceType strings
+const (
+	ChatBoostSourceTypePremium  ChatBoostSourceType = "premium"
+	ChatBoostSourceTypeGiftCode ChatBoostSourceType = "gift_code"
+	ChatBoostSourceTypeGiveaway ChatBoostSourceType = "giveaway"
+)
+
+// SlotMachineSymbol is a symbol on a reel of slot machine
+type SlotMachineSymbol string
+
+// SlotMachineSymbol strings
+const (
+	SlotMachineSymbolBar    SlotMachineSymbol = "bar"
+	SlotMachineSymbolGrapes SlotMachineSymbol = "grapes"
+	SlotMachineSymbolLemon  SlotMachineSymbol = "lemon"
+	SlotMachineSymbolSeven  SlotMachineSymbol = "seven"
+)
+
 // InlineQueryResultType is a type of inline query result
 type InlineQueryResultType string
 
@@ -136,18 +188,24 @@ const (
 // APIResponse is a base of API responses
 type APIResponse[T any] struct {
 	Ok          bool                   `json:"ok"`
+	ErrorCode   int                    `json:"error_code,omitempty"`
 	Description *string                `json:"description,omitempty"`
 	Parameters  *APIResponseParameters `json:"parameters,omitempty"`
 	Result      *T                     `json:"result,omitempty"`
+
+	raw []byte // body of the response (for RawResult())
 }
 
 // APIResponseMessageOrBool type for ambiguous type of `result`
 type APIResponseMessageOrBool struct {
 	Ok            bool                   `json:"ok"`
+	ErrorCode     int                    `json:"error_code,omitempty"`
 	Description   *string                `json:"description,omitempty"`
 	Parameters    *APIResponseParameters `json:"parameters,omitempty"`
 	ResultMessage *Message               `json:"result_message,omitempty"`
 	ResultBool    *bool                  `json:"result_bool,omitempty"`
+
+	raw []byte // body of the response (for RawResult())
 }
 
 // APIResponseParameters is parameters in API responses
@@ -166,16 +224,28 @@ type UpdateType string
 
 // UpdateType strings
 const (
-	UpdateTypeMessage            UpdateType = "message"
-	UpdateTypeEditedMessage      UpdateType = "edited_message"
-	UpdateTypeChannelPost        UpdateType = "channel_post"
-	UpdateTypeEditedChannelPost  UpdateType = "edited_channel_post"
-	UpdateTypeInlineQuery        UpdateType = "inline_query"
-	UpdateTypeChosenInlineResult UpdateType = "chosen_inline_result"
-	UpdateTypeCallbackQuery      UpdateType = "callback_query"
-	UpdateTypeShippingQuery      UpdateType = "shipping_query"
-	UpdateTypePreCheckoutQuery   UpdateType = "pre_checkout_query"
-	UpdateTypePoll               UpdateType = "poll"
+	UpdateTypeMessage                 UpdateType = "message"
+	UpdateTypeEditedMessage           UpdateType = "edited_message"
+	UpdateTypeChannelPost             UpdateType = "channel_post"
+	UpdateTypeEditedChannelPost       UpdateType = "edited_channel_post"
+	UpdateTypeInlineQuery             UpdateType = "inline_query"
+	UpdateTypeChosenInlineResult      UpdateType = "chosen_inline_result"
+	UpdateTypeCallbackQuery           UpdateType = "callback_query"
+	UpdateTypeShippingQuery           UpdateType = "shipping_query"
+	UpdateTypePreCheckoutQuery        UpdateType = "pre_checkout_query"
+	UpdateTypePoll                    UpdateType = "poll"
+	UpdateTypePollAnswer              UpdateType = "poll_answer"
+	UpdateTypeMyChatMember            UpdateType = "my_chat_member"
+	UpdateTypeChatMember              UpdateType = "chat_member"
+	UpdateTypeChatJoinRequest         UpdateType = "chat_join_request"
+	UpdateTypeMessageReaction         UpdateType = "message_reaction"
+	UpdateTypeMessageReactionCount    UpdateType = "message_reaction_count"
+	UpdateTypeBusinessConnection      UpdateType = "business_connection"
+	UpdateTypeBusinessMessage         UpdateType = "business_message"
+	UpdateTypeEditedBusinessMessage   UpdateType = "edited_business_message"
+	UpdateTypeDeletedBusinessMessages UpdateType = "deleted_business_messages"
+	UpdateTypeChatBoost               UpdateType = "chat_boost"
+	UpdateTypeRemovedChatBoost        UpdateType = "removed_chat_boost"
 )
 
 // WebhookInfo is a struct of webhook info
@@ -197,21 +267,29 @@ type WebhookInfo struct {
 //
 // https://core.telegram.org/bots/api#update
 type Update struct {
-	UpdateID           int64               `json:"update_id"`
-	Message            *Message            `json:"message,omitempty"`
-	EditedMessage      *Message            `json:"edited_message,omitempty"`
-	ChannelPost        *Message            `json:"channel_post,omitempty"`
-	EditedChannelPost  *Message            `json:"edited_channel_post,omitempty"`
-	InlineQuery        *InlineQuery        `json:"inline_query,omitempty"`
-	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result,omitempty"`
-	CallbackQuery      *CallbackQuery      `json:"callback_query,omitempty"`
-	ShippingQuery      *ShippingQuery      `json:"shipping_query,omitempty"`
-	PreCheckoutQuery   *PreCheckoutQuery   `json:"pre_checkout_query,omitempty"`
-	Poll               *Poll               `json:"poll,omitempty"`
-	PollAnswer         *PollAnswer         `json:"poll_answer,omitempty"`
-	MyChatMember       *ChatMemberUpdated  `json:"my_chat_member,omitempty"`
-	ChatMember         *ChatMemberUpdated  `json:"chat_member,omitempty"`
-	ChatJoinRequest    *ChatJoinRequest    `json:"chat_join_request,omitempty"`
+	UpdateID                int64                        `json:"update_id"`
+	Message                 *Message                     `json:"message,omitempty"`
+	EditedMessage           *Message                     `json:"edited_message,omitempty"`
+	ChannelPost             *Message                     `json:"channel_post,omitempty"`
+	EditedChannelPost       *Message                     `json:"edited_channel_post,omitempty"`
+	InlineQuery             *InlineQuery                 `json:"inline_query,omitempty"`
+	ChosenInlineResult      *ChosenInlineResult          `json:"chosen_inline_result,omitempty"`
+	CallbackQuery           *CallbackQuery               `json:"callback_query,omitempty"`
+	ShippingQuery           *ShippingQuery               `json:"shipping_query,omitempty"`
+	PreCheckoutQuery        *PreCheckoutQuery            `json:"pre_checkout_query,omitempty"`
+	Poll                    *Poll                        `json:"poll,omitempty"`
+	PollAnswer              *PollAnswer                  `json:"poll_answer,omitempty"`
+	MyChatMember            *ChatMemberUpdated           `json:"my_chat_member,omitempty"`
+	ChatMember              *ChatMemberUpdated           `json:"chat_member,omitempty"`
+	ChatJoinRequest         *ChatJoinRequest             `json:"chat_join_request,omitempty"`
+	MessageReaction         *MessageReactionUpdated      `json:"message_reaction,omitempty"`
+	MessageReactionCount    *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
+	BusinessConnection      *BusinessConnection          `json:"business_connection,omitempty"`
+	BusinessMessage         *Message                     `json:"business_message,omitempty"`
+	EditedBusinessMessage   *Message                     `json:"edited_business_message,omitempty"`
+	DeletedBusinessMessages *BusinessMessagesDeleted     `json:"deleted_business_messages,omitempty"`
+	ChatBoost               *ChatBoostUpdated            `json:"chat_boost,omitempty"`
+	RemovedChatBoost        *ChatBoostRemoved            `json:"removed_chat_boost,omitempty"`
 }
 
 // AllowedUpdate is a type for 'allowed_updates'
@@ -219,15 +297,23 @@ type AllowedUpdate string
 
 // AllowedUpdate type constants constants constants constants
 const (
-	AllowMessage            AllowedUpdate = "message"
-	AllowEditedMessage      AllowedUpdate = "edited_message"
-	AllowChannelPost        AllowedUpdate = "channel_post"
-	AllowEditedChannelPost  AllowedUpdate = "edited_channel_post"
-	AllowInlineQuery        AllowedUpdate = "inline_query"
-	AllowChosenInlineResult AllowedUpdate = "chosen_inline_result"
-	AllowCallbackQuery      AllowedUpdate = "callback_query"
-	AllowShippingQuery      AllowedUpdate = "shipping_query"
-	AllowPreCheckoutQuery   AllowedUpdate = "pre_checkout_query"
+	AllowMessage                 AllowedUpdate = "message"
+	AllowEditedMessage           AllowedUpdate = "edited_message"
+	AllowChannelPost             AllowedUpdate = "channel_post"
+	AllowEditedChannelPost       AllowedUpdate = "edited_channel_post"
+	AllowInlineQuery             AllowedUpdate = "inline_query"
+	AllowChosenInlineResult      AllowedUpdate = "chosen_inline_result"
+	AllowCallbackQuery           AllowedUpdate = "callback_query"
+	AllowShippingQuery           AllowedUpdate = "shipping_query"
+	AllowPreCheckoutQuery        AllowedUpdate = "pre_checkout_query"
+	AllowMessageReaction         AllowedUpdate = "message_reaction"       // NOTE: not delivered unless specified explicitly
+	AllowMessageReactionCount    AllowedUpdate = "message_reaction_count" // NOTE: not delivered unless specified explicitly
+	AllowBusinessConnection      AllowedUpdate = "business_connection"
+	AllowBusinessMessage         AllowedUpdate = "business_message"
+	AllowEditedBusinessMessage   AllowedUpdate = "edited_business_message"
+	AllowDeletedBusinessMessages AllowedUpdate = "deleted_business_messages"
+	AllowChatBoost               AllowedUpdate = "chat_boost"
+	AllowRemovedChatBoost        AllowedUpdate = "removed_chat_boost"
 )
 
 // User is a struct of a user
@@ -245,6 +331,7 @@ type User struct {
 	CanJoinGroups           bool    `json:"can_join_groups,omitempty"`             // returned only in GetMe()
 	CanReadAllGroupMessages bool    `json:"can_read_all_group_messages,omitempty"` // returned only in GetMe()
 	SupportsInlineQueries   bool    `json:"supports_inline_queries,omitempty"`     // returned only in GetMe()
+	CanConnectToBusiness    bool    `json:"can_connect_to_business,omitempty"`     // returned only in GetMe()
 }
 
 // Chat is a struct of a chat
@@ -311,6 +398,8 @@ type InputMedia struct {
 	Title                       *string         `json:"title,omitempty"`                          // audio only
 	SupportsStreaming           bool            `json:"supports_streaming,omitempty"`             // video only
 	DisableContentTypeDetection bool            `json:"disable_content_type_detection,omitempty"` // document only
+
+	MediaFile *InputFile `json:"-"` // file for `media`, which is attached automatically when it should be uploaded (see NewInputMedia())
 }
 
 // InputFile represents contents of a file to be uploaded.
@@ -322,6 +411,13 @@ type InputFile struct {
 	URL      *string
 	Bytes    []byte
 	FileID   *string
+
+	Reader io.Reader // content read while uploading (closed after the request if it is an io.Closer, and cannot be retried)
+	Size   int64     // size of `Reader`'s content (0 if unknown)
+
+	Filename *string // filename of uploaded `Bytes` or `Reader` (generated from the content type if nil)
+
+	Source *InputFile // original file of `FileID`, uploaded again when `FileID` is invalid (see InputFileFromFileIDWithSource())
 }
 
 // StickerFormat is a format of sticker
@@ -359,6 +455,40 @@ type Audio struct {
 	Thumbnail    *PhotoSize `json:"thumbnail,omitempty"`
 }
 
+// ReplyParameters is a struct of parameters of a reply to a message (which can be in another chat)
+//
+// https://core.telegram.org/bots/api#replyparameters
+type ReplyParameters struct {
+	MessageID                int64           `json:"message_id"`
+	ChatID                   ChatID          `json:"chat_id,omitempty"` // for replying to a message in another chat
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
+	Quote                    *string         `json:"quote,omitempty"` // exact part of the message to be quoted
+	QuoteParseMode           *ParseMode      `json:"quote_parse_mode,omitempty"`
+	QuoteEntities            []MessageEntity `json:"quote_entities,omitempty"`
+	QuotePosition            *int            `json:"quote_position,omitempty"` // position of the quote in UTF-16 code units
+}
+
+// LinkPreviewOptions is a struct of options for generating the link preview of a message
+//
+// https://core.telegram.org/bots/api#linkpreviewoptions
+type LinkPreviewOptions struct {
+	IsDisabled       bool    `json:"is_disabled,omitempty"`
+	URL              *string `json:"url,omitempty"` // url for the preview (default: the first one in the text)
+	PreferSmallMedia bool    `json:"prefer_small_media,omitempty"`
+	PreferLargeMedia bool    `json:"prefer_large_media,omitempty"`
+	ShowAboveText    bool    `json:"show_above_text,omitempty"`
+}
+
+// TextQuote is a struct of a quoted part of a replied message
+//
+// https://core.telegram.org/bots/api#textquote
+type TextQuote struct {
+	Text     string          `json:"text"`
+	Entities []MessageEntity `json:"entities,omitempty"`
+	Position int             `json:"position"`
+	IsManual bool            `json:"is_manual,omitempty"`
+}
+
 // MessageEntity is a struct of a message entity
 //
 // https://core.telegram.org/bots/api#messageentity
@@ -502,9 +632,9 @@ type Contact struct {
 //
 // https://core.telegram.org/bots/api#location
 type Location struct {
-	Longitude            float32 `json:"longitude"`
-	Latitude             float32 `json:"latitude"`
-	HorizontalAccuracy   float32 `json:"horizontal_accuracy,omitempty"`
+	Longitude            float64 `json:"longitude"`
+	Latitude             float64 `json:"latitude"`
+	HorizontalAccuracy   float64 `json:"horizontal_accuracy,omitempty"`
 	LivePeriod           int     `json:"live_period,omitempty"`
 	Heading              int     `json:"heading,omitempty"`
 	ProximityAlertRadius int     `json:"proximity_alert_radius,omitempty"`
@@ -584,6 +714,52 @@ type Dice struct {
 	Value int    `json:"value"` // 1-6 for dice, dart, and bowling; 1-5 for basketball and football; 1-64 for slotmachine;
 }
 
+// ReactionType is a struct of a reaction type
+//
+// (merged from ReactionTypeEmoji and ReactionTypeCustomEmoji)
+//
+// https://core.telegram.org/bots/api#reactiontype
+type ReactionType struct {
+	Type ReactionTypeType `json:"type"`
+
+	// when Type == ReactionTypeTypeEmoji
+	Emoji *string `json:"emoji,omitempty"`
+
+	// when Type == ReactionTypeTypeCustomEmoji
+	CustomEmojiID *string `json:"custom_emoji_id,omitempty"`
+}
+
+// ReactionCount is a struct of a reaction added to a message, with the number of times it was added
+//
+// https://core.telegram.org/bots/api#reactioncount
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`
+	TotalCount int          `json:"total_count"`
+}
+
+// MessageReactionUpdated is a struct of a change of a reaction on a message by a user
+//
+// https://core.telegram.org/bots/api#messagereactionupdated
+type MessageReactionUpdated struct {
+	Chat        Chat           `json:"chat"`
+	MessageID   int64          `json:"message_id"`
+	User        *User          `json:"user,omitempty"`
+	ActorChat   *Chat          `json:"actor_chat,omitempty"` // when the reaction was changed on behalf of a chat (anonymously)
+	Date        int            `json:"date"`
+	OldReaction []ReactionType `json:"old_reaction"`
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+// MessageReactionCountUpdated is a struct of changes of anonymous reactions on a message
+//
+// https://core.telegram.org/bots/api#messagereactioncountupdated
+type MessageReactionCountUpdated struct {
+	Chat      Chat            `json:"chat"`
+	MessageID int64           `json:"message_id"`
+	Date      int             `json:"date"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
 // MessageAutoDeleteTimerChanged is service message: message auto delete timer changed
 //
 // https://core.telegram.org/bots/api#messageautodeletetimerchanged
@@ -636,12 +812,34 @@ type UserShared struct {
 	UserID    int64 `json:"user_id"`
 }
 
+// UsersShared is a struct for users who were shared with the bot.
+//
+// https://core.telegram.org/bots/api#usersshared
+type UsersShared struct {
+	RequestID int64        `json:"request_id"`
+	Users     []SharedUser `json:"users"`
+}
+
+// SharedUser is a struct of a user shared with the bot. (fields are set only when requested)
+//
+// https://core.telegram.org/bots/api#shareduser
+type SharedUser struct {
+	UserID    int64       `json:"user_id"`
+	FirstName *string     `json:"first_name,omitempty"`
+	LastName  *string     `json:"last_name,omitempty"`
+	Username  *string     `json:"username,omitempty"`
+	Photo     []PhotoSize `json:"photo,omitempty"`
+}
+
 // ChatShared is a struct for a chat which shared the message.
 //
 // https://core.telegram.org/bots/api#chatshared
 type ChatShared struct {
-	RequestID int64 `json:"request_id"`
-	ChatID    int64 `json:"chat_id"`
+	RequestID int64       `json:"request_id"`
+	ChatID    int64       `json:"chat_id"`
+	Title     *string     `json:"title,omitempty"`    // when requested
+	Username  *string     `json:"username,omitempty"` // when requested
+	Photo     []PhotoSize `json:"photo,omitempty"`    // when requested
 }
 
 // WriteAccessAllowed is a struct for an allowed write access in the chat.
@@ -651,6 +849,51 @@ type WriteAccessAllowed struct {
 	WebAppName *string `json:"web_app_name,omitempty"`
 }
 
+// GiveawayCreated is a struct for service message: a scheduled giveaway was created
+//
+// https://core.telegram.org/bots/api#giveawaycreated
+type GiveawayCreated struct{}
+
+// Giveaway is a struct of a scheduled giveaway
+//
+// https://core.telegram.org/bots/api#giveaway
+type Giveaway struct {
+	Chats                         []Chat   `json:"chats"`
+	WinnersSelectionDate          int      `json:"winners_selection_date"`
+	WinnerCount                   int      `json:"winner_count"`
+	OnlyNewMembers                bool     `json:"only_new_members,omitempty"`
+	HasPublicWinners              bool     `json:"has_public_winners,omitempty"`
+	PrizeDescription              *string  `json:"prize_description,omitempty"`
+	CountryCodes                  []string `json:"country_codes,omitempty"`
+	PremiumSubscriptionMonthCount int      `json:"premium_subscription_month_count,omitempty"`
+}
+
+// GiveawayWinners is a struct of a giveaway with public winners, which was completed
+//
+// https://core.telegram.org/bots/api#giveawaywinners
+type GiveawayWinners struct {
+	Chat                          Chat    `json:"chat"`
+	GiveawayMessageID             int64   `json:"giveaway_message_id"`
+	WinnersSelectionDate          int     `json:"winners_selection_date"`
+	WinnerCount                   int     `json:"winner_count"`
+	Winners                       []User  `json:"winners"`
+	AdditionalChatCount           int     `json:"additional_chat_count,omitempty"`
+	PremiumSubscriptionMonthCount int     `json:"premium_subscription_month_count,omitempty"`
+	UnclaimedPrizeCount           int     `json:"unclaimed_prize_count,omitempty"`
+	OnlyNewMembers                bool    `json:"only_new_members,omitempty"`
+	WasRefunded                   bool    `json:"was_refunded,omitempty"`
+	PrizeDescription              *string `json:"prize_description,omitempty"`
+}
+
+// GiveawayCompleted is a struct for service message: a giveaway without public winners was completed
+//
+// https://core.telegram.org/bots/api#giveawaycompleted
+type GiveawayCompleted struct {
+	WinnerCount         int      `json:"winner_count"`
+	UnclaimedPrizeCount int      `json:"unclaimed_prize_count,omitempty"`
+	GiveawayMessage     *Message `json:"giveaway_message,omitempty"`
+}
+
 // VideoChatStarted is a struct for service message: video chat started
 //
 // https://core.telegram.org/bots/api#videochatstarted
@@ -711,13 +954,14 @@ type ReplyKeyboardMarkup struct {
 //
 // https://core.telegram.org/bots/api#keyboardbutton
 type KeyboardButton struct {
-	Text            string                     `json:"text"`
-	RequestUser     *KeyboardButtonRequestUser `json:"request_user,omitempty"`
-	RequestChat     *KeyboardButtonRequestChat `json:"request_chat,omitempty"`
-	RequestContact  bool                       `json:"request_contact,omitempty"`
-	RequestLocation bool                       `json:"request_location,omitempty"`
-	RequestPoll     *KeyboardButtonPollType    `json:"request_poll,omitempty"`
-	WebApp          *WebAppInfo                `json:"web_app,omitempty"`
+	Text            string                      `json:"text"`
+	RequestUsers    *KeyboardButtonRequestUsers `json:"request_users,omitempty"`
+	RequestUser     *KeyboardButtonRequestUser  `json:"request_user,omitempty"` // Deprecated: use RequestUsers instead
+	RequestChat     *KeyboardButtonRequestChat  `json:"request_chat,omitempty"`
+	RequestContact  bool                        `json:"request_contact,omitempty"`
+	RequestLocation bool                        `json:"request_location,omitempty"`
+	RequestPoll     *KeyboardButtonPollType     `json:"request_poll,omitempty"`
+	WebApp          *WebAppInfo                 `json:"web_app,omitempty"`
 }
 
 // KeyboardButtonRequestUser is a struct for `request_user` in KeyboardButton
@@ -729,6 +973,19 @@ type KeyboardButtonRequestUser struct {
 	UserIsPremium *bool `json:"user_is_premium,omitempty"`
 }
 
+// KeyboardButtonRequestUsers is a struct for `request_users` in KeyboardButton
+//
+// https://core.telegram.org/bots/api#keyboardbuttonrequestusers
+type KeyboardButtonRequestUsers struct {
+	RequestID       int64 `json:"request_id"`
+	UserIsBot       *bool `json:"user_is_bot,omitempty"`
+	UserIsPremium   *bool `json:"user_is_premium,omitempty"`
+	MaxQuantity     int   `json:"max_quantity,omitempty"` // 1-10 (default: 1)
+	RequestName     bool  `json:"request_name,omitempty"`
+	RequestUsername bool  `json:"request_username,omitempty"`
+	RequestPhoto    bool  `json:"request_photo,omitempty"`
+}
+
 // KeyboardButtonRequestChat is a struct for `request_chat` in KeyboardButton
 //
 // https://core.telegram.org/bots/api#keyboardbuttonrequestchat
@@ -741,6 +998,9 @@ type KeyboardButtonRequestChat struct {
 	UserAdministratorRights *ChatAdministratorRights `json:"user_administrator_rights,omitempty"`
 	BotAdministratorRights  *ChatAdministratorRights `json:"bot_administrator_rights,omitempty"`
 	BotIsMember             *bool                    `json:"bot_is_member,omitempty"`
+	RequestTitle            bool                     `json:"request_title,omitempty"`
+	RequestUsername         bool                     `json:"request_username,omitempty"`
+	RequestPhoto            bool                     `json:"request_photo,omitempty"`
 }
 
 // KeyboardButtonPollType is a struct for KeyboardButtonPollType
@@ -913,6 +1173,12 @@ type ChatMember struct {
 	IsMember              bool             `json:"is_member,omitempty"`                 // restricted only
 	CanSendMessages       bool             `json:"can_send_messages,omitempty"`         // restricted only
 	CanSendMediaMessages  bool             `json:"can_send_media_messages,omitempty"`   // restricted only
+	CanSendAudios         bool             `json:"can_send_audios,omitempty"`           // restricted only
+	CanSendDocuments      bool             `json:"can_send_documents,omitempty"`        // restricted only
+	CanSendPhotos         bool             `json:"can_send_photos,omitempty"`           // restricted only
+	CanSendVideos         bool             `json:"can_send_videos,omitempty"`           // restricted only
+	CanSendVideoNotes     bool             `json:"can_send_video_notes,omitempty"`      // restricted only
+	CanSendVoiceNotes     bool             `json:"can_send_voice_notes,omitempty"`      // restricted only
 	CanSendPolls          bool             `json:"can_send_polls,omitempty"`            // restricted only
 	CanSendOtherMessages  bool             `json:"can_send_other_messages,omitempty"`   // restricted only
 	CanAddWebPagePreviews bool             `json:"can_add_web_page_previews,omitempty"` // restricted only
@@ -1053,6 +1319,83 @@ type ChatJoinRequest struct {
 	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
 }
 
+// BusinessConnection is a struct of a connection of the bot with a business account
+//
+// https://core.telegram.org/bots/api#businessconnection
+type BusinessConnection struct {
+	ID         string `json:"id"`
+	User       User   `json:"user"`
+	UserChatID int64  `json:"user_chat_id"`
+	Date       int    `json:"date"`
+	CanReply   bool   `json:"can_reply"`
+	IsEnabled  bool   `json:"is_enabled"`
+}
+
+// BusinessMessagesDeleted is a struct of messages deleted from a connected business account
+//
+// https://core.telegram.org/bots/api#businessmessagesdeleted
+type BusinessMessagesDeleted struct {
+	BusinessConnectionID string  `json:"business_connection_id"`
+	Chat                 Chat    `json:"chat"`
+	MessageIDs           []int64 `json:"message_ids"`
+}
+
+// ChatBoostSource is a struct of a source of a chat boost
+//
+// (merged from ChatBoostSourcePremium, ChatBoostSourceGiftCode, and ChatBoostSourceGiveaway)
+//
+// https://core.telegram.org/bots/api#chatboostsource
+type ChatBoostSource struct {
+	Source ChatBoostSourceType `json:"source"`
+	User   *User               `json:"user,omitempty"` // can be nil for unclaimed giveaways
+
+	// when Source == ChatBoostSourceTypeGiveaway
+	GiveawayMessageID int64 `json:"giveaway_message_id,omitempty"`
+	IsUnclaimed       bool  `json:"is_unclaimed,omitempty"`
+}
+
+// ChatBoost is a struct of a chat boost
+//
+// https://core.telegram.org/bots/api#chatboost
+type ChatBoost struct {
+	BoostID        string          `json:"boost_id"`
+	AddDate        int             `json:"add_date"`
+	ExpirationDate int             `json:"expiration_date"`
+	Source         ChatBoostSource `json:"source"`
+}
+
+// ChatBoostUpdated is a struct of a boost added to (or changed in) a chat
+//
+// https://core.telegram.org/bots/api#chatboostupdated
+type ChatBoostUpdated struct {
+	Chat  Chat      `json:"chat"`
+	Boost ChatBoost `json:"boost"`
+}
+
+// ChatBoostRemoved is a struct of a boost removed from a chat
+//
+// https://core.telegram.org/bots/api#chatboostremoved
+type ChatBoostRemoved struct {
+	Chat       Chat            `json:"chat"`
+	BoostID    string          `json:"boost_id"`
+	RemoveDate int             `json:"remove_date"`
+	Source     ChatBoostSource `json:"source"`
+}
+
+// ChatBoostAdded is service message: a user boosted the chat
+//
+// https://core.telegram.org/bots/api#chatboostadded
+type ChatBoostAdded struct {
+	BoostCount int `json:"boost_count"`
+}
+
+// UserChatBoosts is a struct of boosts added to a chat by a user
+//
+// https://core.telegram.org/bots/api#userchatboosts
+type UserChatBoosts struct {
+	Boosts []ChatBoost `json:"boosts"`
+}
+
 // BotCommand is a struct of a bot command
 //
 // https://core.telegram.org/bots/api#botcommand
@@ -1164,13 +1507,17 @@ type Message struct {
 	IsTopicMessage                bool                           `json:"is_topic_message,omitempty"`
 	IsAutomaticForward            bool                           `json:"is_automatic_forward,omitempty"`
 	ReplyToMessage                *Message                       `json:"reply_to_message,omitempty"`
+	Quote                         *TextQuote                     `json:"quote,omitempty"`
 	ViaBot                        *User                          `json:"via_bot,omitempty"`
+	SenderBusinessBot             *User                          `json:"sender_business_bot,omitempty"`    // bot which sent the message on behalf of a business account
+	BusinessConnectionID          *string                        `json:"business_connection_id,omitempty"` // when the message was received or sent for a business account
 	EditDate                      int                            `json:"edit_date,omitempty"`
 	HasProtectedContent           bool                           `json:"has_protected_content,omitempty"`
 	MediaGroupID                  *string                        `json:"media_group_id,omitempty"`
 	AuthorSignature               *string                        `json:"author_signature,omitempty"`
 	Text                          *string                        `json:"text,omitempty"`
 	Entities                      []MessageEntity                `json:"entities,omitempty"`
+	LinkPreviewOptions            *LinkPreviewOptions            `json:"link_preview_options,omitempty"`
 	Animation                     *Animation                     `json:"animation,omitempty"`
 	Audio                         *Audio                         `json:"audio,omitempty"`
 	Document                      *Document                      `json:"document,omitempty"`
@@ -1202,10 +1549,16 @@ type Message struct {
 	PinnedMessage                 *Message                       `json:"pinned_message,omitempty"`
 	Invoice                       *Invoice                       `json:"invoice,omitempty"`
 	SuccessfulPayment             *SuccessfulPayment             `json:"successful_payment,omitempty"`
+	UsersShared                   *UsersShared                   `json:"users_shared,omitempty"`
 	UserShared                    *UserShared                    `json:"user_shared,omitempty"`
 	ChatShared                    *ChatShared                    `json:"chat_shared,omitempty"`
 	ConnectedWebsite              *string                        `json:"connected_website,omitempty"`
 	WriteAccessAllowed            *WriteAccessAllowed            `json:"write_access_allowed,omitempty"`
+	BoostAdded                    *ChatBoostAdded                `json:"boost_added,omitempty"`
+	GiveawayCreated               *GiveawayCreated               `json:"giveaway_created,omitempty"`
+	Giveaway                      *Giveaway                      `json:"giveaway,omitempty"`
+	GiveawayWinners               *GiveawayWinners               `json:"giveaway_winners,omitempty"`
+	GiveawayCompleted             *GiveawayCompleted             `json:"giveaway_completed,omitempty"`
 	//PassportData          *PassportData         `json:"passport_data,omitempty"` // NOT IMPLEMENTED: https://core.telegram.org/bots/api#passportdata
 	ProximityAlertTriggered      *ProximityAlertTriggered      `json:"proximity_alert_triggered,omitempty"`
 	ForumTopicCreated            *ForumTopicCreated            `json:"forum_topic_created,omitempty"`
@@ -1416,10 +1769,10 @@ type InlineQueryResultDocument struct { // https://core.telegram.org/bots/api#in
 // InlineQueryResultLocation is a struct of InlineQueryResultLocation
 type InlineQueryResultLocation struct { // https://core.telegram.org/bots/api#inlinequeryresultlocation
 	InlineQueryResult
-	Latitude             float32               `json:"latitude"`
-	Longitude            float32               `json:"longitude"`
+	Latitude             float64               `json:"latitude"`
+	Longitude            float64               `json:"longitude"`
 	Title                string                `json:"title"`
-	HorizontalAccuracy   float32               `json:"horizontal_accuracy,omitempty"`
+	HorizontalAccuracy   float64               `json:"horizontal_accuracy,omitempty"`
 	LivePeriod           int                   `json:"live_period,omitempty"`
 	Heading              int                   `json:"heading,omitempty"`
 	ProximityAlertRadius int                   `json:"proximity_alert_radius,omitempty"`
@@ -1433,8 +1786,8 @@ type InlineQueryResultLocation struct { // https://core.telegram.org/bots/api#in
 // InlineQueryResultVenue is a struct of InlineQueryResultVenue
 type InlineQueryResultVenue struct { // https://core.telegram.org/bots/api#inlinequeryresultvenue
 	InlineQueryResult
-	Latitude            float32               `json:"latitude"`
-	Longitude           float32               `json:"longitude"`
+	Latitude            float64               `json:"latitude"`
+	Longitude           float64               `json:"longitude"`
 	Title               string                `json:"title"`
 	Address             string                `json:"address"`
 	FoursquareID        *string               `json:"foursquare_id,omitempty"`
@@ -1570,17 +1923,18 @@ type InputMessageContent any
 
 // InputTextMessageContent is a struct of InputTextMessageContent
 type InputTextMessageContent struct { // https://core.telegram.org/bots/api#inputtextmessagecontent
-	MessageText           string          `json:"message_text"`
-	ParseMode             *ParseMode      `json:"parse_mode,omitempty"`
-	CaptionEntities       []MessageEntity `json:"caption_entities,omitempty"`
-	DisableWebPagePreview bool            `json:"disable_web_page_preview,omitempty"`
+	MessageText           string              `json:"message_text"`
+	ParseMode             *ParseMode          `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity     `json:"caption_entities,omitempty"`
+	DisableWebPagePreview bool                `json:"disable_web_page_preview,omitempty"` // Deprecated: use LinkPreviewOptions instead
+	LinkPreviewOptions    *LinkPreviewOptions `json:"link_preview_options,omitempty"`
 }
 
 // InputLocationMessageContent is a struct of InputLocationMessageContent
 type InputLocationMessageContent struct { // https://core.telegram.org/bots/api#inputlocationmessagecontent
-	Latitude             float32 `json:"latitude"`
-	Longitude            float32 `json:"longitude"`
-	HorizontalAccuracy   float32 `json:"horizontal_accuracy,omitempty"`
+	Latitude             float64 `json:"latitude"`
+	Longitude            float64 `json:"longitude"`
+	HorizontalAccuracy   float64 `json:"horizontal_accuracy,omitempty"`
 	LivePeriod           int     `json:"live_period,omitempty"`
 	Heading              int     `json:"heading,omitempty"`
 	ProximityAlertRadius int     `json:"proximity_alert_radius,omitempty"`
@@ -1588,8 +1942,8 @@ type InputLocationMessageContent struct { // https://core.telegram.org/bots/api#
 
 // InputVenueMessageContent is a struct of InputVenueMessageContent
 type InputVenueMessageContent struct { // https://core.telegram.org/bots/api#inputvenuemessagecontent
-	Latitude        float32 `json:"latitude"`
-	Longitude       float32 `json:"longitude"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
 	Title           string  `json:"title"`
 	Address         string  `json:"address"`
 	FoursquareID    *string `json:"foursquare_id,omitempty"`
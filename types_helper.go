@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
+	"unicode/utf16"
 )
 
 ////////////////////////////////
@@ -187,7 +189,7 @@ func NewInlineQueryResultDocument(documentURL, title string, mimeType DocumentMi
 // NewInlineQueryResultLocation is a helper function for generating a new InlineQueryResultLocation
 //
 // https://core.telegram.org/bots/api#inlinequeryresultlocation
-func NewInlineQueryResultLocation(latitude, longitude float32, title string) (newLocation *InlineQueryResultLocation, generatedID *string) {
+func NewInlineQueryResultLocation(latitude, longitude float64, title string) (newLocation *InlineQueryResultLocation, generatedID *string) {
 	if id, err := newUUID(); err == nil {
 		return &InlineQueryResultLocation{
 			InlineQueryResult: InlineQueryResult{
@@ -206,7 +208,7 @@ func NewInlineQueryResultLocation(latitude, longitude float32, title string) (ne
 // NewInlineQueryResultVenue is a helper function for generating a new InlineQueryResultVenue
 //
 // https://core.telegram.org/bots/api#inlinequeryresultvenue
-func NewInlineQueryResultVenue(latitude, longitude float32, title, address string) (newVenue *InlineQueryResultVenue, generatedID *string) {
+func NewInlineQueryResultVenue(latitude, longitude float64, title, address string) (newVenue *InlineQueryResultVenue, generatedID *string) {
 	if id, err := newUUID(); err == nil {
 		return &InlineQueryResultVenue{
 			InlineQueryResult: InlineQueryResult{
@@ -380,6 +382,69 @@ func NewInlineQueryResultCachedAudio(audioFileID string) (newAudio *InlineQueryR
 	return &InlineQueryResultCachedAudio{}, nil
 }
 
+// NewInlineQueryResultsButtonWithStartParameter is a helper function for generating a new InlineQueryResultsButton
+// which opens a private chat with the bot, and sends "/start `startParameter`".
+//
+// `startParameter` should be 1-64 characters of A-Z, a-z, 0-9, _, and -.
+//
+// https://core.telegram.org/bots/api#inlinequeryresultsbutton
+func NewInlineQueryResultsButtonWithStartParameter(text, startParameter string) InlineQueryResultsButton {
+	return InlineQueryResultsButton{
+		Text:           text,
+		StartParameter: &startParameter,
+	}
+}
+
+// NewInlineQueryResultsButtonWithWebApp is a helper function for generating a new InlineQueryResultsButton
+// which launches the Web App (Mini App) at given url.
+//
+// https://core.telegram.org/bots/api#inlinequeryresultsbutton
+func NewInlineQueryResultsButtonWithWebApp(text, webAppURL string) InlineQueryResultsButton {
+	return InlineQueryResultsButton{
+		Text:   text,
+		WebApp: &WebAppInfo{URL: webAppURL},
+	}
+}
+
+////////////////////////////////
+// Helper functions for APIResponse
+//
+
+// RawResult returns the raw JSON of `result` in the response, for reading fields which are not in the library's types yet.
+//
+// (nil if there was no response from the API)
+//
+//	res := bot.GetMe()
+//	var me struct {
+//		HasMainWebApp bool `json:"has_main_web_app"`
+//	}
+//	_ = json.Unmarshal(res.RawResult(), &me)
+func (r APIResponse[T]) RawResult() json.RawMessage {
+	return rawResultOf(r.raw)
+}
+
+// RawResult returns the raw JSON of `result` in the response, for reading fields which are not in the library's types yet.
+//
+// (nil if there was no response from the API)
+func (r APIResponseMessageOrBool) RawResult() json.RawMessage {
+	return rawResultOf(r.raw)
+}
+
+// extract `result` from given response body
+func rawResultOf(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+	return response.Result
+}
+
 ////////////////////////////////
 // Helper functions for Update
 //
@@ -429,6 +494,281 @@ func (u *Update) HasPoll() bool {
 	return u.Poll != nil
 }
 
+// HasChannelPost checks if Update has ChannelPost.
+func (u *Update) HasChannelPost() bool {
+	return u.ChannelPost != nil
+}
+
+// HasEditedChannelPost checks if Update has EditedChannelPost.
+func (u *Update) HasEditedChannelPost() bool {
+	return u.EditedChannelPost != nil
+}
+
+// HasPollAnswer checks if Update has PollAnswer.
+func (u *Update) HasPollAnswer() bool {
+	return u.PollAnswer != nil
+}
+
+// HasMyChatMember checks if Update has MyChatMember.
+func (u *Update) HasMyChatMember() bool {
+	return u.MyChatMember != nil
+}
+
+// HasChatMember checks if Update has ChatMember.
+func (u *Update) HasChatMember() bool {
+	return u.ChatMember != nil
+}
+
+// HasChatJoinRequest checks if Update has ChatJoinRequest.
+func (u *Update) HasChatJoinRequest() bool {
+	return u.ChatJoinRequest != nil
+}
+
+// HasMessageReaction checks if Update has MessageReaction.
+func (u *Update) HasMessageReaction() bool {
+	return u.MessageReaction != nil
+}
+
+// HasMessageReactionCount checks if Update has MessageReactionCount.
+func (u *Update) HasMessageReactionCount() bool {
+	return u.MessageReactionCount != nil
+}
+
+// HasBusinessConnection checks if Update has BusinessConnection.
+func (u *Update) HasBusinessConnection() bool {
+	return u.BusinessConnection != nil
+}
+
+// HasBusinessMessage checks if Update has BusinessMessage.
+func (u *Update) HasBusinessMessage() bool {
+	return u.BusinessMessage != nil
+}
+
+// HasEditedBusinessMessage checks if Update has EditedBusinessMessage.
+func (u *Update) HasEditedBusinessMessage() bool {
+	return u.EditedBusinessMessage != nil
+}
+
+// HasDeletedBusinessMessages checks if Update has DeletedBusinessMessages.
+func (u *Update) HasDeletedBusinessMessages() bool {
+	return u.DeletedBusinessMessages != nil
+}
+
+// HasChatBoost checks if Update has ChatBoost.
+func (u *Update) HasChatBoost() bool {
+	return u.ChatBoost != nil
+}
+
+// HasRemovedChatBoost checks if Update has RemovedChatBoost.
+func (u *Update) HasRemovedChatBoost() bool {
+	return u.RemovedChatBoost != nil
+}
+
+// Type returns the type of Update. (empty if unknown)
+func (u *Update) Type() UpdateType {
+	switch {
+	case u.Message != nil:
+		return UpdateTypeMessage
+	case u.EditedMessage != nil:
+		return UpdateTypeEditedMessage
+	case u.ChannelPost != nil:
+		return UpdateTypeChannelPost
+	case u.EditedChannelPost != nil:
+		return UpdateTypeEditedChannelPost
+	case u.InlineQuery != nil:
+		return UpdateTypeInlineQuery
+	case u.ChosenInlineResult != nil:
+		return UpdateTypeChosenInlineResult
+	case u.CallbackQuery != nil:
+		return UpdateTypeCallbackQuery
+	case u.ShippingQuery != nil:
+		return UpdateTypeShippingQuery
+	case u.PreCheckoutQuery != nil:
+		return UpdateTypePreCheckoutQuery
+	case u.Poll != nil:
+		return UpdateTypePoll
+	case u.PollAnswer != nil:
+		return UpdateTypePollAnswer
+	case u.MyChatMember != nil:
+		return UpdateTypeMyChatMember
+	case u.ChatMember != nil:
+		return UpdateTypeChatMember
+	case u.ChatJoinRequest != nil:
+		return UpdateTypeChatJoinRequest
+	case u.MessageReaction != nil:
+		return UpdateTypeMessageReaction
+	case u.MessageReactionCount != nil:
+		return UpdateTypeMessageReactionCount
+	case u.BusinessConnection != nil:
+		return UpdateTypeBusinessConnection
+	case u.BusinessMessage != nil:
+		return UpdateTypeBusinessMessage
+	case u.EditedBusinessMessage != nil:
+		return UpdateTypeEditedBusinessMessage
+	case u.DeletedBusinessMessages != nil:
+		return UpdateTypeDeletedBusinessMessages
+	case u.ChatBoost != nil:
+		return UpdateTypeChatBoost
+	case u.RemovedChatBoost != nil:
+		return UpdateTypeRemovedChatBoost
+	}
+	return ""
+}
+
+// EffectiveMessage returns the message of Update (or of its callback query), if any.
+func (u *Update) EffectiveMessage() *Message {
+	switch {
+	case u.Message != nil:
+		return u.Message
+	case u.EditedMessage != nil:
+		return u.EditedMessage
+	case u.ChannelPost != nil:
+		return u.ChannelPost
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.Message
+	case u.BusinessMessage != nil:
+		return u.BusinessMessage
+	case u.EditedBusinessMessage != nil:
+		return u.EditedBusinessMessage
+	}
+	return nil
+}
+
+// EffectiveChat returns the chat where Update happened, if any.
+func (u *Update) EffectiveChat() *Chat {
+	if message := u.EffectiveMessage(); message != nil {
+		return &message.Chat
+	}
+
+	switch {
+	case u.MyChatMember != nil:
+		return &u.MyChatMember.Chat
+	case u.ChatMember != nil:
+		return &u.ChatMember.Chat
+	case u.ChatJoinRequest != nil:
+		return &u.ChatJoinRequest.Chat
+	case u.MessageReaction != nil:
+		return &u.MessageReaction.Chat
+	case u.MessageReactionCount != nil:
+		return &u.MessageReactionCount.Chat
+	case u.DeletedBusinessMessages != nil:
+		return &u.DeletedBusinessMessages.Chat
+	case u.ChatBoost != nil:
+		return &u.ChatBoost.Chat
+	case u.RemovedChatBoost != nil:
+		return &u.RemovedChatBoost.Chat
+	}
+	return nil
+}
+
+// Command returns the bot command at the beginning of the text of Update's Message, and whether it exists or not.
+//
+// Edited messages, channel posts, and business messages are not checked, as HandleCommand() does.
+func (u *Update) Command() (command Command, ok bool) {
+	if u.Message == nil {
+		return command, false
+	}
+	return ParseCommand(*u.Message)
+}
+
+// IsCommand checks if Update's Message starts with a bot command.
+func (u *Update) IsCommand() bool {
+	_, ok := u.Command()
+	return ok
+}
+
+// CallbackData returns the data of Update's callback query. ("" if none)
+func (u *Update) CallbackData() string {
+	if u.CallbackQuery != nil && u.CallbackQuery.Data != nil {
+		return *u.CallbackQuery.Data
+	}
+	return ""
+}
+
+// EffectiveText returns the text (or caption) of the effective message of Update. ("" if none)
+func (u *Update) EffectiveText() string {
+	if message := u.EffectiveMessage(); message != nil {
+		if message.Text != nil {
+			return *message.Text
+		}
+		if message.Caption != nil {
+			return *message.Caption
+		}
+	}
+	return ""
+}
+
+// Giveaway returns the giveaway in the effective message of Update, if any.
+func (u *Update) Giveaway() *Giveaway {
+	if message := u.EffectiveMessage(); message != nil {
+		return message.Giveaway
+	}
+	return nil
+}
+
+// GiveawayWinners returns the giveaway winners in the effective message of Update, if any.
+func (u *Update) GiveawayWinners() *GiveawayWinners {
+	if message := u.EffectiveMessage(); message != nil {
+		return message.GiveawayWinners
+	}
+	return nil
+}
+
+// GiveawayCompleted returns the completed giveaway in the effective message of Update, if any.
+func (u *Update) GiveawayCompleted() *GiveawayCompleted {
+	if message := u.EffectiveMessage(); message != nil {
+		return message.GiveawayCompleted
+	}
+	return nil
+}
+
+// EffectiveUser returns the user who caused Update, if any.
+func (u *Update) EffectiveUser() *User {
+	switch {
+	case u.Message != nil:
+		return u.Message.From
+	case u.EditedMessage != nil:
+		return u.EditedMessage.From
+	case u.ChannelPost != nil:
+		return u.ChannelPost.From
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost.From
+	case u.InlineQuery != nil:
+		return &u.InlineQuery.From
+	case u.ChosenInlineResult != nil:
+		return &u.ChosenInlineResult.From
+	case u.CallbackQuery != nil:
+		return &u.CallbackQuery.From
+	case u.ShippingQuery != nil:
+		return &u.ShippingQuery.From
+	case u.PreCheckoutQuery != nil:
+		return &u.PreCheckoutQuery.From
+	case u.PollAnswer != nil:
+		return &u.PollAnswer.User
+	case u.MyChatMember != nil:
+		return &u.MyChatMember.From
+	case u.ChatMember != nil:
+		return &u.ChatMember.From
+	case u.ChatJoinRequest != nil:
+		return &u.ChatJoinRequest.From
+	case u.MessageReaction != nil:
+		return u.MessageReaction.User
+	case u.BusinessConnection != nil:
+		return &u.BusinessConnection.User
+	case u.BusinessMessage != nil:
+		return u.BusinessMessage.From
+	case u.EditedBusinessMessage != nil:
+		return u.EditedBusinessMessage.From
+	case u.ChatBoost != nil:
+		return u.ChatBoost.Boost.Source.User
+	case u.RemovedChatBoost != nil:
+		return u.RemovedChatBoost.Source.User
+	}
+	return nil
+}
+
 ////////////////////////////////
 // Helper functions for User
 //
@@ -440,7 +780,7 @@ func (u User) String() string {
 
 // InlineLink generates an inline link for User
 func (u User) InlineLink() string {
-	return fmt.Sprintf("tg://user?id=%d", u.ID)
+	return UserMentionLink(u.ID)
 }
 
 ////////////////////////////////
@@ -621,6 +961,75 @@ func (m *Message) HasPinnedMessage() bool {
 	return m.PinnedMessage != nil
 }
 
+// HasGiveawayCreated checks if Message has GiveawayCreated.
+func (m *Message) HasGiveawayCreated() bool {
+	return m.GiveawayCreated != nil
+}
+
+// HasGiveaway checks if Message has Giveaway.
+func (m *Message) HasGiveaway() bool {
+	return m.Giveaway != nil
+}
+
+// HasGiveawayWinners checks if Message has GiveawayWinners.
+func (m *Message) HasGiveawayWinners() bool {
+	return m.GiveawayWinners != nil
+}
+
+// HasGiveawayCompleted checks if Message has GiveawayCompleted.
+func (m *Message) HasGiveawayCompleted() bool {
+	return m.GiveawayCompleted != nil
+}
+
+// Reply sends a text message to the chat of this message, as a reply to it.
+//
+// In forum topics, the reply is sent to the same topic, and business messages are replied through their business connections.
+//
+//	message.Reply(bot, "pong", nil)
+func (m *Message) Reply(b *Bot, text string, options OptionsSendMessage) (result APIResponse[Message]) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	options.SetReplyToMessageID(m.MessageID)
+	if m.IsTopicMessage {
+		options.SetMessageThreadID(m.MessageThreadID)
+	}
+	if m.BusinessConnectionID != nil {
+		options.SetBusinessConnectionID(*m.BusinessConnectionID)
+	}
+
+	return b.SendMessage(m.Chat.ID, text, options)
+}
+
+// EditText edits the text of this message.
+func (m *Message) EditText(b *Bot, text string, options OptionsEditMessageText) (result APIResponseMessageOrBool) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	return b.EditMessageText(text, options.SetIDs(m.Chat.ID, m.MessageID))
+}
+
+// EditCaption edits the caption of this message.
+func (m *Message) EditCaption(b *Bot, caption string, options OptionsEditMessageCaption) (result APIResponseMessageOrBool) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	return b.EditMessageCaption(options.SetIDs(m.Chat.ID, m.MessageID).SetCaption(caption))
+}
+
+// EditReplyMarkup edits the inline keyboard of this message.
+func (m *Message) EditReplyMarkup(b *Bot, replyMarkup InlineKeyboardMarkup) (result APIResponseMessageOrBool) {
+	return b.EditMessageReplyMarkup(OptionsEditMessageReplyMarkup{}.SetIDs(m.Chat.ID, m.MessageID).SetReplyMarkup(replyMarkup))
+}
+
+// Delete deletes this message.
+func (m *Message) Delete(b *Bot) (result APIResponse[bool]) {
+	return b.DeleteMessage(m.Chat.ID, m.MessageID)
+}
+
 ////////////////////////////////
 // Helper functions for InlineQuery
 //
@@ -630,6 +1039,11 @@ func (i InlineQuery) String() string {
 	return structToString(i)
 }
 
+// Answer sends answers to this inline query.
+func (i *InlineQuery) Answer(b *Bot, results []any, options OptionsAnswerInlineQuery) (result APIResponse[bool]) {
+	return b.AnswerInlineQuery(i.ID, results, options)
+}
+
 ////////////////////////////////
 // Helper functions for ChosenInlineResult
 //
@@ -639,6 +1053,347 @@ func (c ChosenInlineResult) String() string {
 	return structToString(c)
 }
 
+////////////////////////////////
+// Helper functions for ChatMember and ChatPermissions
+//
+// NOTE: predicates are named May...() as ChatMember already has Can... fields,
+// which are only meaningful for some statuses.
+//
+
+// IsOwner checks if ChatMember is the owner of the chat.
+func (m ChatMember) IsOwner() bool {
+	return m.Status == ChatMemberStatusCreator
+}
+
+// IsAdministrator checks if ChatMember is an administrator (or the owner) of the chat.
+func (m ChatMember) IsAdministrator() bool {
+	return m.Status == ChatMemberStatusCreator || m.Status == ChatMemberStatusAdministrator
+}
+
+// IsInChat checks if ChatMember is currently in the chat.
+//
+// Restricted members are in the chat only when their `is_member` is true.
+func (m ChatMember) IsInChat() bool {
+	switch m.Status {
+	case ChatMemberStatusCreator, ChatMemberStatusAdministrator, ChatMemberStatusMember:
+		return true
+	case ChatMemberStatusRestricted:
+		return m.IsMember
+	}
+	return false
+}
+
+// check an administrator right of ChatMember (always true for the owner)
+func (m ChatMember) hasAdministratorRight(right bool) bool {
+	switch m.Status {
+	case ChatMemberStatusCreator:
+		return true
+	case ChatMemberStatusAdministrator:
+		return right
+	}
+	return false
+}
+
+// MayManageChat checks if ChatMember can access the chat event log, statistics, members list, etc.
+func (m ChatMember) MayManageChat() bool {
+	return m.hasAdministratorRight(m.CanManageChat)
+}
+
+// MayDeleteMessages checks if ChatMember can delete messages of other users.
+func (m ChatMember) MayDeleteMessages() bool {
+	return m.hasAdministratorRight(m.CanDeleteMessages)
+}
+
+// MayRestrictMembers checks if ChatMember can restrict, ban, or unban chat members.
+func (m ChatMember) MayRestrictMembers() bool {
+	return m.hasAdministratorRight(m.CanRestrictMembers)
+}
+
+// MayPromoteMembers checks if ChatMember can add new administrators.
+func (m ChatMember) MayPromoteMembers() bool {
+	return m.hasAdministratorRight(m.CanPromoteMembers)
+}
+
+// MayManageVideoChats checks if ChatMember can manage video chats.
+func (m ChatMember) MayManageVideoChats() bool {
+	return m.hasAdministratorRight(m.CanManageVideoChats)
+}
+
+// MayPost checks if ChatMember can post messages in the channel. (channels only)
+func (m ChatMember) MayPost() bool {
+	return m.hasAdministratorRight(m.CanPostMessages)
+}
+
+// MayEditMessages checks if ChatMember can edit messages of other users and pin messages in the channel. (channels only)
+func (m ChatMember) MayEditMessages() bool {
+	return m.hasAdministratorRight(m.CanEditMessages)
+}
+
+// EffectivePermissions returns the permissions which ChatMember actually has in a group or a supergroup,
+// with `defaults` as the default permissions of the chat. (`Chat.Permissions` from GetChat())
+//
+// The owner has all permissions, administrators have all sending permissions (and others from their rights),
+// members have the default ones, restricted members have their own ones, and users who are not in the chat have none.
+func (m ChatMember) EffectivePermissions(defaults ChatPermissions) ChatPermissions {
+	switch m.Status {
+	case ChatMemberStatusCreator:
+		return AllChatPermissions()
+	case ChatMemberStatusAdministrator:
+		permissions := AllChatPermissions()
+		permissions.CanChangeInfo = m.CanChangeInfo
+		permissions.CanInviteUsers = m.CanInviteUsers
+		permissions.CanPinMessages = m.CanPinMessages
+		permissions.CanManageTopics = m.CanManageTopics
+		return permissions
+	case ChatMemberStatusMember:
+		return defaults
+	case ChatMemberStatusRestricted:
+		if !m.IsMember {
+			return ChatPermissions{}
+		}
+		return ChatPermissions{
+			CanSendMessages:       m.CanSendMessages,
+			CanSendAudios:         m.CanSendAudios,
+			CanSendDocuments:      m.CanSendDocuments,
+			CanSendPhotos:         m.CanSendPhotos,
+			CanSendVideos:         m.CanSendVideos,
+			CanSendVideoNotes:     m.CanSendVideoNotes,
+			CanSendVoiceNotes:     m.CanSendVoiceNotes,
+			CanSendPolls:          m.CanSendPolls,
+			CanSendOtherMessages:  m.CanSendOtherMessages,
+			CanAddWebPagePreviews: m.CanAddWebPagePreviews,
+			CanChangeInfo:         m.CanChangeInfo,
+			CanInviteUsers:        m.CanInviteUsers,
+			CanPinMessages:        m.CanPinMessages,
+			CanManageTopics:       m.CanManageTopics,
+		}
+	}
+	return ChatPermissions{}
+}
+
+// AllChatPermissions returns ChatPermissions with all permissions granted.
+func AllChatPermissions() ChatPermissions {
+	return ChatPermissions{
+		CanSendMessages:       true,
+		CanSendAudios:         true,
+		CanSendDocuments:      true,
+		CanSendPhotos:         true,
+		CanSendVideos:         true,
+		CanSendVideoNotes:     true,
+		CanSendVoiceNotes:     true,
+		CanSendPolls:          true,
+		CanSendOtherMessages:  true,
+		CanAddWebPagePreviews: true,
+		CanChangeInfo:         true,
+		CanInviteUsers:        true,
+		CanPinMessages:        true,
+		CanManageTopics:       true,
+	}
+}
+
+// ReadOnlyChatPermissions returns ChatPermissions with all permissions denied. (eg. for muting members)
+func ReadOnlyChatPermissions() ChatPermissions {
+	return ChatPermissions{}
+}
+
+// CanSendMediaMessages checks if any kind of media (audios, documents, photos, videos, video notes, and voice notes) can be sent.
+func (p ChatPermissions) CanSendMediaMessages() bool {
+	return p.CanSendAudios || p.CanSendDocuments || p.CanSendPhotos || p.CanSendVideos || p.CanSendVideoNotes || p.CanSendVoiceNotes
+}
+
+// CanSendAnything checks if any kind of message can be sent.
+func (p ChatPermissions) CanSendAnything() bool {
+	return p.CanSendMessages || p.CanSendMediaMessages() || p.CanSendPolls || p.CanSendOtherMessages
+}
+
+////////////////////////////////
+// Helper functions for ChatMemberUpdated
+//
+
+// StatusChange returns the old and new statuses of the chat member.
+func (u ChatMemberUpdated) StatusChange() (from, to ChatMemberStatus) {
+	return u.OldChatMember.Status, u.NewChatMember.Status
+}
+
+// Joined checks if the user joined (or was added to) the chat.
+//
+// Restricted users who were not in the chat and became members also count.
+func (u ChatMemberUpdated) Joined() bool {
+	return !u.OldChatMember.IsInChat() && u.NewChatMember.IsInChat()
+}
+
+// Left checks if the user left (or was removed or banned from) the chat.
+func (u ChatMemberUpdated) Left() bool {
+	return u.OldChatMember.IsInChat() && !u.NewChatMember.IsInChat()
+}
+
+// WasBanned checks if the user was banned from the chat.
+func (u ChatMemberUpdated) WasBanned() bool {
+	return u.OldChatMember.Status != ChatMemberStatusBanned && u.NewChatMember.Status == ChatMemberStatusBanned
+}
+
+// WasUnbanned checks if the user was unbanned from the chat.
+func (u ChatMemberUpdated) WasUnbanned() bool {
+	return u.OldChatMember.Status == ChatMemberStatusBanned && u.NewChatMember.Status != ChatMemberStatusBanned
+}
+
+// WasPromoted checks if the user became an administrator (or the owner) of the chat.
+func (u ChatMemberUpdated) WasPromoted() bool {
+	return !u.OldChatMember.IsAdministrator() && u.NewChatMember.IsAdministrator()
+}
+
+// WasDemoted checks if the user is no longer an administrator (or the owner) of the chat.
+//
+// Administrators who left or were banned also count.
+func (u ChatMemberUpdated) WasDemoted() bool {
+	return u.OldChatMember.IsAdministrator() && !u.NewChatMember.IsAdministrator()
+}
+
+// AdministratorRightsChanged checks if the user stayed as an administrator, but with different rights or title.
+func (u ChatMemberUpdated) AdministratorRightsChanged() bool {
+	oldMember, newMember := u.OldChatMember, u.NewChatMember
+	if oldMember.Status != ChatMemberStatusAdministrator || newMember.Status != ChatMemberStatusAdministrator {
+		return false
+	}
+
+	// compare rights only
+	oldMember.User, newMember.User = User{}, User{}
+	oldMember.CanBeEdited, newMember.CanBeEdited = false, false
+	return !reflect.DeepEqual(oldMember, newMember)
+}
+
+// WasRestricted checks if the user became restricted in the chat.
+func (u ChatMemberUpdated) WasRestricted() bool {
+	return u.OldChatMember.Status != ChatMemberStatusRestricted && u.NewChatMember.Status == ChatMemberStatusRestricted
+}
+
+// WasUnrestricted checks if the restrictions of the user were lifted, while staying in the chat.
+func (u ChatMemberUpdated) WasUnrestricted() bool {
+	return u.OldChatMember.Status == ChatMemberStatusRestricted &&
+		u.NewChatMember.Status != ChatMemberStatusRestricted &&
+		u.NewChatMember.IsInChat()
+}
+
+// RestrictionsChanged checks if the user stayed restricted, but with different permissions or until date.
+func (u ChatMemberUpdated) RestrictionsChanged() bool {
+	oldMember, newMember := u.OldChatMember, u.NewChatMember
+	if oldMember.Status != ChatMemberStatusRestricted || newMember.Status != ChatMemberStatusRestricted {
+		return false
+	}
+
+	// compare permissions and until date only
+	oldMember.User, newMember.User = User{}, User{}
+	oldMember.IsMember, newMember.IsMember = false, false
+	return !reflect.DeepEqual(oldMember, newMember)
+}
+
+////////////////////////////////
+// Helper functions for Dice
+//
+
+// DiceEmoji returns the emoji of Dice as a DiceEmoji.
+func (d Dice) DiceEmoji() DiceEmoji {
+	return DiceEmoji(d.Emoji)
+}
+
+// MaxValue returns the maximum value of Dice with its emoji.
+func (d Dice) MaxValue() int {
+	switch d.DiceEmoji() {
+	case DiceEmojiBasketball, DiceEmojiFootball:
+		return 5
+	case DiceEmojiSlotMachine:
+		return 64
+	}
+	return 6
+}
+
+// IsWin checks if Dice has the best outcome of its emoji:
+// 6 for 🎲, bullseye for 🎯, strike for 🎳, scored for 🏀 and ⚽, and three of a kind for 🎰.
+func (d Dice) IsWin() bool {
+	switch d.DiceEmoji() {
+	case DiceEmojiBasketball, DiceEmojiFootball:
+		return d.IsScored()
+	case DiceEmojiSlotMachine:
+		return d.IsThreeOfAKind()
+	}
+	return d.Value == 6
+}
+
+// IsBullseye checks if 🎯 hit the bullseye.
+func (d Dice) IsBullseye() bool {
+	return d.DiceEmoji() == DiceEmojiDarts && d.Value == 6
+}
+
+// IsStrike checks if 🎳 knocked down all pins.
+func (d Dice) IsStrike() bool {
+	return d.DiceEmoji() == DiceEmojiBowling && d.Value == 6
+}
+
+// BowlingPins returns the number of pins knocked down by 🎳. (0~6)
+func (d Dice) BowlingPins() int {
+	if d.DiceEmoji() != DiceEmojiBowling {
+		return 0
+	}
+
+	switch d.Value {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	case 3, 4, 5, 6:
+		return d.Value
+	}
+	return 0
+}
+
+// IsScored checks if 🏀 or ⚽ went into the basket or goal.
+//
+// 🏀 scores with 4~5, and ⚽ scores with 3~5.
+func (d Dice) IsScored() bool {
+	switch d.DiceEmoji() {
+	case DiceEmojiBasketball:
+		return d.Value >= 4
+	case DiceEmojiFootball:
+		return d.Value >= 3
+	}
+	return false
+}
+
+// SlotMachineReels returns the symbols on the left, center, and right reels of 🎰.
+//
+// Returns nil for other emojis or invalid values.
+func (d Dice) SlotMachineReels() []SlotMachineSymbol {
+	if d.DiceEmoji() != DiceEmojiSlotMachine || d.Value < 1 || d.Value > 64 {
+		return nil
+	}
+
+	// (value - 1) is a 3-digit base-4 number, with the left reel as the least significant digit
+	symbols := []SlotMachineSymbol{SlotMachineSymbolBar, SlotMachineSymbolGrapes, SlotMachineSymbolLemon, SlotMachineSymbolSeven}
+	reels := make([]SlotMachineSymbol, 3)
+	for i := range reels {
+		reels[i] = symbols[((d.Value-1)>>(2*i))&3]
+	}
+	return reels
+}
+
+// IsThreeOfAKind checks if all reels of 🎰 show the same symbol. (1: bar, 22: grapes, 43: lemon, and 64: seven)
+func (d Dice) IsThreeOfAKind() bool {
+	if d.DiceEmoji() != DiceEmojiSlotMachine {
+		return false
+	}
+
+	switch d.Value {
+	case 1, 22, 43, 64:
+		return true
+	}
+	return false
+}
+
+// IsJackpot checks if 🎰 shows three sevens.
+func (d Dice) IsJackpot() bool {
+	return d.DiceEmoji() == DiceEmojiSlotMachine && d.Value == 64
+}
+
 ////////////////////////////////
 // Helper functions for KeyboardButton and InlineKeyboardButton
 //
@@ -728,6 +1483,81 @@ func NewInlineKeyboardButtonsWithSwitchInlineQuery(values map[string]string) []I
 	return keyboards
 }
 
+////////////////////////////////
+// Helper functions for ReplyKeyboardRemove and ForceReply
+//
+
+// NewReplyKeyboardRemove generates a ReplyKeyboardRemove for removing the custom keyboard.
+//
+//	bot.SendMessage(chatID, "done", OptionsSendMessage{}.SetReplyMarkup(NewReplyKeyboardRemove()))
+func NewReplyKeyboardRemove() ReplyKeyboardRemove {
+	return ReplyKeyboardRemove{
+		RemoveKeyboard: true,
+	}
+}
+
+// WithSelective returns a copy of ReplyKeyboardRemove which removes the keyboard only for
+// mentioned users and the sender of the replied message.
+func (r ReplyKeyboardRemove) WithSelective() ReplyKeyboardRemove {
+	r.Selective = true
+	return r
+}
+
+// NewForceReply generates a ForceReply which shows a reply interface to the user.
+//
+//	bot.SendMessage(chatID, "What's your name?", OptionsSendMessage{}.
+//		SetReplyMarkup(NewForceReply().WithInputFieldPlaceholder("Your name")))
+func NewForceReply() ForceReply {
+	return ForceReply{
+		ForceReply: true,
+	}
+}
+
+// WithInputFieldPlaceholder returns a copy of ForceReply with the placeholder of input field. (1-64 characters)
+func (r ForceReply) WithInputFieldPlaceholder(placeholder string) ForceReply {
+	r.InputFieldPlaceholder = &placeholder
+	return r
+}
+
+// WithSelective returns a copy of ForceReply which forces reply only from
+// mentioned users and the sender of the replied message.
+func (r ForceReply) WithSelective() ForceReply {
+	r.Selective = true
+	return r
+}
+
+////////////////////////////////
+// Helper functions for ReplyParameters
+//
+
+// NewReplyParameters generates a ReplyParameters for replying to a message in the same chat.
+//
+//	bot.SendMessage(chatID, "agreed", OptionsSendMessage{}.
+//		SetReplyParameters(NewReplyParameters(messageID).WithQuote("the exact part")))
+func NewReplyParameters(messageID int64) ReplyParameters {
+	return ReplyParameters{
+		MessageID: messageID,
+	}
+}
+
+// WithChatID returns a copy of ReplyParameters which replies to a message in another chat.
+func (p ReplyParameters) WithChatID(chatID ChatID) ReplyParameters {
+	p.ChatID = chatID
+	return p
+}
+
+// WithQuote returns a copy of ReplyParameters which quotes an exact part of the replied message.
+func (p ReplyParameters) WithQuote(quote string) ReplyParameters {
+	p.Quote = &quote
+	return p
+}
+
+// WithAllowSendingWithoutReply returns a copy of ReplyParameters which is sent even if the replied message is not found.
+func (p ReplyParameters) WithAllowSendingWithoutReply() ReplyParameters {
+	p.AllowSendingWithoutReply = true
+	return p
+}
+
 ////////////////////////////////
 // Helper functions for CallbackQuery
 
@@ -736,6 +1566,151 @@ func (q CallbackQuery) String() string {
 	return structToString(q)
 }
 
+// Answer answers this callback query with given text. (no notification is shown if `text` is empty)
+func (q *CallbackQuery) Answer(b *Bot, text string) (result APIResponse[bool]) {
+	options := OptionsAnswerCallbackQuery{}
+	if text != "" {
+		options.SetText(text)
+	}
+
+	return b.AnswerCallbackQuery(q.ID, options)
+}
+
+// AnswerAlert answers this callback query with an alert of given text.
+func (q *CallbackQuery) AnswerAlert(b *Bot, text string) (result APIResponse[bool]) {
+	return b.AnswerCallbackQuery(q.ID, OptionsAnswerCallbackQuery{}.SetText(text).SetShowAlert(true))
+}
+
+// EditText edits the text of the message with the callback button.
+//
+// Both messages sent by the bot and messages sent via the bot (inline mode) can be edited.
+//
+//	query.EditText(bot, "Done!", OptionsEditMessageText{}.SetReplyMarkup(keyboard))
+func (q *CallbackQuery) EditText(b *Bot, text string, options OptionsEditMessageText) (result APIResponseMessageOrBool) {
+	if options == nil {
+		options = map[string]any{}
+	}
+
+	if q.Message != nil {
+		options.SetIDs(q.Message.Chat.ID, q.Message.MessageID)
+	} else if q.InlineMessageID != nil {
+		options.SetInlineMessageID(*q.InlineMessageID)
+	}
+
+	return b.EditMessageText(text, options)
+}
+
+// EditReplyMarkup edits the inline keyboard of the message with the callback button.
+func (q *CallbackQuery) EditReplyMarkup(b *Bot, replyMarkup InlineKeyboardMarkup) (result APIResponseMessageOrBool) {
+	options := OptionsEditMessageReplyMarkup{}.SetReplyMarkup(replyMarkup)
+	if q.Message != nil {
+		options.SetIDs(q.Message.Chat.ID, q.Message.MessageID)
+	} else if q.InlineMessageID != nil {
+		options.SetInlineMessageID(*q.InlineMessageID)
+	}
+
+	return b.EditMessageReplyMarkup(options)
+}
+
+////////////////////////////////
+// Helper functions for InputMedia
+
+// NewInputMedia generates an InputMedia of given type with `media`.
+//
+// When `media` should be uploaded (eg. a local file), it is attached as a multipart part automatically:
+//
+//	bot.SendMediaGroup(chatID, []InputMedia{
+//		NewInputMediaPhotoFromFile("./photo1.jpg").WithCaption("Day 1"),
+//		NewInputMediaPhotoFromFile("./photo2.jpg"),
+//		NewInputMedia(InputMediaPhoto, InputFileFromFileID(fileID)),
+//	}, nil)
+func NewInputMedia(mediaType InputMediaType, media InputFile) InputMedia {
+	return InputMedia{
+		Type:      mediaType,
+		MediaFile: &media,
+	}
+}
+
+// NewInputMediaPhotoFromFile generates an InputMedia of photo with a local file at given filepath.
+func NewInputMediaPhotoFromFile(filepath string) InputMedia {
+	return NewInputMedia(InputMediaPhoto, InputFileFromFilepath(filepath))
+}
+
+// NewInputMediaPhotoFromReader generates an InputMedia of photo with given reader. (see InputFileFromReader())
+func NewInputMediaPhotoFromReader(reader io.Reader, filename string, size int64) InputMedia {
+	return NewInputMedia(InputMediaPhoto, InputFileFromReader(reader, filename, size))
+}
+
+// NewInputMediaVideoFromFile generates an InputMedia of video with a local file at given filepath.
+func NewInputMediaVideoFromFile(filepath string) InputMedia {
+	return NewInputMedia(InputMediaVideo, InputFileFromFilepath(filepath))
+}
+
+// NewInputMediaVideoFromReader generates an InputMedia of video with given reader. (see InputFileFromReader())
+func NewInputMediaVideoFromReader(reader io.Reader, filename string, size int64) InputMedia {
+	return NewInputMedia(InputMediaVideo, InputFileFromReader(reader, filename, size))
+}
+
+// NewInputMediaAnimationFromFile generates an InputMedia of animation with a local file at given filepath.
+func NewInputMediaAnimationFromFile(filepath string) InputMedia {
+	return NewInputMedia(InputMediaAnimation, InputFileFromFilepath(filepath))
+}
+
+// NewInputMediaAnimationFromReader generates an InputMedia of animation with given reader. (see InputFileFromReader())
+func NewInputMediaAnimationFromReader(reader io.Reader, filename string, size int64) InputMedia {
+	return NewInputMedia(InputMediaAnimation, InputFileFromReader(reader, filename, size))
+}
+
+// NewInputMediaAudioFromFile generates an InputMedia of audio with a local file at given filepath.
+func NewInputMediaAudioFromFile(filepath string) InputMedia {
+	return NewInputMedia(InputMediaAudio, InputFileFromFilepath(filepath))
+}
+
+// NewInputMediaAudioFromReader generates an InputMedia of audio with given reader. (see InputFileFromReader())
+func NewInputMediaAudioFromReader(reader io.Reader, filename string, size int64) InputMedia {
+	return NewInputMedia(InputMediaAudio, InputFileFromReader(reader, filename, size))
+}
+
+// NewInputMediaDocumentFromFile generates an InputMedia of document with a local file at given filepath.
+func NewInputMediaDocumentFromFile(filepath string) InputMedia {
+	return NewInputMedia(InputMediaDocument, InputFileFromFilepath(filepath))
+}
+
+// NewInputMediaDocumentFromReader generates an InputMedia of document with given reader. (see InputFileFromReader())
+func NewInputMediaDocumentFromReader(reader io.Reader, filename string, size int64) InputMedia {
+	return NewInputMedia(InputMediaDocument, InputFileFromReader(reader, filename, size))
+}
+
+// WithCaption returns a copy of InputMedia with given caption.
+func (m InputMedia) WithCaption(caption string) InputMedia {
+	m.Caption = &caption
+	return m
+}
+
+// WithParseMode returns a copy of InputMedia with given parse mode of its caption.
+func (m InputMedia) WithParseMode(parseMode ParseMode) InputMedia {
+	m.ParseMode = &parseMode
+	return m
+}
+
+// WithCaptionEntities returns a copy of InputMedia with given entities of its caption.
+func (m InputMedia) WithCaptionEntities(entities []MessageEntity) InputMedia {
+	m.CaptionEntities = entities
+	return m
+}
+
+// WithSpoiler returns a copy of InputMedia covered with a spoiler animation. (video, animation, photo)
+func (m InputMedia) WithSpoiler() InputMedia {
+	m.HasSpoiler = true
+	return m
+}
+
+// WithThumbnail returns a copy of InputMedia with given thumbnail, which is attached automatically. (video, animation, audio, document)
+func (m InputMedia) WithThumbnail(thumbnail InputFile) InputMedia {
+	m.Thumbnail = &thumbnail
+	return m
+}
+
 ////////////////////////////////
 // Other helper functions
 
@@ -750,6 +1725,11 @@ func structToString(v any) string {
 	return err.Error()
 }
 
+// length of given string in UTF-16 code units (offsets and lengths of MessageEntity are counted in them)
+func utf16Len(str string) int {
+	return len(utf16.Encode([]rune(str)))
+}
+
 // InputFileFromFilepath generates an InputFile from given filepath
 func InputFileFromFilepath(filepath string) InputFile {
 	return InputFile{
@@ -771,9 +1751,62 @@ func InputFileFromBytes(bytes []byte) InputFile {
 	}
 }
 
+// InputFileFromBytesWithFilename generates an InputFile from given bytes array, uploaded with given filename
+func InputFileFromBytesWithFilename(bytes []byte, filename string) InputFile {
+	return InputFile{
+		Bytes:    bytes,
+		Filename: &filename,
+	}
+}
+
+// InputFileFromReader generates an InputFile from given reader, uploaded with given filename
+// ("" for generating it from the content type) and size of its content (0 if unknown)
+//
+// Its content is streamed while uploading (eg. from S3 objects or HTTP responses), so it is not held in memory.
+// It is closed after the request if it is an io.Closer.
+func InputFileFromReader(reader io.Reader, filename string, size int64) InputFile {
+	file := InputFile{
+		Reader: reader,
+		Size:   size,
+	}
+	if filename != "" {
+		file.Filename = &filename
+	}
+	return file
+}
+
 // InputFileFromFileID generates an InputFile from given file id
 func InputFileFromFileID(fileID string) InputFile {
 	return InputFile{
 		FileID: &fileID,
 	}
 }
+
+// InputFileFromFileIDWithSource generates an InputFile from given file id, with its original file
+//
+// When sending with the file id fails because it is wrong (or expired), `source` will be uploaded instead.
+func InputFileFromFileIDWithSource(fileID string, source InputFile) InputFile {
+	return InputFile{
+		FileID: &fileID,
+		Source: &source,
+	}
+}
+
+// MarshalJSON encodes InputFile in JSON-serialized params (eg. `thumbnail` of InputMedia) as its file id or URL.
+//
+// Files to upload cannot be encoded, and should be attached as multipart parts instead.
+// (SendMediaGroup() and EditMessageMedia() attach them automatically)
+func (f InputFile) MarshalJSON() ([]byte, error) {
+	if f.FileID != nil {
+		return json.Marshal(*f.FileID)
+	}
+	if f.URL != nil {
+		return json.Marshal(*f.URL)
+	}
+	return nil, fmt.Errorf("InputFile to upload cannot be encoded in JSON, it should be attached")
+}
+
+// check if InputFile has a content to upload
+func (f InputFile) uploadable() bool {
+	return len(f.Bytes) > 0 || f.Filepath != nil || f.Reader != nil
+}
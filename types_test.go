@@ -0,0 +1,143 @@
+package telegrambot
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// decoders for API responses in testdata/responses/, keyed by the prefix of file names
+var _responseDecoders = map[string]func(data []byte) (any, error){
+	"user":        decodeStrict[APIResponse[User]],
+	"chat":        decodeStrict[APIResponse[Chat]],
+	"webhookinfo": decodeStrict[APIResponse[WebhookInfo]],
+	"message":     decodeStrict[APIResponse[Message]],
+	"chatmembers": decodeStrict[APIResponse[[]ChatMember]],
+	"stickerset":  decodeStrict[APIResponse[StickerSet]],
+	"file":        decodeStrict[APIResponse[File]],
+	"error":       decodeStrict[APIResponse[bool]],
+}
+
+// TestUpdatesRoundTrip tests unmarshal -> marshal round trips of updates in testdata/updates/.
+func TestUpdatesRoundTrip(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "updates", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find golden files for updates: %v", err)
+	}
+
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			testRoundTrip(t, file, decodeStrict[Update])
+		})
+	}
+}
+
+// TestResponsesRoundTrip tests unmarshal -> marshal round trips of API responses in testdata/responses/.
+func TestResponsesRoundTrip(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "responses", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find golden files for responses: %v", err)
+	}
+
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+			decode, exists := _responseDecoders[prefix]
+			if !exists {
+				t.Fatalf("no decoder for golden file: %s", file)
+			}
+
+			testRoundTrip(t, file, decode)
+		})
+	}
+}
+
+// unmarshal given file, marshal it again, and compare the two
+func testRoundTrip(t *testing.T, file string, decode func([]byte) (any, error)) {
+	original, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %s", err)
+	}
+
+	decoded, err := decode(original)
+	if err != nil {
+		t.Fatalf("failed to unmarshal golden file (missing field or wrong type?): %s", err)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+
+	var expected, actual any
+	if err := json.Unmarshal(original, &expected); err != nil {
+		t.Fatalf("invalid golden file: %s", err)
+	}
+	if err := json.Unmarshal(encoded, &actual); err != nil {
+		t.Fatalf("invalid marshaled json: %s", err)
+	}
+	expected, actual = normalizeJSON(expected), normalizeJSON(actual)
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("round trip mismatch for %s\nexpected: %v\nactual:   %v", file, expected, actual)
+	}
+}
+
+// unmarshal given bytes into T, failing on unknown fields
+func decodeStrict[T any](data []byte) (any, error) {
+	var v T
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// remove zero values (which can be omitted with `omitempty`) from decoded json
+func normalizeJSON(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		normalized := map[string]any{}
+		for k, v := range val {
+			if n := normalizeJSON(v); n != nil {
+				normalized[k] = n
+			}
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return normalized
+	case []any:
+		normalized := []any{}
+		for _, v := range val {
+			normalized = append(normalized, normalizeJSON(v))
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return normalized
+	case bool:
+		if !val {
+			return nil
+		}
+	case float64:
+		if val == 0 {
+			return nil
+		}
+	case string:
+		if val == "" {
+			return nil
+		}
+	}
+
+	return v
+}
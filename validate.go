@@ -0,0 +1,274 @@
+package telegrambot
+
+// Validating params of API requests before sending them, for failing fast with descriptive errors.
+
+import (
+	"fmt"
+	"os"
+)
+
+// description of errors from the payload validation
+const payloadValidationDescription = "payload validation failed"
+
+// default values of PayloadLimits
+const (
+	defaultInlineKeyboardMaxButtons = 100
+	defaultCallbackDataMaxBytes     = 64
+	defaultPollQuestionMaxLength    = 300
+	defaultPollOptionMaxLength      = 100
+	defaultPollMinOptions           = 2
+	defaultPollMaxOptions           = 12
+	defaultUploadPhotoMaxSize       = 10 * 1024 * 1024
+	defaultUploadFileMaxSize        = 50 * 1024 * 1024
+
+	bulkMessagesMaxCount = 100 // maximum number of `message_ids` in forwardMessages, copyMessages, and deleteMessages
+
+	// LocalServerUploadFileMaxSize is the maximum size of uploaded files with a local Bot API server
+	LocalServerUploadFileMaxSize = 2000 * 1024 * 1024
+)
+
+// PayloadLimits is a set of limits for validating params of API requests before sending them
+//
+// Zero values are replaced with the limits of Telegram's Bot API server.
+type PayloadLimits struct {
+	MessageTextLength     int // maximum length of `text` in sendMessage and editMessageText (default: MessageTextMaxLength)
+	CaptionLength         int // maximum length of `caption` (default: CaptionMaxLength)
+	InlineKeyboardButtons int // maximum number of buttons in an inline keyboard (default: 100)
+	CallbackDataBytes     int // maximum bytes of `callback_data` of inline keyboard buttons (default: 64)
+
+	PollQuestionLength int // maximum length of a poll's question (default: 300)
+	PollOptionLength   int // maximum length of each poll option (default: 100)
+	PollMinOptions     int // minimum number of poll options (default: 2)
+	PollMaxOptions     int // maximum number of poll options (default: 12)
+
+	UploadPhotoSize int64 // maximum size of uploaded photos in bytes (default: 10MB)
+	UploadFileSize  int64 // maximum size of other uploaded files in bytes (default: 50MB, LocalServerUploadFileMaxSize for local Bot API servers)
+}
+
+// fill default values of the limits
+func (l PayloadLimits) withDefaults() PayloadLimits {
+	if l.MessageTextLength <= 0 {
+		l.MessageTextLength = MessageTextMaxLength
+	}
+	if l.CaptionLength <= 0 {
+		l.CaptionLength = CaptionMaxLength
+	}
+	if l.InlineKeyboardButtons <= 0 {
+		l.InlineKeyboardButtons = defaultInlineKeyboardMaxButtons
+	}
+	if l.CallbackDataBytes <= 0 {
+		l.CallbackDataBytes = defaultCallbackDataMaxBytes
+	}
+	if l.PollQuestionLength <= 0 {
+		l.PollQuestionLength = defaultPollQuestionMaxLength
+	}
+	if l.PollOptionLength <= 0 {
+		l.PollOptionLength = defaultPollOptionMaxLength
+	}
+	if l.PollMinOptions <= 0 {
+		l.PollMinOptions = defaultPollMinOptions
+	}
+	if l.PollMaxOptions <= 0 {
+		l.PollMaxOptions = defaultPollMaxOptions
+	}
+	if l.UploadPhotoSize <= 0 {
+		l.UploadPhotoSize = defaultUploadPhotoMaxSize
+	}
+	if l.UploadFileSize <= 0 {
+		l.UploadFileSize = defaultUploadFileMaxSize
+	}
+	return l
+}
+
+// SetPayloadLimits enables validating params of API requests with given limits before sending them,
+// or disables the validation if `limits` is nil. (disabled by default)
+//
+// Requests with invalid params fail without hitting the network, with errors which name the offending params.
+// (see IsValidationError())
+//
+// NOTE: Telegram may raise its limits at any time, so override stale defaults with explicit limits if needed.
+//
+// With a local Bot API server (see SetLocalServer()), UploadFileSize defaults to LocalServerUploadFileMaxSize.
+//
+//	client.SetPayloadLimits(&PayloadLimits{CaptionLength: 2048})
+func (b *Bot) SetPayloadLimits(limits *PayloadLimits) {
+	if limits == nil {
+		b.payloadLimits = nil
+		return
+	}
+
+	validated := *limits
+	if b.localServer && validated.UploadFileSize <= 0 {
+		validated.UploadFileSize = LocalServerUploadFileMaxSize
+	}
+	validated = validated.withDefaults()
+	b.payloadLimits = &validated
+}
+
+// IsValidationError checks if given error is from the payload validation, so the request was not sent.
+func IsValidationError(err error) bool {
+	return isAPIError(err, 0, payloadValidationDescription)
+}
+
+// validate params of a method, and return an error which names the offending param
+func (l PayloadLimits) validate(method string, params map[string]any) error {
+	fail := func(field, format string, args ...any) error {
+		return fmt.Errorf("%s: %s: %s", payloadValidationDescription, field, fmt.Sprintf(format, args...))
+	}
+
+	parseMode := paramParseMode(params["parse_mode"])
+
+	// text
+	if method == "sendMessage" || method == "editMessageText" {
+		if text, ok := params["text"].(string); ok {
+			if length := parsedTextLength(text, parseMode); length > l.MessageTextLength {
+				return fail("text", "length %d exceeds %d", length, l.MessageTextLength)
+			}
+		}
+	}
+
+	// captions
+	if caption, ok := params["caption"].(string); ok {
+		if length := parsedTextLength(caption, parseMode); length > l.CaptionLength {
+			return fail("caption", "length %d exceeds %d", length, l.CaptionLength)
+		}
+	}
+	if media, ok := params["media"].([]InputMedia); ok {
+		for i, m := range media {
+			if m.Caption == nil {
+				continue
+			}
+			var mode ParseMode
+			if m.ParseMode != nil {
+				mode = *m.ParseMode
+			}
+			if length := parsedTextLength(*m.Caption, mode); length > l.CaptionLength {
+				return fail(fmt.Sprintf("media[%d].caption", i), "length %d exceeds %d", length, l.CaptionLength)
+			}
+		}
+	}
+
+	// inline keyboards
+	var keyboard *InlineKeyboardMarkup
+	switch markup := params["reply_markup"].(type) {
+	case InlineKeyboardMarkup:
+		keyboard = &markup
+	case *InlineKeyboardMarkup:
+		keyboard = markup
+	}
+	if keyboard != nil {
+		count := 0
+		for i, row := range keyboard.InlineKeyboard {
+			for j, button := range row {
+				count++
+				if button.CallbackData != nil && len(*button.CallbackData) > l.CallbackDataBytes {
+					return fail(fmt.Sprintf("reply_markup.inline_keyboard[%d][%d].callback_data", i, j), "%d bytes exceed %d", len(*button.CallbackData), l.CallbackDataBytes)
+				}
+			}
+		}
+		if count > l.InlineKeyboardButtons {
+			return fail("reply_markup.inline_keyboard", "%d buttons exceed %d", count, l.InlineKeyboardButtons)
+		}
+	}
+
+	// polls
+	if method == "sendPoll" {
+		if question, ok := params["question"].(string); ok && utf16Len(question) > l.PollQuestionLength {
+			return fail("question", "length %d exceeds %d", utf16Len(question), l.PollQuestionLength)
+		}
+		if options, ok := params["options"].([]string); ok {
+			if len(options) < l.PollMinOptions || len(options) > l.PollMaxOptions {
+				return fail("options", "%d options are not in %d-%d", len(options), l.PollMinOptions, l.PollMaxOptions)
+			}
+			for i, option := range options {
+				if utf16Len(option) > l.PollOptionLength {
+					return fail(fmt.Sprintf("options[%d]", i), "length %d exceeds %d", utf16Len(option), l.PollOptionLength)
+				}
+			}
+		}
+	}
+
+	// bulk messages
+	if messageIDs, ok := params["message_ids"].([]int64); ok {
+		if len(messageIDs) < 1 || len(messageIDs) > bulkMessagesMaxCount {
+			return fail("message_ids", "%d messages are not in 1-%d", len(messageIDs), bulkMessagesMaxCount)
+		}
+	}
+
+	// uploaded files
+	for key, value := range params {
+		size, ok := paramFileSize(value)
+		if !ok {
+			continue
+		}
+
+		limit := l.UploadFileSize
+		if key == "photo" {
+			limit = l.UploadPhotoSize
+		}
+		if size > limit {
+			return fail(key, "file size %d bytes exceeds %d bytes", size, limit)
+		}
+	}
+
+	return nil
+}
+
+// parse mode of given `parse_mode` param
+func paramParseMode(value any) ParseMode {
+	switch mode := value.(type) {
+	case ParseMode:
+		return mode
+	case *ParseMode:
+		if mode != nil {
+			return *mode
+		}
+	case string:
+		return ParseMode(mode)
+	}
+	return ""
+}
+
+// length of given text after parsing (in UTF-16 code units)
+func parsedTextLength(text string, parseMode ParseMode) (length int) {
+	var units []textUnit
+	switch parseMode {
+	case ParseModeHTML:
+		units = htmlTextUnits(text)
+	case ParseModeMarkdownV2:
+		units = markdownTextUnits(text, true)
+	case ParseModeMarkdown:
+		units = markdownTextUnits(text, false)
+	default:
+		return utf16Len(text)
+	}
+	for _, unit := range units {
+		length += unit.length
+	}
+	return length
+}
+
+// size of a file param to be uploaded (not ok if it is not uploaded, or its size is unknown)
+func paramFileSize(value any) (size int64, ok bool) {
+	switch val := value.(type) {
+	case []byte:
+		return int64(len(val)), true
+	case *os.File:
+		if info, err := val.Stat(); err == nil && info.Mode().IsRegular() {
+			return info.Size(), true
+		}
+	case InputFile:
+		if len(val.Bytes) > 0 {
+			return int64(len(val.Bytes)), true
+		}
+		if val.Reader != nil && val.Size > 0 {
+			return val.Size, true
+		}
+		if val.Filepath != nil {
+			if info, err := os.Stat(*val.Filepath); err == nil {
+				return info.Size(), true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,209 @@
+package telegrambot
+
+// Record/replay transport for testing with recorded API interactions.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// VCRMode is a mode of VCRTransport
+type VCRMode int
+
+// VCRMode constants
+const (
+	VCRModeRecord VCRMode = iota // send requests to the API server and record interactions
+	VCRModeReplay                // replay recorded interactions without network access
+)
+
+const (
+	vcrMultipartBody = "<multipart form data>" // multipart bodies (files) are not recorded
+)
+
+// token pattern in API urls: /bot<id>:<secret>/, /file/bot<id>:<secret>/
+var _vcrTokenRegex = regexp.MustCompile(`bot\d+:[A-Za-z0-9_-]+`)
+
+// VCRInteraction is a recorded pair of an API request and its response
+type VCRInteraction struct {
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	ContentType  string `json:"content_type,omitempty"`
+	RequestBody  string `json:"request_body,omitempty"`
+	StatusCode   int    `json:"status_code"`
+	ResponseBody string `json:"response_body"`
+}
+
+// VCRTransport is a http.RoundTripper which records real API interactions to a fixture file,
+// or replays them deterministically from it.
+//
+// Bot tokens (and other given secrets) are scrubbed from recorded urls and bodies.
+type VCRTransport struct {
+	mode        VCRMode
+	fixturePath string
+	secrets     []string
+
+	base http.RoundTripper // for recording
+
+	interactions []VCRInteraction
+	used         []bool
+
+	lock sync.Mutex
+}
+
+// NewVCRTransport returns a new VCRTransport with given fixture file and mode.
+//
+// In replay mode, the fixture file should exist, and will be loaded immediately.
+// In record mode, interactions will be written to the fixture file with Save().
+//
+// `secrets` (eg. bot token, provider token) will be scrubbed from recorded interactions.
+func NewVCRTransport(fixturePath string, mode VCRMode, secrets ...string) (*VCRTransport, error) {
+	t := &VCRTransport{
+		mode:        mode,
+		fixturePath: fixturePath,
+		secrets:     secrets,
+		base:        http.DefaultTransport,
+	}
+
+	if mode == VCRModeReplay {
+		bytes, err := os.ReadFile(fixturePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vcr fixture: %w", err)
+		}
+		if err := json.Unmarshal(bytes, &t.interactions); err != nil {
+			return nil, fmt.Errorf("failed to parse vcr fixture: %w", err)
+		}
+		t.used = make([]bool, len(t.interactions))
+	}
+
+	return t, nil
+}
+
+// SetBaseTransport sets the underlying transport used in record mode.
+func (t *VCRTransport) SetBaseTransport(base http.RoundTripper) *VCRTransport {
+	t.base = base
+	return t
+}
+
+// Interactions returns the recorded (or loaded) interactions.
+func (t *VCRTransport) Interactions() []VCRInteraction {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return append([]VCRInteraction{}, t.interactions...)
+}
+
+// Save writes recorded interactions to the fixture file.
+func (t *VCRTransport) Save() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	bytes, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode vcr fixture: %w", err)
+	}
+
+	return os.WriteFile(t.fixturePath, bytes, 0644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *VCRTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == VCRModeReplay {
+		return t.replay(req)
+	}
+	return t.record(req)
+}
+
+// send request through the base transport and record the interaction
+func (t *VCRTransport) record(req *http.Request) (*http.Response, error) {
+	contentType := req.Header.Get("Content-Type")
+
+	var reqBody string
+	if req.Body != nil {
+		if strings.HasPrefix(contentType, "multipart/") {
+			reqBody = vcrMultipartBody
+		} else {
+			bs, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			reqBody = string(bs)
+			req.Body = io.NopCloser(bytes.NewReader(bs))
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	t.lock.Lock()
+	t.interactions = append(t.interactions, VCRInteraction{
+		Method:       req.Method,
+		URL:          t.scrub(req.URL.String()),
+		ContentType:  t.scrub(contentType),
+		RequestBody:  t.scrub(reqBody),
+		StatusCode:   resp.StatusCode,
+		ResponseBody: t.scrub(string(respBody)),
+	})
+	t.lock.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// find the first unused interaction with the same method and url, and build a response from it
+func (t *VCRTransport) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	url := t.scrub(req.URL.String())
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Method != req.Method || interaction.URL != url {
+			continue
+		}
+		t.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+			ContentLength: int64(len(interaction.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded vcr interaction for: %s %s", req.Method, url)
+}
+
+// remove bot tokens and secrets from given string
+func (t *VCRTransport) scrub(str string) string {
+	for _, secret := range t.secrets {
+		if secret != "" {
+			str = strings.ReplaceAll(str, secret, redactedString)
+		}
+	}
+	return _vcrTokenRegex.ReplaceAllString(str, "bot"+redactedString)
+}
@@ -0,0 +1,199 @@
+package telegrambot
+
+// Serving webhook requests on custom servers, and verifying their sources and secret tokens.
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// name of the http header which carries the secret token of webhook requests
+//
+// https://core.telegram.org/bots/api#setwebhook
+const webhookSecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// TelegramWebhookSubnets are the subnets which Telegram sends webhook requests from
+//
+// https://core.telegram.org/bots/webhooks#the-short-version
+var TelegramWebhookSubnets = []string{"149.154.160.0/20", "91.108.4.0/22"}
+
+// WebhookIPFilter is a configuration for allowing webhook requests only from given subnets
+type WebhookIPFilter struct {
+	// subnets in CIDR notation which webhook requests are allowed from (default: TelegramWebhookSubnets)
+	Subnets []string
+
+	// subnets of reverse proxies whose `X-Forwarded-For` headers are trusted (nil for ignoring the header)
+	//
+	// The client's address is the rightmost one in the header which is not a trusted proxy.
+	TrustedProxies []string
+}
+
+// parsed WebhookIPFilter
+type webhookIPFilter struct {
+	subnets        []*net.IPNet
+	trustedProxies []*net.IPNet
+}
+
+// message of the HMAC which derives default secret tokens from bot tokens
+const webhookSecretTokenMessage = "webhook-secret-token"
+
+// WebhookPath returns the path of webhook requests, for mounting WebhookHandler() on a custom server.
+func (b *Bot) WebhookPath() string {
+	return b.getWebhookPath()
+}
+
+// WebhookHandler returns a http handler of webhook requests, for serving them on a custom server
+// (eg. with its own TLS termination, behind a reverse proxy, or along with other routes)
+// instead of StartWebhookServerAndWait().
+//
+// Incoming webhooks will be received through `webhookHandler` function. (can be nil when handlers are added with AddUpdateHandler())
+//
+//	client.SetWebhook("example.com", 443, nil)
+//
+//	mux := http.NewServeMux()
+//	mux.Handle(client.WebhookPath(), client.WebhookHandler(handleUpdate))
+//	mux.HandleFunc("/healthz", handleHealthz)
+//	http.ListenAndServe(":8080", mux) // behind a reverse proxy which terminates TLS
+func (b *Bot) WebhookHandler(webhookHandler func(b *Bot, webhook Update, err error)) http.Handler {
+	b.updateHandler = webhookHandler
+
+	return http.HandlerFunc(b.handleWebhook)
+}
+
+// SetWebhookSecretToken sets the secret token which webhook requests should carry,
+// or disables the verification if `token` is "".
+//
+// SetWebhook() sets it automatically (with one derived from the bot token if `secret_token` is not given),
+// so call this only when the webhook was set by another process with a known token.
+func (b *Bot) SetWebhookSecretToken(token string) {
+	b.webhookLock.Lock()
+	defer b.webhookLock.Unlock()
+
+	b.webhookSecretToken = token
+}
+
+// WebhookSecretToken returns the secret token which webhook requests should carry. ("" if not verified)
+func (b *Bot) WebhookSecretToken() string {
+	b.webhookLock.RLock()
+	defer b.webhookLock.RUnlock()
+
+	return b.webhookSecretToken
+}
+
+// derive the default secret token for webhook from the bot token (hex-encoded to 64 characters)
+//
+// NOTE: it is deterministic, so that all replicas of a bot which call SetWebhook() register and verify the same token
+func (b *Bot) defaultWebhookSecretToken() string {
+	mac := hmac.New(sha256.New, []byte(b.token))
+	mac.Write([]byte(webhookSecretTokenMessage))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetWebhookIPFilter allows webhook requests only from subnets of `filter`, or allows all of them if `filter` is nil.
+//
+// Requests from other addresses are rejected with 403, before their bodies are read.
+// It returns an error if a subnet is not in valid CIDR notation.
+//
+//	// behind a reverse proxy on localhost
+//	client.SetWebhookIPFilter(&WebhookIPFilter{TrustedProxies: []string{"127.0.0.1/32", "::1/128"}})
+func (b *Bot) SetWebhookIPFilter(filter *WebhookIPFilter) error {
+	if filter == nil {
+		b.webhookIPFilter = nil
+		return nil
+	}
+
+	subnets := filter.Subnets
+	if len(subnets) == 0 {
+		subnets = TelegramWebhookSubnets
+	}
+
+	parsed := &webhookIPFilter{}
+	var err error
+	if parsed.subnets, err = parseSubnets(subnets); err != nil {
+		return err
+	}
+	if parsed.trustedProxies, err = parseSubnets(filter.TrustedProxies); err != nil {
+		return err
+	}
+
+	b.webhookIPFilter = parsed
+	return nil
+}
+
+// parse subnets in CIDR notation
+func parseSubnets(cidrs []string) (subnets []*net.IPNet, err error) {
+	for _, cidr := range cidrs {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet '%s': %w", cidr, err)
+		}
+		subnets = append(subnets, subnet)
+	}
+	return subnets, nil
+}
+
+// check if any of given subnets contains the ip
+func subnetsContain(subnets []*net.IPNet, ip net.IP) bool {
+	for _, subnet := range subnets {
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// address of the client who sent given request (nil if unknown)
+func (f *webhookIPFilter) clientIP(req *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !subnetsContain(f.trustedProxies, ip) {
+		return ip
+	}
+
+	// NOTE: addresses are appended by each proxy, so the rightmost untrusted one is the client's
+	forwarded := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		forwardedIP := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if forwardedIP == nil {
+			return nil
+		}
+		if ip = forwardedIP; !subnetsContain(f.trustedProxies, ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+// check the source address and the secret token of a webhook request, and respond with 403 if they are not allowed
+func (b *Bot) verifyWebhookRequest(writer http.ResponseWriter, req *http.Request) bool {
+	if filter := b.webhookIPFilter; filter != nil {
+		if ip := filter.clientIP(req); ip == nil || !subnetsContain(filter.subnets, ip) {
+			b.error("rejected webhook request from a disallowed address: %s (forwarded for: %s)", req.RemoteAddr, req.Header.Get("X-Forwarded-For"))
+
+			http.Error(writer, "forbidden", http.StatusForbidden)
+			return false
+		}
+	}
+
+	secretToken := b.WebhookSecretToken()
+	if secretToken == "" {
+		return true
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.Header.Get(webhookSecretTokenHeader)), []byte(secretToken)) != 1 {
+		b.error("rejected webhook request with a mismatched secret token from: %s", req.RemoteAddr)
+
+		http.Error(writer, "forbidden", http.StatusForbidden)
+		return false
+	}
+	return true
+}
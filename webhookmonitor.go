@@ -0,0 +1,184 @@
+package telegrambot
+
+// Monitoring the health of the webhook with getWebhookInfo.
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// default values of WebhookMonitorConfig
+const (
+	defaultWebhookMonitorInterval = 1 * time.Minute
+	defaultMaxPendingUpdates      = 100
+)
+
+// WebhookAlertReason is a reason of webhook alerts
+type WebhookAlertReason string
+
+// WebhookAlertReason strings
+const (
+	WebhookAlertReasonCheckFailed   WebhookAlertReason = "check_failed"   // getWebhookInfo failed
+	WebhookAlertReasonURLMismatch   WebhookAlertReason = "url_mismatch"   // webhook url is empty or different from the expected one
+	WebhookAlertReasonBackedUp      WebhookAlertReason = "backed_up"      // too many pending updates
+	WebhookAlertReasonDeliveryError WebhookAlertReason = "delivery_error" // Telegram failed to deliver updates recently
+)
+
+// WebhookAlert is an alert from the webhook monitor
+type WebhookAlert struct {
+	Reason WebhookAlertReason
+	Info   *WebhookInfo // nil if getWebhookInfo failed
+	Err    error        // error of getWebhookInfo, or the webhook's last error
+
+	Reset    bool  // whether the webhook was re-registered
+	ResetErr error // error of re-registering the webhook
+}
+
+// Error returns the description of this alert.
+func (a WebhookAlert) Error() string {
+	switch a.Reason {
+	case WebhookAlertReasonCheckFailed:
+		return fmt.Sprintf("failed to get webhook info: %s", a.Err)
+	case WebhookAlertReasonURLMismatch:
+		return fmt.Sprintf("webhook url mismatch: '%s'", *a.Info.URL)
+	case WebhookAlertReasonBackedUp:
+		return fmt.Sprintf("webhook backed up: %d pending updates", a.Info.PendingUpdateCount)
+	case WebhookAlertReasonDeliveryError:
+		return fmt.Sprintf("webhook delivery error: %s", a.Err)
+	}
+	return string(a.Reason)
+}
+
+// WebhookMonitorConfig is a configuration for StartWebhookMonitor()
+type WebhookMonitorConfig struct {
+	Interval time.Duration // interval of checks (default: 1 minute)
+
+	ExpectedURL       string        // expected webhook url (default: the url registered with SetWebhook())
+	MaxPendingUpdates int           // pending updates over this count are alerted (default: 100)
+	MaxErrorAge       time.Duration // delivery errors newer than this are alerted (default: `Interval`)
+
+	// re-register the webhook with SetWebhook() when its url is empty or different from the expected one
+	//
+	// (SetWebhook() should have been called before, for its host and port)
+	ResetWebhook      bool
+	SetWebhookOptions OptionsSetWebhook // options for re-registering the webhook
+
+	OnAlert func(b *Bot, alert WebhookAlert) // called for each alert (alerts are logged when nil)
+}
+
+// fill default values of the configuration
+func (c WebhookMonitorConfig) withDefaults(b *Bot) WebhookMonitorConfig {
+	if c.Interval <= 0 {
+		c.Interval = defaultWebhookMonitorInterval
+	}
+	if c.ExpectedURL == "" {
+		_, _, c.ExpectedURL = b.webhookAddress()
+	}
+	if c.MaxPendingUpdates <= 0 {
+		c.MaxPendingUpdates = defaultMaxPendingUpdates
+	}
+	if c.MaxErrorAge <= 0 {
+		c.MaxErrorAge = c.Interval
+	}
+	return c
+}
+
+// StartWebhookMonitor starts a background monitor which checks the webhook with GetWebhookInfo() periodically,
+// and returns a function for stopping it.
+//
+// Alerts are fired when the webhook's url is empty (or different from the expected one), when updates are
+// backed up, or when Telegram failed to deliver updates recently.
+//
+//	stop := client.StartWebhookMonitor(WebhookMonitorConfig{
+//		ResetWebhook: true,
+//		OnAlert: func(b *Bot, alert WebhookAlert) {
+//			notifyOperators(alert.Error())
+//		},
+//	})
+//	defer stop()
+func (b *Bot) StartWebhookMonitor(config WebhookMonitorConfig) (stop func()) {
+	config = config.withDefaults(b)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				b.verbose("stopped webhook monitor")
+				return
+			case <-ticker.C:
+				for _, alert := range b.checkWebhook(config) {
+					if config.OnAlert != nil {
+						config.OnAlert(b, alert)
+					} else {
+						b.error("webhook monitor: %s", alert.Error())
+					}
+				}
+			}
+		}
+	}()
+
+	b.verbose("started webhook monitor (interval: %s)", config.Interval)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// check the webhook once, and return alerts
+func (b *Bot) checkWebhook(config WebhookMonitorConfig) (alerts []WebhookAlert) {
+	res := b.GetWebhookInfo()
+	if err := res.Err(); err != nil {
+		return []WebhookAlert{{Reason: WebhookAlertReasonCheckFailed, Err: err}}
+	}
+	info := res.Result
+
+	url := ""
+	if info.URL != nil {
+		url = *info.URL
+	} else {
+		info.URL = &url
+	}
+
+	if url == "" || (config.ExpectedURL != "" && url != config.ExpectedURL) {
+		alert := WebhookAlert{Reason: WebhookAlertReasonURLMismatch, Info: info}
+
+		if host, port, _ := b.webhookAddress(); config.ResetWebhook && host != "" {
+			options := OptionsSetWebhook{}
+			for k, v := range config.SetWebhookOptions {
+				options[k] = v
+			}
+			if _, exists := options["secret_token"]; !exists {
+				// NOTE: keep the current secret token, so updates in flight are not rejected
+				if secretToken := b.WebhookSecretToken(); secretToken != "" {
+					options.SetSecretToken(secretToken)
+				}
+			}
+
+			alert.Reset = true
+			alert.ResetErr = b.SetWebhook(host, port, options).Err()
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	if info.PendingUpdateCount > config.MaxPendingUpdates {
+		alerts = append(alerts, WebhookAlert{Reason: WebhookAlertReasonBackedUp, Info: info})
+	}
+
+	if info.LastErrorDate > 0 && time.Since(time.Unix(int64(info.LastErrorDate), 0)) <= config.MaxErrorAge {
+		message := "unknown error"
+		if info.LastErrorMessage != nil {
+			message = *info.LastErrorMessage
+		}
+		alerts = append(alerts, WebhookAlert{Reason: WebhookAlertReasonDeliveryError, Info: info, Err: errors.New(message)})
+	}
+
+	return alerts
+}
@@ -0,0 +1,155 @@
+package telegrambot
+
+// Handling updates concurrently with a pool of workers, optionally in order per chat.
+
+import (
+	"sync"
+)
+
+// default values of WorkerPoolConfig
+const (
+	defaultWorkerPoolWorkers   = 8
+	defaultWorkerPoolQueueSize = 16
+)
+
+// WorkerPoolConfig is a configuration for EnableWorkerPool()
+type WorkerPoolConfig struct {
+	Workers   int // number of goroutines which handle updates (default: 8)
+	QueueSize int // number of updates queued for each worker before receiving blocks (default: 16)
+
+	// handle updates of the same chat (or of the same user, if there is no chat) sequentially in order,
+	// and updates of different chats in parallel
+	//
+	// Updates are assigned to workers by their chats, so a slow update also delays other chats of the same worker.
+	OrderByChat bool
+}
+
+// fill default values of the config
+func (c WorkerPoolConfig) withDefaults() WorkerPoolConfig {
+	if c.Workers <= 0 {
+		c.Workers = defaultWorkerPoolWorkers
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultWorkerPoolQueueSize
+	}
+	return c
+}
+
+// WorkerPool handles received updates concurrently with a fixed number of workers.
+type WorkerPool struct {
+	bot    *Bot
+	config WorkerPoolConfig
+
+	queues     []chan queuedUpdate // queue of each worker (one shared queue if not ordered by chat)
+	wg         sync.WaitGroup
+	submitting sync.WaitGroup // submissions in progress
+
+	stopped bool
+	lock    sync.RWMutex
+}
+
+// an update queued for a worker, with a function called after it is handled (nil for none)
+type queuedUpdate struct {
+	update Update
+	done   func()
+}
+
+// EnableWorkerPool handles updates (from webhook, polling, or update sources) with a pool of workers,
+// so one slow handler does not stall the others, and returns the WorkerPool.
+//
+// Receiving updates blocks while queues of workers are full.
+// Errors of receiving updates are not queued, but handled immediately.
+//
+//	pool := client.EnableWorkerPool(WorkerPoolConfig{Workers: 16, OrderByChat: true})
+//	defer pool.Stop()
+func (b *Bot) EnableWorkerPool(config WorkerPoolConfig) *WorkerPool {
+	config = config.withDefaults()
+
+	p := &WorkerPool{
+		bot:    b,
+		config: config,
+	}
+
+	numQueues := 1
+	if config.OrderByChat {
+		numQueues = config.Workers
+	}
+	for i := 0; i < numQueues; i++ {
+		p.queues = append(p.queues, make(chan queuedUpdate, config.QueueSize))
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		queue := p.queues[i%numQueues]
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+
+			for queued := range queue {
+				b.handleReceivedUpdate(queued.update)
+				if queued.done != nil {
+					queued.done()
+				}
+			}
+		}()
+	}
+
+	b.workerPool = p
+
+	return p
+}
+
+// Stop stops receiving updates with this pool, and waits for queued updates to be handled.
+//
+// Updates received after it are handled without the pool.
+func (p *WorkerPool) Stop() {
+	p.lock.Lock()
+	if p.stopped {
+		p.lock.Unlock()
+		return
+	}
+	p.stopped = true
+	p.lock.Unlock()
+
+	p.submitting.Wait() // NOTE: queues are closed after submissions in progress are queued
+	for _, queue := range p.queues {
+		close(queue)
+	}
+
+	p.wg.Wait()
+}
+
+// queue an update for a worker, and return whether it was queued or not (when stopped)
+//
+// `done` (if not nil) is called by the worker after the update is handled.
+func (p *WorkerPool) submit(update Update, done func()) bool {
+	p.lock.RLock()
+	if p.stopped {
+		p.lock.RUnlock()
+		return false
+	}
+	p.submitting.Add(1)
+	p.lock.RUnlock()
+
+	defer p.submitting.Done()
+
+	p.queues[p.queueIndex(update)] <- queuedUpdate{update: update, done: done} // NOTE: blocks while the queue is full
+	return true
+}
+
+// index of the queue for given update
+func (p *WorkerPool) queueIndex(update Update) int {
+	if len(p.queues) == 1 {
+		return 0
+	}
+
+	var key int64
+	if chat := update.EffectiveChat(); chat != nil {
+		key = chat.ID
+	} else if user := update.EffectiveUser(); user != nil {
+		key = user.ID
+	} else {
+		key = update.UpdateID
+	}
+	return int(uint64(key) % uint64(len(p.queues)))
+}